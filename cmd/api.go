@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
+	"github.com/spf13/cobra"
+)
+
+var (
+	apiRawFields   []string
+	apiTypedFields []string
+	apiInputFile   string
+	apiPaginate    bool
+)
+
+var apiCmd = &cobra.Command{
+	Use:   "api [<query>]",
+	Short: "Make an authenticated GraphQL request",
+	Long: `Send a GraphQL query or mutation to the Linear API using your stored
+credentials and print the "data" object of the response as JSON.
+
+The query is read from the argument, or from a file with --input
+(use '-' for stdin).
+
+Pass variables with -f key=value for strings, or -F key=value to convert
+true, false, null, numbers and JSON objects or arrays. With -F, a value
+starting with '@' is read from a file (e.g. -F description=@notes.md).
+
+--paginate follows the pageInfo of the first connection in the response.
+The query must accept an $endCursor variable and request
+pageInfo { hasNextPage endCursor }; the nodes of all pages are combined
+into one result.
+
+Examples:
+  linear api 'query { viewer { id name email } }'
+  linear api 'query($id: String!) { issue(id: $id) { title } }' -f id=ENG-123
+  linear api --input cycles.graphql -F first=50 --paginate
+  linear api 'query { teams { nodes { key } } }' --jq '.teams.nodes[].key'`,
+	Args: usageArgs(cobra.MaximumNArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query, err := readAPIQuery(args)
+		if err != nil {
+			return err
+		}
+
+		variables, err := parseAPIFields(apiRawFields, apiTypedFields)
+		if err != nil {
+			return err
+		}
+
+		if apiPaginate && !endCursorPattern.MatchString(query) {
+			return usageErrorf("--paginate requires the query to accept an $endCursor variable")
+		}
+
+		ws, err := openWorkspace()
+		if err != nil {
+			return err
+		}
+		c := ws.client
+
+		ctx := cmd.Context()
+
+		var data json.RawMessage
+		err = c.Do(ctx, query, variables, &data)
+		if linear.IsMutation(query) {
+			// There's no telling what a mutation changed, and a failed
+			// one may still have made some of its changes
+			ws.invalidate(cachedKinds()...)
+		}
+		if err != nil {
+			return err
+		}
+
+		if !apiPaginate {
+			return printAPIData(data)
+		}
+
+		var result any
+		if err := decodeJSON(data, &result); err != nil {
+			return err
+		}
+
+		for page := 1; ; page++ {
+			pageInfo, err := findPageInfo(result)
+			if err != nil {
+				return err
+			}
+			if !pageInfo.HasNextPage {
+				break
+			}
+			if pageInfo.EndCursor == "" {
+				return fmt.Errorf("pagination error: hasNextPage is true but endCursor is empty")
+			}
+			if pageInfo.EndCursor == variables["endCursor"] {
+				return fmt.Errorf("pagination error: endCursor did not advance")
+			}
+
+			variables["endCursor"] = pageInfo.EndCursor
+
+			var next json.RawMessage
+			if err := c.Do(ctx, query, variables, &next); err != nil {
+				return partialAPIResult(result, fmt.Errorf("failed to fetch page %d: %w", page+1, err))
+			}
+
+			var nextResult any
+			if err := decodeJSON(next, &nextResult); err != nil {
+				return err
+			}
+			if err := mergePage(result, nextResult); err != nil {
+				return err
+			}
+		}
+
+		return printAPIData(result)
+	},
+}
+
+// endCursorPattern matches the $endCursor variable used by --paginate
+var endCursorPattern = regexp.MustCompile(`\$endCursor\b`)
+
+// readAPIQuery returns the query from the argument or --input
+func readAPIQuery(args []string) (string, error) {
+	if len(args) == 1 && apiInputFile != "" {
+		return "", usageErrorf("pass the query as an argument or with --input, not both")
+	}
+
+	if len(args) == 1 {
+		return args[0], nil
+	}
+	if apiInputFile == "" {
+		return "", usageErrorf("a query argument or --input is required")
+	}
+
+	var content []byte
+	var err error
+	if apiInputFile == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(apiInputFile)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read query: %w", err)
+	}
+	return string(content), nil
+}
+
+// parseAPIFields builds GraphQL variables from -f and -F flags
+func parseAPIFields(rawFields, typedFields []string) (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+
+	for _, field := range rawFields {
+		key, value, err := splitAPIField(field, "-f")
+		if err != nil {
+			return nil, err
+		}
+		variables[key] = value
+	}
+
+	for _, field := range typedFields {
+		key, value, err := splitAPIField(field, "-F")
+		if err != nil {
+			return nil, err
+		}
+		typed, err := parseTypedValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for -F %s: %w", key, err)
+		}
+		variables[key] = typed
+	}
+
+	return variables, nil
+}
+
+func splitAPIField(field, flag string) (string, string, error) {
+	key, value, found := strings.Cut(field, "=")
+	if !found || key == "" {
+		return "", "", usageErrorf("invalid %s value %q: expected key=value", flag, field)
+	}
+	return key, value, nil
+}
+
+// parseTypedValue converts a -F value into a JSON-compatible value
+func parseTypedValue(value string) (interface{}, error) {
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		var content []byte
+		var err error
+		if path == "-" {
+			content, err = io.ReadAll(os.Stdin)
+		} else {
+			content, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return string(content), nil
+	}
+
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f, nil
+	}
+
+	if strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[") {
+		var decoded interface{}
+		if err := decodeJSON([]byte(value), &decoded); err != nil {
+			return nil, err
+		}
+		return decoded, nil
+	}
+
+	return value, nil
+}
+
+// decodeJSON decodes data keeping numbers as json.Number, so large IDs and
+// floats print exactly as the API returned them
+func decodeJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// printAPIData prints the response data as JSON, or through --jq, a
+// template or another structured format
+func printAPIData(data any) error {
+	if usesHumanOutput() {
+		if raw, ok := data.(json.RawMessage); ok {
+			// Indent the raw response to keep the API's field order
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, raw, "", "  "); err != nil {
+				return err
+			}
+			buf.WriteString("\n")
+			_, err := output.Stdout.Write(buf.Bytes())
+			return err
+		}
+		return output.WriteJSON(output.Stdout, data)
+	}
+
+	if raw, ok := data.(json.RawMessage); ok {
+		var decoded any
+		if err := decodeJSON(raw, &decoded); err != nil {
+			return err
+		}
+		data = decoded
+	}
+	return printOutput(data, nil)
+}
+
+// partialAPIResult prints the pages fetched before a pagination failure
+func partialAPIResult(result any, err error) error {
+	if printErr := printAPIData(result); printErr != nil {
+		return printErr
+	}
+	return partialFailure(err, "results are incomplete")
+}
+
+// apiPageInfo is the pagination state of a GraphQL connection
+type apiPageInfo struct {
+	HasNextPage bool
+	EndCursor   string
+}
+
+// findPageInfo locates the pageInfo of the first connection in data,
+// searching object keys in sorted order
+func findPageInfo(data any) (apiPageInfo, error) {
+	connection, ok := findConnection(data)
+	if !ok {
+		return apiPageInfo{}, fmt.Errorf("--paginate: no pageInfo found in the response; request pageInfo { hasNextPage endCursor }")
+	}
+
+	info, _ := connection["pageInfo"].(map[string]any)
+	hasNext, _ := info["hasNextPage"].(bool)
+	cursor, _ := info["endCursor"].(string)
+	return apiPageInfo{HasNextPage: hasNext, EndCursor: cursor}, nil
+}
+
+// findConnection returns the first object with a pageInfo field
+func findConnection(data any) (map[string]any, bool) {
+	switch v := data.(type) {
+	case map[string]any:
+		if _, ok := v["pageInfo"].(map[string]any); ok {
+			return v, true
+		}
+		for _, key := range sortedKeys(v) {
+			if connection, ok := findConnection(v[key]); ok {
+				return connection, true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if connection, ok := findConnection(item); ok {
+				return connection, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// mergePage appends the nodes and edges of next's connection to result's
+// and takes over its pageInfo
+func mergePage(result, next any) error {
+	current, ok := findConnection(result)
+	if !ok {
+		return fmt.Errorf("--paginate: no pageInfo found in the response")
+	}
+	page, ok := findConnection(next)
+	if !ok {
+		return fmt.Errorf("--paginate: no pageInfo found in the next page")
+	}
+
+	for _, key := range []string{"nodes", "edges"} {
+		items, ok := page[key].([]any)
+		if !ok {
+			continue
+		}
+		existing, _ := current[key].([]any)
+		current[key] = append(existing, items...)
+	}
+	current["pageInfo"] = page["pageInfo"]
+	return nil
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	apiCmd.Flags().StringArrayVarP(&apiRawFields, "raw-field", "f", nil, "Add a string variable in key=value format")
+	apiCmd.Flags().StringArrayVarP(&apiTypedFields, "field", "F", nil, "Add a typed variable in key=value format (true, false, null, numbers, JSON, or @file)")
+	apiCmd.Flags().StringVar(&apiInputFile, "input", "", "Read the query from a file (use '-' for stdin)")
+	apiCmd.Flags().BoolVar(&apiPaginate, "paginate", false, "Fetch all pages by following pageInfo.endCursor")
+
+	rootCmd.AddCommand(apiCmd)
+}
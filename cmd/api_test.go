@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseAPIFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(path, []byte("# Notes\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	variables, err := parseAPIFields(
+		[]string{"id=ENG-123", "count=3", "empty="},
+		[]string{"first=50", "ratio=0.5", "archived=false", "cursor=null", "filter={\"team\":{\"key\":{\"eq\":\"ENG\"}}}", "ids=[1,2]", "description=@" + path, "name=plain"},
+	)
+	if err != nil {
+		t.Fatalf("parseAPIFields() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"id":          "ENG-123",
+		"count":       "3",
+		"empty":       "",
+		"first":       int64(50),
+		"ratio":       0.5,
+		"archived":    false,
+		"cursor":      nil,
+		"filter":      map[string]any{"team": map[string]any{"key": map[string]any{"eq": "ENG"}}},
+		"ids":         []any{json.Number("1"), json.Number("2")},
+		"description": "# Notes\n",
+		"name":        "plain",
+	}
+	if !reflect.DeepEqual(variables, want) {
+		t.Errorf("parseAPIFields() =\n%#v\nwant\n%#v", variables, want)
+	}
+}
+
+func TestParseAPIFields_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   []string
+		typed []string
+	}{
+		{name: "missing equals", raw: []string{"id"}},
+		{name: "missing key", typed: []string{"=3"}},
+		{name: "bad JSON", typed: []string{"filter={nope"}},
+		{name: "missing file", typed: []string{"body=@" + filepath.Join(os.TempDir(), "does-not-exist.md")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseAPIFields(tt.raw, tt.typed); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestPaginationHelpers(t *testing.T) {
+	var first, second any
+	if err := decodeJSON([]byte(`{"team":{"issues":{"nodes":[{"id":"a"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}`), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := decodeJSON([]byte(`{"team":{"issues":{"nodes":[{"id":"b"}],"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}}}`), &second); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := findPageInfo(first)
+	if err != nil {
+		t.Fatalf("findPageInfo() error = %v", err)
+	}
+	if !info.HasNextPage || info.EndCursor != "c1" {
+		t.Errorf("findPageInfo() = %+v", info)
+	}
+
+	if err := mergePage(first, second); err != nil {
+		t.Fatalf("mergePage() error = %v", err)
+	}
+
+	merged, _ := json.Marshal(first)
+	want := `{"team":{"issues":{"nodes":[{"id":"a"},{"id":"b"}],"pageInfo":{"endCursor":"c2","hasNextPage":false}}}}`
+	if string(merged) != want {
+		t.Errorf("merged = %s, want %s", merged, want)
+	}
+
+	if _, err := findPageInfo(map[string]any{"viewer": map[string]any{"id": "u"}}); err == nil {
+		t.Error("expected an error when the response has no pageInfo")
+	}
+}
+
+func TestEndCursorPattern(t *testing.T) {
+	if !endCursorPattern.MatchString(`query($endCursor: String) { issues(after: $endCursor) { nodes { id } } }`) {
+		t.Error("expected $endCursor to match")
+	}
+	if endCursorPattern.MatchString(`query($endCursorX: String) { viewer { id } }`) {
+		t.Error("expected $endCursorX not to match")
+	}
+}
@@ -48,7 +48,9 @@ func TestAuthStatus_Authenticated(t *testing.T) {
 	}
 
 	output := captureStdout(t, func() {
-		authStatusCmd.Run(authStatusCmd, nil)
+		if err := authStatusCmd.RunE(authStatusCmd, nil); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
 	})
 
 	if !strings.Contains(output, "Status: Authenticated") {
@@ -70,7 +72,9 @@ func TestAuthStatus_UnauthenticatedShowsDetails(t *testing.T) {
 	}
 
 	output := captureStdout(t, func() {
-		authStatusCmd.Run(authStatusCmd, nil)
+		if err := authStatusCmd.RunE(authStatusCmd, nil); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
 	})
 
 	if !strings.Contains(output, "Status: Not authenticated") {
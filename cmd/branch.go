@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dukky/linear/internal/git"
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
+	"github.com/spf13/cobra"
+)
+
+var (
+	issueCheckoutStart    bool
+	issueCheckoutAssignMe bool
+)
+
+// issueIdentifierPattern matches issue identifiers such as eng-123 at the
+// start of a text or after a separator
+var issueIdentifierPattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])([a-z][a-z0-9]*-[0-9]+)`)
+
+// issueIdentifiers returns the issue identifiers in text, upper-cased.
+// When teamKeys is set only identifiers of those teams count, so that e.g.
+// release-2024 or UTF-8 are skipped.
+func issueIdentifiers(text string, teamKeys []string) []string {
+	var identifiers []string
+	for _, match := range issueIdentifierPattern.FindAllStringSubmatch(text, -1) {
+		identifier := strings.ToUpper(match[1])
+		key, _, _ := strings.Cut(identifier, "-")
+		if len(teamKeys) == 0 || slices.ContainsFunc(teamKeys, func(k string) bool { return strings.EqualFold(k, key) }) {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+	return identifiers
+}
+
+// identifierFromBranch finds the issue identifier in a branch name such as
+// ada/eng-123-fix-login, as issueIdentifiers does. It returns "" if there
+// is none.
+func identifierFromBranch(branch string, teamKeys []string) string {
+	if identifiers := issueIdentifiers(branch, teamKeys); len(identifiers) > 0 {
+		return identifiers[0]
+	}
+	return ""
+}
+
+// teamKeys returns the keys of the workspace's teams
+func teamKeys(ctx context.Context, ws *workspace) ([]string, error) {
+	teams, err := ws.teams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch teams: %w", err)
+	}
+	keys := make([]string, 0, len(teams))
+	for _, team := range teams {
+		keys = append(keys, team.Key)
+	}
+	return keys, nil
+}
+
+// issueArg returns the issue an argument refers to: "." is the issue the
+// current git branch is named after, anything else is used as it is
+func issueArg(ctx context.Context, ws *workspace, arg string) (string, error) {
+	if arg != "." {
+		return arg, nil
+	}
+
+	branch, err := git.CurrentBranch(ctx)
+	if errors.Is(err, git.ErrNotRepository) {
+		return "", withHint(usageErrorf(`"." refers to the issue of the current git branch, but this is not a git repository`), "Pass an issue ID such as ENG-123 instead")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the current git branch: %w", err)
+	}
+
+	keys, err := teamKeys(ctx, ws)
+	if err != nil {
+		return "", err
+	}
+
+	identifier := identifierFromBranch(branch, keys)
+	if identifier == "" {
+		return "", withHint(notFoundErrorf("branch %s is not named after an issue", branch), "Check out an issue's branch with 'linear issue checkout <issue-id>'")
+	}
+	return identifier, nil
+}
+
+// getIssue fetches an issue, failing if it does not exist
+func getIssue(ctx context.Context, ws *workspace, issueID string) (*linear.Issue, error) {
+	resp, err := ws.client.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue: %w", err)
+	}
+	if resp.Issue == nil {
+		return nil, notFoundErrorf("issue not found: %s", issueID)
+	}
+	return resp.Issue, nil
+}
+
+// issueBranch fetches an issue and its suggested git branch name
+func issueBranch(ctx context.Context, ws *workspace, arg string) (*linear.Issue, error) {
+	issueID, err := issueArg(ctx, ws, arg)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := getIssue(ctx, ws, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if issue.BranchName == "" {
+		return nil, fmt.Errorf("Linear did not suggest a branch name for %s", issue.Identifier)
+	}
+	return issue, nil
+}
+
+var issueBranchCmd = &cobra.Command{
+	Use:   "branch <issue-id>",
+	Short: "Print the git branch name of an issue",
+	Long: `Print the git branch name Linear suggests for an issue, as set up in
+your Linear preferences.
+
+Examples:
+  linear issue branch ENG-123
+  git switch -c "$(linear issue branch ENG-123)"`,
+	Args:              usageArgs(cobra.ExactArgs(1)),
+	ValidArgsFunction: completeIssueID,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ws, err := openWorkspace()
+		if err != nil {
+			return err
+		}
+
+		issue, err := issueBranch(cmd.Context(), ws, args[0])
+		if err != nil {
+			return err
+		}
+
+		if !usesHumanOutput() {
+			result := struct {
+				Identifier string `json:"identifier"`
+				BranchName string `json:"branchName"`
+			}{issue.Identifier, issue.BranchName}
+			table := output.NewTable([]string{"ID", "BRANCH"})
+			table.AddRow([]string{issue.Identifier, issue.BranchName})
+			return printOutput(result, table)
+		}
+
+		fmt.Fprintln(output.Stdout, issue.BranchName)
+		return nil
+	},
+}
+
+// checkoutResult is the structured output of issue checkout
+type checkoutResult struct {
+	Identifier string `json:"identifier"`
+	BranchName string `json:"branchName"`
+	// Created is set if the branch did not exist locally
+	Created  bool   `json:"created"`
+	State    string `json:"state,omitempty"`
+	Assignee string `json:"assignee,omitempty"`
+}
+
+var issueCheckoutCmd = &cobra.Command{
+	Use:   "checkout <issue-id>",
+	Short: "Switch to the git branch of an issue",
+	Long: `Switch to the git branch Linear suggests for an issue, creating it if
+needed. A branch that only exists on origin is checked out tracking it.
+
+--start moves the issue to its team's first started state, unless work on
+it has already started, and --assign-me assigns it to you. Neither
+changes the issue if switching branches fails.
+
+Examples:
+  linear issue checkout ENG-123
+  linear issue checkout ENG-123 --start --assign-me`,
+	Args:              usageArgs(cobra.ExactArgs(1)),
+	ValidArgsFunction: completeIssueID,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ws, err := openWorkspace()
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		issue, err := issueBranch(ctx, ws, args[0])
+		if err != nil {
+			return err
+		}
+
+		result := checkoutResult{Identifier: issue.Identifier, BranchName: issue.BranchName}
+		result.Created, err = switchBranch(ctx, issue.BranchName)
+		if err != nil {
+			return err
+		}
+
+		input := linear.UpdateIssueInput{}
+		changed := false
+
+		if issueCheckoutStart && issue.State != nil && issue.Team != nil {
+			switch issue.State.Type {
+			case "triage", "backlog", "unstarted":
+				started, err := firstStartedState(ctx, ws, issue.Team.ID)
+				if err != nil {
+					return err
+				}
+				input.StateID = &started.ID
+				result.State = started.Name
+				changed = true
+			}
+		}
+
+		if issueCheckoutAssignMe {
+			viewer, err := ws.viewer(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to fetch the authenticated user: %w", err)
+			}
+			if issue.Assignee == nil || issue.Assignee.ID != viewer.ID {
+				input.AssigneeID = &viewer.ID
+				result.Assignee = viewer.Name
+				changed = true
+			}
+		}
+
+		if changed {
+			resp, err := ws.client.UpdateIssue(ctx, issue.ID, input)
+			if err != nil {
+				return fmt.Errorf("failed to update issue: %w", err)
+			}
+			ws.invalidate("issues")
+			if !resp.IssueUpdate.Success {
+				return errors.New("failed to update issue")
+			}
+		}
+
+		if !usesHumanOutput() {
+			table := output.NewTable([]string{"ID", "BRANCH", "CREATED", "STATE", "ASSIGNEE"})
+			table.AddRow([]string{result.Identifier, result.BranchName, fmt.Sprint(result.Created), result.State, result.Assignee})
+			return printOutput(result, table)
+		}
+
+		if result.Created {
+			fmt.Fprintf(output.Stdout, "Switched to a new branch '%s'\n", result.BranchName)
+		} else {
+			fmt.Fprintf(output.Stdout, "Switched to branch '%s'\n", result.BranchName)
+		}
+		if result.State != "" {
+			fmt.Fprintf(output.Stdout, "Moved %s to %s\n", result.Identifier, result.State)
+		}
+		if result.Assignee != "" {
+			fmt.Fprintf(output.Stdout, "Assigned %s to %s\n", result.Identifier, result.Assignee)
+		}
+		return nil
+	},
+}
+
+// switchBranch checks out branch, creating it from origin's branch of the
+// same name or from HEAD if needed, and reports whether it was created
+func switchBranch(ctx context.Context, branch string) (bool, error) {
+	current, err := git.CurrentBranch(ctx)
+	if errors.Is(err, git.ErrNotRepository) {
+		return false, usageErrorf("issue checkout must be run inside a git repository")
+	}
+	if err == nil && current == branch {
+		return false, nil
+	}
+
+	local, err := git.HasRef(ctx, "refs/heads/"+branch)
+	if err != nil {
+		return false, err
+	}
+	if local {
+		return false, git.Switch(ctx, branch)
+	}
+
+	remote, err := git.HasRef(ctx, "refs/remotes/origin/"+branch)
+	if err != nil {
+		return false, err
+	}
+	if remote {
+		return true, git.SwitchTrack(ctx, "origin/"+branch)
+	}
+	return true, git.SwitchNew(ctx, branch)
+}
+
+// firstStartedState returns the earliest started state in a team's
+// workflow
+func firstStartedState(ctx context.Context, ws *workspace, teamID string) (*linear.WorkflowState, error) {
+	states, err := ws.states(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workflow states: %w", err)
+	}
+
+	var first *linear.WorkflowState
+	for i, state := range states {
+		if state.Type != "started" || state.Team == nil || state.Team.ID != teamID {
+			continue
+		}
+		if first == nil || state.Position < first.Position {
+			first = &states[i]
+		}
+	}
+	if first == nil {
+		return nil, errors.New("the issue's team has no started workflow state")
+	}
+	return first, nil
+}
+
+var issueCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "View the issue of the current git branch",
+	Long: `View the issue the current git branch is named after, as found by
+looking for an identifier such as ENG-123 in the branch name. Other issue
+commands accept "." to mean the same issue.
+
+Examples:
+  linear issue current
+  linear issue update . --priority 1`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ws, err := openWorkspace()
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		issueID, err := issueArg(ctx, ws, ".")
+		if err != nil {
+			return err
+		}
+
+		issue, err := getIssue(ctx, ws, issueID)
+		if err != nil {
+			return err
+		}
+		return printIssue(issue)
+	},
+}
+
+func init() {
+	issueCheckoutCmd.Flags().BoolVar(&issueCheckoutStart, "start", false, "Move the issue to its team's first started state")
+	issueCheckoutCmd.Flags().BoolVar(&issueCheckoutAssignMe, "assign-me", false, "Assign the issue to yourself")
+	issueCurrentCmd.Flags().BoolVar(&issueViewRaw, "raw", false, "Print the description as raw Markdown")
+
+	issueCmd.AddCommand(issueBranchCmd)
+	issueCmd.AddCommand(issueCheckoutCmd)
+	issueCmd.AddCommand(issueCurrentCmd)
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+func TestIdentifierFromBranch(t *testing.T) {
+	tests := []struct {
+		branch   string
+		teamKeys []string
+		want     string
+	}{
+		{branch: "eng-1-fix-login", want: "ENG-1"},
+		{branch: "ada/eng-42-fix-login", want: "ENG-42"},
+		{branch: "feature/ENG-7", want: "ENG-7"},
+		{branch: "release-2024-eng-12", teamKeys: []string{"ENG"}, want: "ENG-12"},
+		{branch: "release-2024-eng-12", want: "RELEASE-2024"},
+		{branch: "ops-3-and-eng-4", teamKeys: []string{"eng"}, want: "ENG-4"},
+		{branch: "main", want: ""},
+		{branch: "fix-login", want: ""},
+		{branch: "release-2024", teamKeys: []string{"ENG"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := identifierFromBranch(tt.branch, tt.teamKeys); got != tt.want {
+			t.Errorf("identifierFromBranch(%q, %q) = %q, want %q", tt.branch, tt.teamKeys, got, tt.want)
+		}
+	}
+}
+
+// newGitRepo creates a repository with one commit on main and makes it
+// the current directory
+func newGitRepo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	t.Chdir(t.TempDir())
+	gitRun(t, "init", "--quiet", "--initial-branch", "main")
+	gitRun(t, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "Initial commit")
+}
+
+func gitRun(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestIssueBranch(t *testing.T) {
+	srv, team := newIssueServer(t)
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix login"})
+
+	out, err := runCLI(t, srv, "issue", "branch", "ENG-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "eng-1-fix-login\n" {
+		t.Errorf("output = %q, want the branch name alone", out)
+	}
+
+	out, err = runCLI(t, srv, "issue", "branch", "ENG-1", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, `"branchName": "eng-1-fix-login"`) {
+		t.Errorf("JSON output = %s", out)
+	}
+}
+
+func TestIssueCheckout(t *testing.T) {
+	srv, team := newIssueServer(t)
+	issue := srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix login"})
+	newGitRepo(t)
+
+	out, err := runCLI(t, srv, "issue", "checkout", "ENG-1", "--start", "--assign-me")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Switched to a new branch 'eng-1-fix-login'", "Moved ENG-1 to In Progress", "Assigned ENG-1 to Ada Lovelace"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if branch := gitRun(t, "branch", "--show-current"); branch != "eng-1-fix-login" {
+		t.Errorf("current branch = %q", branch)
+	}
+	got, _ := srv.Issue(issue.ID)
+	if state := stateOf(srv, got); state != "In Progress" || got.AssigneeID == "" {
+		t.Errorf("issue is %q and assigned to %q, want In Progress and assigned", state, got.AssigneeID)
+	}
+
+	// Checking out again switches to the existing branch and leaves the
+	// started, assigned issue alone
+	gitRun(t, "switch", "--quiet", "main")
+	out, err = runCLI(t, srv, "issue", "checkout", "ENG-1", "--start", "--assign-me")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(out) != "Switched to branch 'eng-1-fix-login'" {
+		t.Errorf("output = %q, want only the switch", out)
+	}
+	if branch := gitRun(t, "branch", "--show-current"); branch != "eng-1-fix-login" {
+		t.Errorf("current branch = %q", branch)
+	}
+}
+
+func TestIssueCheckout_FailsOutsideRepository(t *testing.T) {
+	srv, team := newIssueServer(t)
+	issue := srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix login"})
+	t.Chdir(t.TempDir())
+	t.Setenv("GIT_CEILING_DIRECTORIES", t.TempDir()+"/..")
+
+	_, err := runCLI(t, srv, "issue", "checkout", "ENG-1", "--start")
+	if err == nil || classifyError(err) != codeUsage {
+		t.Errorf("error = %v, want a usage error", err)
+	}
+	if got, _ := srv.Issue(issue.ID); stateOf(srv, got) == "In Progress" {
+		t.Error("the issue was started although no branch was checked out")
+	}
+}
+
+func TestIssueCurrent(t *testing.T) {
+	srv, team := newIssueServer(t)
+	issue := srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix login"})
+	newGitRepo(t)
+
+	if _, err := runCLI(t, srv, "issue", "current"); err == nil || classifyError(err) != codeNotFound {
+		t.Errorf("error = %v on main, want not found", err)
+	}
+
+	gitRun(t, "switch", "--quiet", "--create", "ada/eng-1-fix-login")
+	out, err := runCLI(t, srv, "issue", "current")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "ID:          ENG-1") || !strings.Contains(out, "Title:       Fix login") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	// "." stands for the same issue
+	if _, err := runCLI(t, srv, "issue", "update", ".", "--priority", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := srv.Issue(issue.ID); got.Priority != 2 {
+		t.Errorf("priority = %d, want 2", got.Priority)
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/dukky/linear/internal/cache"
+	"github.com/dukky/linear/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local cache",
+	Long: `Teams, users, projects, workflow states and labels are cached on disk so
+that commands don't look them up again on every run, and shell completion
+stays fast. Each workspace has its own cache.
+
+Teams, users, states and labels are kept for an hour, projects for 10
+minutes and recent issues (for completion) for a minute. Creating or
+updating issues, and mutations sent with 'linear api', drop the entries
+they may have changed. Use --no-cache on any command to bypass the cache.
+
+Set LINEAR_CACHE_DIR to move the cache directory.`,
+}
+
+// cacheEntryStatus describes a cache entry in 'cache status' output
+type cacheEntryStatus struct {
+	Key       string    `json:"key"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Fresh     bool      `json:"fresh"`
+	Size      int64     `json:"size"`
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show what is cached for the current workspace",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := apiKey()
+		if err != nil {
+			return err
+		}
+		dir, err := workspaceCacheDir(key)
+		if err != nil {
+			return err
+		}
+		entries, err := cache.New(dir).Entries()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		statuses := make([]cacheEntryStatus, 0, len(entries))
+		table := output.NewTable([]string{"ENTRY", "FETCHED", "STATUS", "SIZE"})
+		for _, e := range entries {
+			status := cacheEntryStatus{
+				Key:       e.Key,
+				FetchedAt: e.FetchedAt,
+				ExpiresAt: e.FetchedAt.Add(cacheTTL(e.Key)),
+				Size:      e.Size,
+			}
+			status.Fresh = now.Before(status.ExpiresAt)
+			statuses = append(statuses, status)
+
+			freshness := "stale"
+			if status.Fresh {
+				freshness = "fresh"
+			}
+			table.AddRow([]string{e.Key, output.FormatTimeAgo(now.Sub(e.FetchedAt)), freshness, strconv.FormatInt(e.Size, 10) + " B"})
+		}
+
+		if !usesHumanOutput() {
+			return printOutput(statuses, table)
+		}
+
+		fmt.Fprintf(output.Stdout, "Cache directory: %s\n", dir)
+		if noCacheFlag {
+			fmt.Fprintf(output.Stdout, "The cache is bypassed because of --no-cache\n")
+		}
+		if len(entries) == 0 {
+			fmt.Fprintf(output.Stdout, "Nothing is cached yet\n")
+			return nil
+		}
+		fmt.Fprintln(output.Stdout)
+		return printOutput(statuses, table)
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached data",
+	Long:  "Remove cached data for every workspace",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := cache.Dir()
+		if err != nil {
+			return err
+		}
+		dirs, err := os.ReadDir(root)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read cache directory: %w", err)
+		}
+
+		removed := 0
+		for _, d := range dirs {
+			if !d.IsDir() {
+				continue
+			}
+			n, err := cache.New(filepath.Join(root, d.Name())).Clear()
+			removed += n
+			if err != nil {
+				return err
+			}
+		}
+
+		switch removed {
+		case 0:
+			fmt.Fprintf(output.Stdout, "The cache is already empty\n")
+		case 1:
+			fmt.Fprintf(output.Stdout, "Removed 1 cache entry\n")
+		default:
+			fmt.Fprintf(output.Stdout, "Removed %d cache entries\n", removed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
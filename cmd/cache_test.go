@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+// requestedFields lists the root fields of the requests a fake server
+// received after the first skip requests
+func requestedFields(srv *lineartest.Server, skip int) []string {
+	var fields []string
+	for _, req := range srv.Requests()[skip:] {
+		fields = append(fields, req.Fields...)
+	}
+	return fields
+}
+
+// cachedKeys returns the keys listed by 'cache status'
+func cachedKeys(t *testing.T, srv *lineartest.Server) []string {
+	t.Helper()
+	out, err := runCLI(t, srv, "cache", "status", "--json")
+	if err != nil {
+		t.Fatalf("cache status: %v", err)
+	}
+	var entries []cacheEntryStatus
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	var keys []string
+	for _, e := range entries {
+		if !e.Fresh {
+			t.Errorf("entry %s is stale right after it was fetched", e.Key)
+		}
+		keys = append(keys, e.Key)
+	}
+	return keys
+}
+
+func TestReferenceCache(t *testing.T) {
+	keepCache(t)
+	srv, _ := newIssueServer(t)
+
+	create := []string{"issue", "create", "--team", "ENG", "--title", "Cached", "--project", "Mobile App", "--assignee", "bob@example.com", "--json"}
+	if _, err := runCLI(t, srv, create...); err != nil {
+		t.Fatalf("issue create: %v", err)
+	}
+	if got := strings.Join(cachedKeys(t, srv), ","); !strings.HasPrefix(got, "projects-") || !strings.HasSuffix(got, ",teams,users") {
+		t.Errorf("cached %s, want the team's projects, teams and users", got)
+	}
+
+	seen := len(srv.Requests())
+	if _, err := runCLI(t, srv, create...); err != nil {
+		t.Fatalf("issue create: %v", err)
+	}
+	if got := requestedFields(srv, seen); !slices.Equal(got, []string{"issueCreate"}) {
+		t.Errorf("second create requested %v, want only issueCreate", got)
+	}
+
+	// --no-cache looks everything up again
+	seen = len(srv.Requests())
+	if _, err := runCLI(t, srv, append(create, "--no-cache")...); err != nil {
+		t.Fatalf("issue create --no-cache: %v", err)
+	}
+	if got := requestedFields(srv, seen); len(got) != 4 {
+		t.Errorf("create with --no-cache requested %v, want team, project and user lookups", got)
+	}
+}
+
+func TestReferenceCache_FallsBackForUnknownValues(t *testing.T) {
+	keepCache(t)
+	srv, _ := newIssueServer(t)
+
+	if _, err := runCLI(t, srv, "project", "list", "--team", "ENG"); err != nil {
+		t.Fatalf("project list: %v", err)
+	}
+
+	// A team created after the cache was filled is still found
+	srv.AddTeam(lineartest.Team{Key: "OPS", Name: "Operations"})
+	if _, err := runCLI(t, srv, "project", "list", "--team", "OPS"); err != nil {
+		t.Errorf("project list for a new team: %v", err)
+	}
+
+	_, err := runCLI(t, srv, "project", "list", "--team", "NOPE")
+	if code := classifyError(err); code != codeNotFound {
+		t.Errorf("classifyError(%v) = %s, want %s", err, code, codeNotFound)
+	}
+}
+
+func TestReferenceCache_Invalidation(t *testing.T) {
+	keepCache(t)
+	srv, team := newIssueServer(t)
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "First"})
+
+	complete(t, srv, "issue", "view", "")
+	complete(t, srv, "issue", "view", "ENG-")
+	complete(t, srv, "issue", "list", "--team", "")
+	if got := strings.Join(cachedKeys(t, srv), ","); got != "issues,issues-ENG,teams" {
+		t.Fatalf("cached %s", got)
+	}
+
+	if _, err := runCLI(t, srv, "issue", "update", "ENG-1", "--title", "Renamed"); err != nil {
+		t.Fatalf("issue update: %v", err)
+	}
+	if got := strings.Join(cachedKeys(t, srv), ","); got != "teams" {
+		t.Errorf("cached %s after an update, want teams", got)
+	}
+
+	if _, err := runCLI(t, srv, "api", "mutation { issueUpdate(id: \"ENG-1\", input: { title: \"Again\" }) { success } }"); err != nil {
+		t.Fatalf("api: %v", err)
+	}
+	if got := cachedKeys(t, srv); len(got) != 0 {
+		t.Errorf("cached %v after an api mutation, want nothing", got)
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	keepCache(t)
+	srv, _ := newIssueServer(t)
+
+	complete(t, srv, "issue", "list", "--team", "")
+	complete(t, srv, "issue", "create", "--assignee", "")
+
+	out, err := runCLI(t, srv, "cache", "clear")
+	if err != nil {
+		t.Fatalf("cache clear: %v", err)
+	}
+	if out != "Removed 2 cache entries\n" {
+		t.Errorf("cache clear printed %q", out)
+	}
+	if got := cachedKeys(t, srv); len(got) != 0 {
+		t.Errorf("cached %v after cache clear", got)
+	}
+
+	out, err = runCLI(t, srv, "cache", "status")
+	if err != nil {
+		t.Fatalf("cache status: %v", err)
+	}
+	if !strings.Contains(out, "Nothing is cached yet") {
+		t.Errorf("cache status printed %q", out)
+	}
+}
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dukky/linear/internal/git"
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changelogTeam    string
+	changelogSince   string
+	changelogUntil   string
+	changelogGroupBy string
+	changelogGitLog  string
+)
+
+// changelogKinds are the sections issues are grouped into by label, in
+// order, with the words that put a label in each
+var changelogKinds = []struct {
+	title string
+	words []string
+}{
+	{"Features", []string{"feature", "feat", "enhancement", "improvement"}},
+	{"Bugs", []string{"bug", "fix", "defect", "regression"}},
+	{"Chores", []string{"chore", "maintenance", "refactor", "tech debt", "tech-debt", "dependencies"}},
+}
+
+// changelog is the structured output of report changelog
+type changelog struct {
+	Since    *time.Time         `json:"since,omitempty"`
+	Until    *time.Time         `json:"until,omitempty"`
+	Range    string             `json:"range,omitempty"`
+	Sections []changelogSection `json:"sections"`
+}
+
+// changelogSection is a group of issues in a changelog
+type changelogSection struct {
+	Title  string         `json:"title"`
+	Issues []linear.Issue `json:"issues"`
+}
+
+var reportChangelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "List issues completed between two dates, for release notes",
+	Long: `List the issues completed in a time window as release notes, grouped
+into Features, Bugs and Chores by their labels, or by project.
+
+--since and --until take a date, a duration back from now such as 14d, or
+a git tag, which stands for the date of its commit; --until defaults to
+now. Alternatively --git-log lists exactly the issues referenced by the
+commits in a git range, whatever their state.
+
+The notes are printed as Markdown; --format json and --template give the
+sections and their issues for other tools.
+
+Examples:
+  linear report changelog --team ENG --since 2025-09-01
+  linear report changelog --team ENG --since v1.4 --until v1.5 --group-by project
+  linear report changelog --git-log v1.4..HEAD
+  linear report changelog --team ENG --since 14d --format json`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if changelogGroupBy != "label" && changelogGroupBy != "project" {
+			return usageErrorf("--group-by must be label or project")
+		}
+		if changelogGitLog == "" && changelogSince == "" {
+			return withHint(usageErrorf("--since or --git-log is required"), "Pass a date such as --since 2025-09-01, or a git range such as --git-log v1.4..HEAD")
+		}
+		if changelogGitLog != "" && (changelogSince != "" || changelogUntil != "") {
+			return usageErrorf("--git-log cannot be combined with --since or --until")
+		}
+
+		ws, err := openWorkspace()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+
+		var team *linear.Team
+		if changelogTeam != "" {
+			team, err = resolveTeam(ctx, ws, changelogTeam)
+			if err != nil {
+				return err
+			}
+		}
+
+		var result changelog
+		var issues []linear.Issue
+		if changelogGitLog != "" {
+			result.Range = changelogGitLog
+			issues, err = changelogFromGit(ctx, ws, team, changelogGitLog)
+		} else {
+			var since, until time.Time
+			since, err = parseReportTime(ctx, "--since", changelogSince, false)
+			if err != nil {
+				return err
+			}
+			until = now()
+			if changelogUntil != "" {
+				until, err = parseReportTime(ctx, "--until", changelogUntil, true)
+				if err != nil {
+					return err
+				}
+			}
+			if !since.Before(until) {
+				return usageErrorf("--since must be before --until")
+			}
+			result.Since, result.Until = &since, &until
+			issues, err = completedIssues(ctx, ws, team, since, until)
+		}
+		fatal, partial := partialOK(err)
+		if fatal != nil {
+			return fatal
+		}
+
+		if changelogGroupBy == "project" {
+			result.Sections = groupByProject(issues)
+		} else {
+			result.Sections = groupByKind(issues)
+		}
+
+		if usesHumanOutput() || (outputFormat == output.FormatMarkdown && outputTemplate == nil) {
+			writeChangelog(output.Stdout, result)
+			return partial
+		}
+
+		table := output.NewTable([]string{"SECTION", "ID", "TITLE", "COMPLETED", "URL"})
+		for _, section := range result.Sections {
+			for _, issue := range section.Issues {
+				completed := ""
+				if issue.CompletedAt != nil {
+					completed = *issue.CompletedAt
+				}
+				table.AddRow([]string{section.Title, issue.Identifier, issue.Title, completed, issue.URL})
+			}
+		}
+		if err := printOutput(result, table); err != nil {
+			return err
+		}
+		return partial
+	},
+}
+
+// completedIssues fetches the issues completed in [since, until), of a
+// team when one is given. If a page fails, the issues fetched so far are
+// returned with a partial failure.
+func completedIssues(ctx context.Context, ws *workspace, team *linear.Team, since, until time.Time) ([]linear.Issue, error) {
+	opts := linear.ListIssuesOptions{
+		Filter: linear.IssueFilter{}.CompletedAt(linear.Gte(since.UTC().Format(time.RFC3339)), linear.Lt(until.UTC().Format(time.RFC3339))),
+	}
+	if team != nil {
+		opts.TeamKey = team.Key
+	}
+	issues, err := ws.client.ListAllIssues(ctx, opts)
+	if err != nil {
+		return issues, issueFetchError(len(issues), err)
+	}
+	return issues, nil
+}
+
+// changelogFromGit fetches the issues referenced by the commits in a git
+// range, of a team when one is given, like completedIssues
+func changelogFromGit(ctx context.Context, ws *workspace, team *linear.Team, revisionRange string) ([]linear.Issue, error) {
+	messages, err := git.Messages(ctx, revisionRange)
+	if errors.Is(err, git.ErrNotRepository) {
+		return nil, usageErrorf("--git-log must be used inside a git repository")
+	}
+	if err != nil {
+		return nil, usageErrorf("failed to read git log: %v", err)
+	}
+
+	var keys []string
+	if team != nil {
+		keys = []string{team.Key}
+	} else if keys, err = teamKeys(ctx, ws); err != nil {
+		return nil, err
+	}
+
+	// Issue numbers by team key, each once
+	numbers := map[string][]int{}
+	for _, message := range messages {
+		for _, identifier := range issueIdentifiers(message, keys) {
+			key, n := splitIdentifier(identifier)
+			if !slices.Contains(numbers[key], n) {
+				numbers[key] = append(numbers[key], n)
+			}
+		}
+	}
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+
+	var byTeam []linear.IssueFilter
+	for _, key := range slices.Sorted(maps.Keys(numbers)) {
+		byTeam = append(byTeam, linear.IssueFilter{}.
+			Team(linear.TeamFilter{}.Key(linear.EqIgnoreCase(key))).
+			Number(linear.In(numbers[key]...)))
+	}
+	issues, err := ws.client.ListAllIssues(ctx, linear.ListIssuesOptions{Filter: linear.IssueFilter{}.Or(byTeam...)})
+	if err != nil {
+		return issues, issueFetchError(len(issues), err)
+	}
+	return issues, nil
+}
+
+// issueKind returns the changelog section an issue's labels put it in
+func issueKind(issue linear.Issue) string {
+	for _, kind := range changelogKinds {
+		for _, label := range issue.Labels.Nodes {
+			name := strings.ToLower(label.Name)
+			if slices.ContainsFunc(kind.words, func(word string) bool { return strings.Contains(name, word) }) {
+				return kind.title
+			}
+		}
+	}
+	return "Other"
+}
+
+// groupByKind sorts issues into Features, Bugs, Chores and Other, leaving
+// out empty sections
+func groupByKind(issues []linear.Issue) []changelogSection {
+	titles := []string{}
+	for _, kind := range changelogKinds {
+		titles = append(titles, kind.title)
+	}
+	titles = append(titles, "Other")
+	return group(issues, titles, issueKind)
+}
+
+// groupByProject sorts issues by project name, with issues outside any
+// project last
+func groupByProject(issues []linear.Issue) []changelogSection {
+	const noProject = "No project"
+	projectOf := func(issue linear.Issue) string {
+		if issue.Project == nil {
+			return noProject
+		}
+		return issue.Project.Name
+	}
+
+	var titles []string
+	for _, issue := range issues {
+		if name := projectOf(issue); name != noProject && !slices.Contains(titles, name) {
+			titles = append(titles, name)
+		}
+	}
+	slices.SortFunc(titles, func(a, b string) int { return cmp.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	return group(issues, append(titles, noProject), projectOf)
+}
+
+// group sorts issues into sections in the order of titles, each ordered by
+// completion time and identifier, leaving out empty sections
+func group(issues []linear.Issue, titles []string, sectionOf func(linear.Issue) string) []changelogSection {
+	sections := []changelogSection{}
+	for _, title := range titles {
+		var matched []linear.Issue
+		for _, issue := range issues {
+			if sectionOf(issue) == title {
+				matched = append(matched, issue)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		slices.SortStableFunc(matched, func(a, b linear.Issue) int {
+			return cmp.Or(cmp.Compare(completedAt(a), completedAt(b)), compareIdentifiers(a.Identifier, b.Identifier))
+		})
+		sections = append(sections, changelogSection{Title: title, Issues: matched})
+	}
+	return sections
+}
+
+// completedAt returns when an issue was completed, or "" if it is not
+func completedAt(issue linear.Issue) string {
+	if issue.CompletedAt == nil {
+		return ""
+	}
+	return *issue.CompletedAt
+}
+
+// writeChangelog writes release notes as Markdown
+func writeChangelog(w io.Writer, c changelog) {
+	switch {
+	case c.Range != "":
+		fmt.Fprintf(w, "## Changes in %s\n", c.Range)
+	case c.Since != nil && c.Until != nil:
+		// --until dates are exclusive ends of days, shown as the last day
+		last := c.Until.Add(-time.Nanosecond)
+		fmt.Fprintf(w, "## Changes from %s to %s\n", c.Since.Format(time.DateOnly), last.Format(time.DateOnly))
+	}
+
+	if len(c.Sections) == 0 {
+		fmt.Fprintf(w, "\nNo completed issues.\n")
+		return
+	}
+	for _, section := range c.Sections {
+		fmt.Fprintf(w, "\n### %s\n\n", section.Title)
+		for _, issue := range section.Issues {
+			fmt.Fprintf(w, "- %s ([%s](%s))\n", issue.Title, issue.Identifier, issue.URL)
+		}
+	}
+}
+
+func init() {
+	reportChangelogCmd.Flags().StringVar(&changelogTeam, "team", "", "Only include issues of this team (e.g., ENG)")
+	reportChangelogCmd.Flags().StringVar(&changelogSince, "since", "", "Start of the window: a date, a duration such as 14d, or a git tag")
+	reportChangelogCmd.Flags().StringVar(&changelogUntil, "until", "", "End of the window, like --since (default: now)")
+	reportChangelogCmd.Flags().StringVar(&changelogGroupBy, "group-by", "label", "Group issues by label (Features, Bugs, Chores) or project")
+	reportChangelogCmd.Flags().StringVar(&changelogGitLog, "git-log", "", "List the issues referenced by the commits in a git range, e.g. v1.4..HEAD")
+
+	_ = reportChangelogCmd.RegisterFlagCompletionFunc("team", completeTeams)
+	_ = reportChangelogCmd.RegisterFlagCompletionFunc("group-by", cobra.FixedCompletions([]string{"label", "project"}, cobra.ShellCompDirectiveNoFileComp))
+
+	reportCmd.AddCommand(reportChangelogCmd)
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+// newChangelogServer returns a server with ENG issues completed in
+// September 2025 and around it
+func newChangelogServer(t *testing.T) *lineartest.Server {
+	t.Helper()
+	fixNow(t, time.Date(2025, 10, 1, 12, 0, 0, 0, time.Local))
+
+	srv, team := newIssueServer(t)
+	mobile := srv.AddProject(lineartest.Project{Name: "Mobile Web", TeamIDs: []string{team.ID}}).ID
+	ops := srv.AddTeam(lineartest.Team{Key: "OPS", Name: "Operations"})
+	bug := srv.AddLabel(lineartest.Label{Name: "Bug"})
+	feature := srv.AddLabel(lineartest.Label{Name: "Feature request"})
+	done := srv.States(team.ID)[3].ID
+	completed := func(day int) time.Time { return time.Date(2025, 9, day, 10, 0, 0, 0, time.UTC) }
+
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix crash", LabelIDs: []string{bug.ID}, StateID: done, CompletedAt: completed(10)})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Dark mode", LabelIDs: []string{feature.ID}, StateID: done, CompletedAt: completed(5), ProjectID: mobile})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Old work", StateID: done, CompletedAt: time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC)})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Bump dependencies", StateID: done, CompletedAt: completed(15), ProjectID: mobile})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Unfinished"})
+	srv.AddIssue(lineartest.Issue{TeamID: ops.ID, Title: "Renew certificates", StateID: srv.States(ops.ID)[3].ID, CompletedAt: completed(12)})
+	return srv
+}
+
+func TestReportChangelog(t *testing.T) {
+	srv := newChangelogServer(t)
+
+	out, err := runCLI(t, srv, "report", "changelog", "--team", "ENG", "--since", "2025-09-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `## Changes from 2025-09-01 to 2025-10-01
+
+### Features
+
+- Dark mode ([ENG-2](https://linear.app/test/issue/ENG-2/dark-mode))
+
+### Bugs
+
+- Fix crash ([ENG-1](https://linear.app/test/issue/ENG-1/fix-crash))
+
+### Other
+
+- Bump dependencies ([ENG-4](https://linear.app/test/issue/ENG-4/bump-dependencies))
+`
+	if out != want {
+		t.Errorf("output:\n%s\nwant:\n%s", out, want)
+	}
+
+	out, err = runCLI(t, srv, "report", "changelog", "--since", "2025-09-01", "--until", "2025-09-30", "--group-by", "project", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got changelog
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	var sections []string
+	for _, section := range got.Sections {
+		var ids []string
+		for _, issue := range section.Issues {
+			ids = append(ids, issue.Identifier)
+		}
+		sections = append(sections, section.Title+": "+strings.Join(ids, ","))
+	}
+	if strings.Join(sections, "; ") != "Mobile Web: ENG-2,ENG-4; No project: ENG-1,OPS-1" {
+		t.Errorf("sections = %q", sections)
+	}
+}
+
+func TestReportChangelog_GitLog(t *testing.T) {
+	srv := newChangelogServer(t)
+	newGitRepo(t)
+	gitRun(t, "tag", "v1.4")
+	for _, message := range []string{"Fix crash\n\nRefs: ENG-1", "Start ENG-5", "Add UTF-8 support", "Mention eng-1 again"} {
+		gitRun(t, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", message)
+	}
+
+	out, err := runCLI(t, srv, "report", "changelog", "--git-log", "v1.4..HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## Changes in v1.4..HEAD", "### Bugs\n\n- Fix crash ([ENG-1]", "### Other\n\n- Unfinished ([ENG-5]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "ENG-1]") != 1 {
+		t.Errorf("ENG-1 is listed more than once:\n%s", out)
+	}
+}
+
+func TestReportChangelog_PartialFailure(t *testing.T) {
+	srv := newChangelogServer(t)
+	eng := srv.Issues()[0].TeamID
+	done := srv.States(eng)[3].ID
+	for i := 0; i < 100; i++ {
+		srv.AddIssue(lineartest.Issue{TeamID: eng, Title: "More work", StateID: done, CompletedAt: time.Date(2025, 9, 20, 10, 0, 0, 0, time.UTC)})
+	}
+	srv.Fail(lineartest.Fault{Operation: "issues", Status: http.StatusBadRequest, Message: "Query too complex", After: 1})
+
+	out, err := runCLI(t, srv, "report", "changelog", "--team", "ENG", "--since", "2025-09-01", "--json")
+	if code := classifyError(err); code != codePartialFailure {
+		t.Fatalf("classifyError(%v) = %s, want %s", err, code, codePartialFailure)
+	}
+
+	var got changelog
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	n := 0
+	for _, section := range got.Sections {
+		n += len(section.Issues)
+	}
+	if n != 100 {
+		t.Errorf("printed %d issues, want the first page of 100", n)
+	}
+}
+
+func TestReportChangelog_Usage(t *testing.T) {
+	srv := newChangelogServer(t)
+
+	for _, args := range [][]string{
+		{"report", "changelog"},
+		{"report", "changelog", "--since", "7d", "--group-by", "assignee"},
+		{"report", "changelog", "--since", "2025-09-10", "--until", "2025-09-01"},
+		{"report", "changelog", "--since", "7d", "--git-log", "HEAD~3..HEAD"},
+	} {
+		if _, err := runCLI(t, srv, args...); err == nil || classifyError(err) != codeUsage {
+			t.Errorf("linear %v: error = %v, want a usage error", args, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/dukky/linear/internal/auth"
+	"github.com/dukky/linear/linear"
+)
+
+var (
+	debugFlag     bool
+	traceFileFlag string
+	traceFile     io.WriteCloser
+)
+
+// userAgent identifies the CLI to the Linear API
+const userAgent = "linear-cli"
+
+// replayAPIKey stands in for credentials when replaying a cassette
+const replayAPIKey = "lin_api_replay"
+
+// newClient creates a Linear API client using the stored API key,
+// reporting missing or unreadable credentials as auth errors
+func newClient() (*linear.Client, error) {
+	key, err := apiKey()
+	if err != nil {
+		return nil, err
+	}
+	return newClientWithKey(key)
+}
+
+// newClientWithKey creates a Linear API client that authenticates with key
+func newClientWithKey(key string) (*linear.Client, error) {
+	opts, err := clientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return linear.NewClient(append([]linear.Option{linear.WithAPIKey(key)}, opts...)...)
+}
+
+// apiKey returns the stored API key, or a stand-in when replaying a cassette
+func apiKey() (string, error) {
+	if os.Getenv("LINEAR_REPLAY") != "" {
+		return replayAPIKey, nil
+	}
+	key, err := auth.GetAPIKey()
+	if err != nil {
+		return "", &cmdError{code: codeAuth, err: err}
+	}
+	return key, nil
+}
+
+// clientOptions returns the client options selected by global flags and
+// the environment
+func clientOptions() ([]linear.Option, error) {
+	opts := []linear.Option{
+		linear.WithUserAgent(userAgent),
+		linear.WithRequestTimeout(requestTimeout),
+	}
+	if endpoint := os.Getenv("LINEAR_API_URL"); endpoint != "" {
+		opts = append(opts, linear.WithEndpoint(endpoint))
+	}
+
+	base, err := cassetteTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	var trace linear.TraceOptions
+	if debugEnabled() {
+		trace.Log = os.Stderr
+	}
+	if traceFile != nil {
+		trace.Trace = traceFile
+	}
+	if trace.Log != nil || trace.Trace != nil {
+		base = linear.NewTracingTransport(base, trace)
+	}
+	if base != nil {
+		opts = append(opts, linear.WithTransport(base))
+	}
+	return opts, nil
+}
+
+// cassetteTransport returns the transport selected by LINEAR_RECORD, which
+// records interactions into a cassette file, or LINEAR_REPLAY, which
+// serves them back without a network; nil means the default transport
+func cassetteTransport() (http.RoundTripper, error) {
+	record, replay := os.Getenv("LINEAR_RECORD"), os.Getenv("LINEAR_REPLAY")
+	switch {
+	case record != "" && replay != "":
+		return nil, usageErrorf("LINEAR_RECORD and LINEAR_REPLAY cannot both be set")
+	case record != "":
+		return linear.NewRecordingTransport(nil, record)
+	case replay != "":
+		cassette, err := linear.LoadCassette(replay)
+		if err != nil {
+			return nil, err
+		}
+		return linear.NewReplayTransport(cassette), nil
+	}
+	return nil, nil
+}
+
+// debugEnabled reports whether --debug or LINEAR_DEBUG is set
+func debugEnabled() bool {
+	if debugFlag {
+		return true
+	}
+	enabled, _ := strconv.ParseBool(os.Getenv("LINEAR_DEBUG"))
+	return enabled
+}
+
+// openTraceFile opens --trace-file for appending
+func openTraceFile() error {
+	if traceFileFlag == "" {
+		return nil
+	}
+	f, err := os.OpenFile(traceFileFlag, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open trace file: %w", err)
+	}
+	traceFile = f
+	return nil
+}
+
+// closeTraceFile closes the trace file opened by openTraceFile
+func closeTraceFile() {
+	if traceFile != nil {
+		traceFile.Close()
+		traceFile = nil
+	}
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
+)
+
+// issueColumns are the columns available to issue list output
+var issueColumns = output.NewColumnSet(
+	[]string{"id", "title", "state", "assignee", "priority"},
+	output.Column[linear.Issue]{
+		Name:    "id",
+		Aliases: []string{"identifier"},
+		Header:  "ID",
+		Value:   func(i linear.Issue) string { return i.Identifier },
+		Compare: func(a, b linear.Issue) int { return compareIdentifiers(a.Identifier, b.Identifier) },
+	},
+	output.Column[linear.Issue]{
+		Name:     "title",
+		Header:   "TITLE",
+		MaxWidth: 50,
+		Value:    func(i linear.Issue) string { return i.Title },
+	},
+	output.Column[linear.Issue]{
+		Name:    "state",
+		Aliases: []string{"status"},
+		Header:  "STATUS",
+		Value: func(i linear.Issue) string {
+			if i.State == nil {
+				return "-"
+			}
+			return i.State.Name
+		},
+		Styled: func(i linear.Issue) string {
+			if i.State == nil {
+				return "-"
+			}
+			return output.Chip(i.State.Color, i.State.Name)
+		},
+		Compare: func(a, b linear.Issue) int {
+			return cmp.Or(
+				cmp.Compare(stateTypeRank(a.State), stateTypeRank(b.State)),
+				cmp.Compare(stateName(a.State), stateName(b.State)),
+			)
+		},
+	},
+	output.Column[linear.Issue]{
+		Name:   "assignee",
+		Header: "ASSIGNEE",
+		Value: func(i linear.Issue) string {
+			if i.Assignee == nil {
+				return "-"
+			}
+			return i.Assignee.Name
+		},
+	},
+	output.Column[linear.Issue]{
+		Name:   "priority",
+		Header: "PRIORITY",
+		Value: func(i linear.Issue) string {
+			if i.PriorityLabel == "" {
+				return "-"
+			}
+			return i.PriorityLabel
+		},
+		Styled: func(i linear.Issue) string {
+			if i.PriorityLabel == "" {
+				return output.PriorityIcon(i.Priority)
+			}
+			return output.PriorityIcon(i.Priority) + " " + i.PriorityLabel
+		},
+		Compare: func(a, b linear.Issue) int { return cmp.Compare(priorityRank(a.Priority), priorityRank(b.Priority)) },
+	},
+	output.Column[linear.Issue]{
+		Name:     "labels",
+		Header:   "LABELS",
+		MaxWidth: 30,
+		Value: func(i linear.Issue) string {
+			if len(i.Labels.Nodes) == 0 {
+				return "-"
+			}
+			names := make([]string, len(i.Labels.Nodes))
+			for j, label := range i.Labels.Nodes {
+				names[j] = label.Name
+			}
+			return strings.Join(names, ", ")
+		},
+		Styled: func(i linear.Issue) string {
+			if len(i.Labels.Nodes) == 0 {
+				return "-"
+			}
+			chips := make([]string, len(i.Labels.Nodes))
+			for j, label := range i.Labels.Nodes {
+				chips[j] = output.Chip(label.Color, label.Name)
+			}
+			return strings.Join(chips, " ")
+		},
+	},
+	output.Column[linear.Issue]{
+		Name:     "project",
+		Header:   "PROJECT",
+		MaxWidth: 30,
+		Value: func(i linear.Issue) string {
+			if i.Project == nil {
+				return "-"
+			}
+			return i.Project.Name
+		},
+	},
+	output.Column[linear.Issue]{
+		Name:   "team",
+		Header: "TEAM",
+		Value: func(i linear.Issue) string {
+			if i.Team == nil {
+				return "-"
+			}
+			return i.Team.Key
+		},
+	},
+	output.Column[linear.Issue]{
+		Name:   "estimate",
+		Header: "ESTIMATE",
+		Value: func(i linear.Issue) string {
+			if i.Estimate == nil {
+				return "-"
+			}
+			return strconv.FormatFloat(*i.Estimate, 'f', -1, 64)
+		},
+		Compare: func(a, b linear.Issue) int { return cmp.Compare(floatOrZero(a.Estimate), floatOrZero(b.Estimate)) },
+	},
+	output.Column[linear.Issue]{
+		Name:    "due",
+		Aliases: []string{"duedate"},
+		Header:  "DUE",
+		Value: func(i linear.Issue) string {
+			if i.DueDate == nil || *i.DueDate == "" {
+				return "-"
+			}
+			return *i.DueDate
+		},
+	},
+	output.Column[linear.Issue]{
+		Name:   "url",
+		Header: "URL",
+		Value:  func(i linear.Issue) string { return i.URL },
+	},
+	output.Column[linear.Issue]{
+		Name:    "created",
+		Aliases: []string{"createdat"},
+		Header:  "CREATED",
+		Value:   func(i linear.Issue) string { return i.CreatedAt },
+	},
+	output.Column[linear.Issue]{
+		Name:    "updated",
+		Aliases: []string{"updatedat"},
+		Header:  "UPDATED",
+		Value:   func(i linear.Issue) string { return i.UpdatedAt },
+	},
+)
+
+// projectColumns are the columns available to project list output
+var projectColumns = output.NewColumnSet(
+	[]string{"id", "name"},
+	output.Column[linear.Project]{
+		Name:   "id",
+		Header: "ID",
+		Value:  func(p linear.Project) string { return p.ID },
+	},
+	output.Column[linear.Project]{
+		Name:   "name",
+		Header: "NAME",
+		Value:  func(p linear.Project) string { return p.Name },
+	},
+)
+
+// teamColumns are the columns available to team list output
+var teamColumns = output.NewColumnSet(
+	[]string{"key", "name", "description"},
+	output.Column[linear.Team]{
+		Name:   "key",
+		Header: "KEY",
+		Value:  func(t linear.Team) string { return t.Key },
+	},
+	output.Column[linear.Team]{
+		Name:   "name",
+		Header: "NAME",
+		Value:  func(t linear.Team) string { return t.Name },
+	},
+	output.Column[linear.Team]{
+		Name:     "description",
+		Header:   "DESCRIPTION",
+		MaxWidth: 50,
+		Value: func(t linear.Team) string {
+			if t.Description == nil {
+				return ""
+			}
+			return *t.Description
+		},
+	},
+	output.Column[linear.Team]{
+		Name:   "id",
+		Header: "ID",
+		Value:  func(t linear.Team) string { return t.ID },
+	},
+)
+
+// compareIdentifiers orders issue identifiers by team key, then numerically
+// by issue number, so ENG-9 sorts before ENG-10
+func compareIdentifiers(a, b string) int {
+	aKey, aNum := splitIdentifier(a)
+	bKey, bNum := splitIdentifier(b)
+	return cmp.Or(cmp.Compare(aKey, bKey), cmp.Compare(aNum, bNum))
+}
+
+func splitIdentifier(identifier string) (string, int) {
+	key, number, found := strings.Cut(identifier, "-")
+	if !found {
+		return identifier, 0
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return identifier, 0
+	}
+	return key, n
+}
+
+// priorityRank maps Linear priorities (0=None, 1=Urgent ... 4=Low) onto an
+// ascending urgency scale, so sorting by -priority puts urgent issues first
+func priorityRank(priority int) int {
+	if priority <= 0 || priority > 4 {
+		return 0
+	}
+	return 5 - priority
+}
+
+// stateTypeRank orders workflow states the way they progress in Linear
+func stateTypeRank(state *linear.State) int {
+	if state == nil {
+		return -1
+	}
+	switch state.Type {
+	case "triage":
+		return 0
+	case "backlog":
+		return 1
+	case "unstarted":
+		return 2
+	case "started":
+		return 3
+	case "completed":
+		return 4
+	case "canceled":
+		return 5
+	default:
+		return 6
+	}
+}
+
+func stateName(state *linear.State) string {
+	if state == nil {
+		return ""
+	}
+	return strings.ToLower(state.Name)
+}
+
+func floatOrZero(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
+)
+
+func TestIssueColumns_SortByIdentifierIsNumeric(t *testing.T) {
+	issues := []linear.Issue{
+		{Identifier: "ENG-10"},
+		{Identifier: "ENG-9"},
+		{Identifier: "APP-100"},
+	}
+
+	keys, _ := output.ParseSortSpec([]string{"id"})
+	if err := issueColumns.Sort(issues, keys); err != nil {
+		t.Fatalf("Sort() error = %v", err)
+	}
+
+	got := []string{issues[0].Identifier, issues[1].Identifier, issues[2].Identifier}
+	if strings.Join(got, ",") != "APP-100,ENG-9,ENG-10" {
+		t.Errorf("sorted identifiers = %v", got)
+	}
+}
+
+func TestIssueColumns_SortByDescendingPriorityPutsUrgentFirst(t *testing.T) {
+	issues := []linear.Issue{
+		{Identifier: "ENG-1", Priority: 0},
+		{Identifier: "ENG-2", Priority: 4},
+		{Identifier: "ENG-3", Priority: 1},
+		{Identifier: "ENG-4", Priority: 2},
+	}
+
+	keys, _ := output.ParseSortSpec([]string{"-priority"})
+	if err := issueColumns.Sort(issues, keys); err != nil {
+		t.Fatalf("Sort() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.Identifier)
+	}
+	if strings.Join(got, ",") != "ENG-3,ENG-4,ENG-2,ENG-1" {
+		t.Errorf("sorted identifiers = %v", got)
+	}
+}
+
+func TestIssueColumns_OptionalValues(t *testing.T) {
+	estimate := 2.5
+	due := "2025-10-01"
+	issue := linear.Issue{
+		Estimate: &estimate,
+		DueDate:  &due,
+		Labels: struct {
+			Nodes []linear.Label `json:"nodes"`
+		}{Nodes: []linear.Label{{Name: "bug"}, {Name: "ui"}}},
+	}
+
+	cols, err := issueColumns.Select([]string{"estimate", "due", "labels", "project"})
+	if err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+
+	want := []string{"2.5", "2025-10-01", "bug, ui", "-"}
+	for i, col := range cols {
+		if got := col.Value(issue); got != want[i] {
+			t.Errorf("column %s = %q, want %q", col.Name, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dukky/linear/linear"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// completionTimeout bounds how long a tab press may wait on the API
+	completionTimeout = 5 * time.Second
+	// issueCompletionLimit is how many recent issues are offered
+	issueCompletionLimit = 100
+)
+
+// issueCandidates returns recent issue identifiers and titles, for one
+// team when teamKey is set
+func (w *workspace) issueCandidates(ctx context.Context, teamKey string) ([]string, error) {
+	key := "issues"
+	if teamKey != "" {
+		key += "-" + teamKey
+	}
+	return fetchCached(w, key, func() ([]string, error) {
+		resp, err := w.client.ListIssues(ctx, linear.ListIssuesOptions{TeamKey: teamKey, Limit: issueCompletionLimit})
+		if err != nil {
+			return nil, err
+		}
+		var candidates []string
+		for _, issue := range resp.Issues.Nodes {
+			candidates = append(candidates, candidate(issue.Identifier, issue.Title))
+		}
+		return candidates, nil
+	})
+}
+
+// candidate formats a completion with a description, which shells that
+// support them show next to the value
+func candidate(value, description string) string {
+	if description == "" {
+		return value
+	}
+	return cobra.CompletionWithDesc(value, strings.Join(strings.Fields(description), " "))
+}
+
+// completionFunc adapts a candidate lister to cobra. Candidates are
+// narrowed to those starting with the word being completed, ignoring case;
+// failures are logged to the completion debug log and offer nothing.
+func completionFunc(list func(ctx context.Context, cmd *cobra.Command, w *workspace, toComplete string) ([]string, error)) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		w, err := openWorkspace()
+		if err != nil {
+			cobra.CompDebugln(err.Error(), true)
+			return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveError
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+		defer cancel()
+
+		candidates, err := list(ctx, cmd, w, toComplete)
+		if err != nil {
+			cobra.CompDebugln(fmt.Sprintf("failed to fetch completions: %v", err), true)
+			return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveError
+		}
+
+		prefix := strings.ToLower(toComplete)
+		var matches []string
+		for _, cand := range candidates {
+			value, _, _ := strings.Cut(cand, "\t")
+			if strings.HasPrefix(strings.ToLower(value), prefix) {
+				matches = append(matches, cand)
+			}
+		}
+		return matches, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeTeams completes team keys
+var completeTeams = completionFunc(func(ctx context.Context, cmd *cobra.Command, w *workspace, toComplete string) ([]string, error) {
+	teams, err := w.teams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []string
+	for _, team := range teams {
+		candidates = append(candidates, candidate(team.Key, team.Name))
+	}
+	return candidates, nil
+})
+
+// completeProjects completes project names
+var completeProjects = completionFunc(func(ctx context.Context, cmd *cobra.Command, w *workspace, toComplete string) ([]string, error) {
+	projects, err := w.projects(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	var candidates []string
+	for _, project := range projects {
+		candidates = append(candidates, project.Name)
+	}
+	return candidates, nil
+})
+
+// completeUsers completes user email addresses, described by name
+var completeUsers = completionFunc(func(ctx context.Context, cmd *cobra.Command, w *workspace, toComplete string) ([]string, error) {
+	users, err := w.users(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []string
+	for _, user := range users {
+		if user.Email != "" {
+			candidates = append(candidates, candidate(user.Email, user.Name))
+		}
+	}
+	return candidates, nil
+})
+
+// completeStates completes workflow state names, only from the --team
+// team's workflow when that flag is set
+var completeStates = completionFunc(func(ctx context.Context, cmd *cobra.Command, w *workspace, toComplete string) ([]string, error) {
+	states, err := w.states(ctx)
+	if err != nil {
+		return nil, err
+	}
+	teamKey, _ := cmd.Flags().GetString("team")
+
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, state := range states {
+		if teamKey != "" && (state.Team == nil || !strings.EqualFold(state.Team.Key, teamKey)) {
+			continue
+		}
+		if seen[strings.ToLower(state.Name)] {
+			continue
+		}
+		seen[strings.ToLower(state.Name)] = true
+		candidates = append(candidates, candidate(state.Name, state.Type))
+	}
+	return candidates, nil
+})
+
+// completeLabels completes label names
+var completeLabels = completionFunc(func(ctx context.Context, cmd *cobra.Command, w *workspace, toComplete string) ([]string, error) {
+	labels, err := w.labels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, label := range labels {
+		if !seen[strings.ToLower(label.Name)] {
+			seen[strings.ToLower(label.Name)] = true
+			candidates = append(candidates, label.Name)
+		}
+	}
+	return candidates, nil
+})
+
+// teamPrefix matches the team key part of a partly typed identifier
+var teamPrefix = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9]*)-`)
+
+// completeIssueID completes the first argument with recent issue
+// identifiers, described by title; once a team key has been typed
+// (e.g. "ENG-") only that team's issues are fetched
+func completeIssueID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return issueCompletions(cmd, args, toComplete)
+}
+
+// issueCompletions lists recent issues for completeIssueID
+var issueCompletions = completionFunc(func(ctx context.Context, cmd *cobra.Command, w *workspace, toComplete string) ([]string, error) {
+	var teamKey string
+	if m := teamPrefix.FindStringSubmatch(toComplete); m != nil {
+		teamKey = strings.ToUpper(m[1])
+	}
+	return w.issueCandidates(ctx, teamKey)
+})
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+// complete runs cobra's hidden __complete command against a fake Linear
+// API and returns the candidates it printed, without the directive line
+func complete(t *testing.T, srv *lineartest.Server, args ...string) []string {
+	t.Helper()
+
+	t.Setenv("LINEAR_API_KEY", lineartest.DefaultAPIKey)
+	t.Setenv("LINEAR_API_URL", srv.URL)
+	setCacheDir(t)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(io.Discard)
+	defer func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		resetFlags(rootCmd)
+	}()
+
+	resetFlags(rootCmd)
+	rootCmd.SetArgs(append([]string{"__complete"}, args...))
+	if err := rootCmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("__complete %v: %v", args, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	return lines[:len(lines)-1]
+}
+
+func TestCompletion(t *testing.T) {
+	keepCache(t)
+
+	srv, eng := newIssueServer(t)
+	ops := srv.AddTeam(lineartest.Team{Key: "OPS", Name: "Operations"})
+	srv.AddLabel(lineartest.Label{Name: "Bug"})
+	srv.AddLabel(lineartest.Label{Name: "Bug", TeamID: ops.ID})
+	srv.AddLabel(lineartest.Label{Name: "Feature"})
+	srv.AddIssue(lineartest.Issue{TeamID: eng.ID, Title: "Crash on launch"})
+	srv.AddIssue(lineartest.Issue{TeamID: ops.ID, Title: "Renew TLS\ncertificates"})
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"team", []string{"issue", "list", "--team", ""}, []string{"ENG\tEngineering", "OPS\tOperations"}},
+		{"team prefix", []string{"project", "list", "--team", "o"}, []string{"OPS\tOperations"}},
+		{"project", []string{"issue", "create", "--project", "mob"}, []string{"Mobile App"}},
+		{"assignee", []string{"issue", "update", "ENG-1", "--assignee", "b"}, []string{"bob@example.com\tBob Smith"}},
+		{"state", []string{"issue", "list", "--state", "in"}, []string{"In Progress\tstarted"}},
+		{"label", []string{"issue", "list", "--label", ""}, []string{"Bug", "Feature"}},
+		{"issue", []string{"issue", "view", ""}, []string{"ENG-1\tCrash on launch", "OPS-1\tRenew TLS certificates"}},
+		{"issue in team", []string{"issue", "view", "ops-"}, []string{"OPS-1\tRenew TLS certificates"}},
+		{"second argument", []string{"issue", "view", "ENG-1", ""}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := complete(t, srv, tt.args...)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("completions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletion_StatesForTeam(t *testing.T) {
+	keepCache(t)
+
+	srv, eng := newIssueServer(t)
+	srv.AddTeam(lineartest.Team{Key: "OPS", Name: "Operations"})
+	got := complete(t, srv, "issue", "list", "--team", "ENG", "--state", "")
+	if len(got) != len(srv.States(eng.ID)) {
+		t.Errorf("completions = %q, want the %d ENG states", got, len(srv.States(eng.ID)))
+	}
+}
+
+func TestCompletion_CachedAndOffline(t *testing.T) {
+	keepCache(t)
+
+	srv, _ := newIssueServer(t)
+	want := []string{"ENG\tEngineering"}
+
+	if got := complete(t, srv, "issue", "list", "--team", ""); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("completions = %q, want %q", got, want)
+	}
+	requests := len(srv.Requests())
+
+	if got := complete(t, srv, "issue", "list", "--team", ""); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("cached completions = %q, want %q", got, want)
+	}
+	if len(srv.Requests()) != requests {
+		t.Errorf("completing again within the TTL made %d requests", len(srv.Requests())-requests)
+	}
+
+	// Cached candidates are still offered when the API cannot be reached
+	srv.Close()
+	if got := complete(t, srv, "project", "list", "--team", ""); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("offline completions = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// openURL opens a URL in the default browser; tests replace it
+var openURL = func(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open a browser: %w", err)
+	}
+	// The browser keeps running; only reap the launcher
+	go cmd.Wait()
+	return nil
+}
+
+// clipboardCommands are tried in order to copy text
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"clip.exe"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+}
+
+// copyText puts text on the clipboard; tests replace it. Without a
+// clipboard command it returns an OSC 52 sequence for the caller to write
+// to the terminal, which most terminals support, including over SSH.
+var copyText = func(text string) (string, error) {
+	for _, args := range clipboardCommands {
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err == nil {
+			return "", nil
+		}
+	}
+
+	if os.Getenv("TERM") == "dumb" {
+		return "", errors.New("no clipboard command found (install xclip, xsel or wl-copy)")
+	}
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a", nil
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/dukky/linear/internal/auth"
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
+	"github.com/spf13/cobra"
+)
+
+// errorCode classifies a failure for the exit status and JSON error output
+type errorCode string
+
+const (
+	codeError          errorCode = "error"
+	codeUsage          errorCode = "usage"
+	codeAuth           errorCode = "auth"
+	codeNotFound       errorCode = "not_found"
+	codeAmbiguous      errorCode = "ambiguous"
+	codeRateLimited    errorCode = "rate_limited"
+	codeNetwork        errorCode = "network"
+	codePartialFailure errorCode = "partial_failure"
+	codeInterrupted    errorCode = "interrupted"
+)
+
+// exitCodes maps error codes to documented process exit statuses
+var exitCodes = map[errorCode]int{
+	codeError:          1,
+	codeUsage:          2,
+	codeAuth:           3,
+	codeNotFound:       4,
+	codeAmbiguous:      5,
+	codeRateLimited:    6,
+	codeNetwork:        7,
+	codePartialFailure: 8,
+	codeInterrupted:    130,
+}
+
+// cmdError is an error returned by a command with an explicit code and an
+// optional hint on how to resolve it
+type cmdError struct {
+	code errorCode
+	err  error
+	hint string
+}
+
+func (e *cmdError) Error() string {
+	return e.err.Error()
+}
+
+func (e *cmdError) Unwrap() error {
+	return e.err
+}
+
+// usageErrorf returns an error for invalid flags or arguments
+func usageErrorf(format string, args ...any) error {
+	return &cmdError{code: codeUsage, err: fmt.Errorf(format, args...)}
+}
+
+// notFoundErrorf returns an error for a resource that does not exist
+func notFoundErrorf(format string, args ...any) error {
+	return &cmdError{code: codeNotFound, err: fmt.Errorf(format, args...)}
+}
+
+// partialFailure wraps err for a command that printed incomplete results
+func partialFailure(err error, format string, args ...any) error {
+	return &cmdError{
+		code: codePartialFailure,
+		err:  fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err),
+		hint: "Raise --timeout or --request-timeout, or narrow the results with --team or --project",
+	}
+}
+
+// issueFetchError wraps the error of a paginated issue fetch: a partial
+// failure when some issues were fetched, which are still printed
+func issueFetchError(fetched int, err error) error {
+	if fetched == 0 {
+		return fmt.Errorf("failed to fetch issues: %w", err)
+	}
+	return partialFailure(err, "results are incomplete: fetched %d issues before the request failed", fetched)
+}
+
+// partialOK splits the error of a fetch into a fatal error, which stops the
+// command, and a partial failure, which it returns after printing what was
+// fetched
+func partialOK(err error) (fatal, partial error) {
+	if err != nil && classifyError(err) == codePartialFailure {
+		return nil, err
+	}
+	return err, nil
+}
+
+// withHint attaches a hint to err, keeping its classification
+func withHint(err error, hint string) error {
+	return &cmdError{code: classifyError(err), err: err, hint: hint}
+}
+
+// usageArgs marks argument validation failures as usage errors
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return &cmdError{code: codeUsage, err: err}
+		}
+		return nil
+	}
+}
+
+// flagError marks flag parsing failures as usage errors
+func flagError(cmd *cobra.Command, err error) error {
+	return &cmdError{code: codeUsage, err: err}
+}
+
+// classifyError determines the error code for err
+func classifyError(err error) errorCode {
+	var cmdErr *cmdError
+	if errors.As(err, &cmdErr) && cmdErr.code != "" {
+		return cmdErr.code
+	}
+
+	var apiErr *linear.APIError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, auth.ErrNoAPIKey):
+		return codeAuth
+	case errors.Is(err, linear.ErrNotFound):
+		return codeNotFound
+	case errors.Is(err, linear.ErrAmbiguous):
+		return codeAmbiguous
+	case errors.As(err, &apiErr):
+		switch {
+		case apiErr.IsRateLimited():
+			return codeRateLimited
+		case apiErr.IsAuthError():
+			return codeAuth
+		case apiErr.IsNotFound():
+			return codeNotFound
+		}
+	case errors.Is(err, context.Canceled):
+		return codeInterrupted
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return codeNetwork
+	case strings.HasPrefix(err.Error(), "unknown command"):
+		// cobra reports unknown subcommands as plain errors
+		return codeUsage
+	}
+	return codeError
+}
+
+// errorHint returns the hint attached to err, or a default hint for its code
+func errorHint(cmd *cobra.Command, err error, code errorCode) string {
+	var cmdErr *cmdError
+	if errors.As(err, &cmdErr) && cmdErr.hint != "" {
+		return cmdErr.hint
+	}
+
+	switch code {
+	case codeUsage:
+		if cmd != nil {
+			return fmt.Sprintf("Run '%s --help' for usage", cmd.CommandPath())
+		}
+	case codeAuth:
+		if !errors.Is(err, auth.ErrNoAPIKey) {
+			return "Check your API key with 'linear auth status'"
+		}
+	case codeRateLimited:
+		return "Linear's API rate limit was reached; wait a moment and try again"
+	case codeNetwork:
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "The request timed out; raise --timeout or --request-timeout"
+		}
+		return "Check your network connection and try again"
+	}
+	return ""
+}
+
+// jsonError is the machine-readable error written to stderr for JSON output
+type jsonError struct {
+	Error struct {
+		Code    errorCode `json:"code"`
+		Message string    `json:"message"`
+		Hint    string    `json:"hint,omitempty"`
+	} `json:"error"`
+}
+
+// reportError writes err to w, as JSON when asJSON is set, and returns the
+// exit status for it
+func reportError(w io.Writer, cmd *cobra.Command, err error, asJSON bool) int {
+	code := classifyError(err)
+	hint := errorHint(cmd, err, code)
+
+	if asJSON {
+		var out jsonError
+		out.Error.Code = code
+		out.Error.Message = err.Error()
+		out.Error.Hint = hint
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		encoder.Encode(out)
+	} else {
+		label := "Error"
+		if code == codePartialFailure {
+			label = "Warning"
+		}
+		fmt.Fprintf(w, "%s: %s\n", label, err)
+		if hint != "" {
+			fmt.Fprintf(w, "Tip: %s\n", hint)
+		}
+	}
+	return exitCodes[code]
+}
+
+// jsonErrorsRequested reports whether errors should be written as JSON
+func jsonErrorsRequested() bool {
+	return jsonOutput || outputFormat == output.FormatJSON || outputFormat == output.FormatNDJSON
+}
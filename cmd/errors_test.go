@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dukky/linear/internal/auth"
+	"github.com/dukky/linear/linear"
+	"github.com/spf13/cobra"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want errorCode
+	}{
+		{name: "plain error", err: errors.New("boom"), want: codeError},
+		{name: "usage", err: usageErrorf("--title is required"), want: codeUsage},
+		{name: "not found", err: notFoundErrorf("team not found: ENG"), want: codeNotFound},
+		{name: "no API key", err: auth.ErrNoAPIKey, want: codeAuth},
+		{name: "wrapped client not found", err: fmt.Errorf("failed to fetch project: %w", linear.ErrNotFound), want: codeNotFound},
+		{name: "ambiguous", err: fmt.Errorf("failed to fetch project: %w", linear.ErrAmbiguous), want: codeAmbiguous},
+		{name: "rate limited status", err: &linear.APIError{StatusCode: http.StatusTooManyRequests}, want: codeRateLimited},
+		{name: "rate limited code", err: &linear.APIError{StatusCode: http.StatusBadRequest, Code: "RATELIMITED", Message: "Rate limit exceeded"}, want: codeRateLimited},
+		{name: "unauthorized", err: &linear.APIError{StatusCode: http.StatusUnauthorized}, want: codeAuth},
+		{name: "authentication error code", err: &linear.APIError{StatusCode: http.StatusOK, Code: "AUTHENTICATION_ERROR"}, want: codeAuth},
+		{name: "entity not found", err: fmt.Errorf("failed to fetch issue: %w", &linear.APIError{StatusCode: http.StatusOK, Message: "Entity not found: Issue"}), want: codeNotFound},
+		{name: "other API error", err: &linear.APIError{StatusCode: http.StatusInternalServerError, Body: "oops"}, want: codeError},
+		{name: "interrupted", err: fmt.Errorf("request failed: %w", context.Canceled), want: codeInterrupted},
+		{name: "partial failure", err: partialFailure(context.Canceled, "fetched %d issues", 3), want: codePartialFailure},
+		{name: "timeout", err: fmt.Errorf("request failed: %w", context.DeadlineExceeded), want: codeNetwork},
+		{name: "network", err: fmt.Errorf("request failed: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), want: codeNetwork},
+		{name: "unknown command", err: errors.New(`unknown command "foo" for "linear"`), want: codeUsage},
+		{name: "hint keeps code", err: withHint(fmt.Errorf("failed: %w", linear.ErrNotFound), "try again"), want: codeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyError(tt.err); got != tt.want {
+				t.Errorf("classifyError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportError_Text(t *testing.T) {
+	cmd := &cobra.Command{Use: "view"}
+
+	var buf bytes.Buffer
+	code := reportError(&buf, cmd, usageErrorf("accepts 1 arg(s), received 0"), false)
+
+	if code != 2 {
+		t.Errorf("exit code = %d, want 2", code)
+	}
+	want := "Error: accepts 1 arg(s), received 0\nTip: Run 'view --help' for usage\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestReportError_JSON(t *testing.T) {
+	err := withHint(fmt.Errorf("failed to fetch project: %w", linear.ErrNotFound), "Run 'linear project list' to see available projects")
+
+	var buf bytes.Buffer
+	code := reportError(&buf, nil, err, true)
+
+	if code != 4 {
+		t.Errorf("exit code = %d, want 4", code)
+	}
+
+	var got map[string]map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("stderr is not JSON: %v\n%s", err, buf.String())
+	}
+	want := map[string]string{
+		"code":    "not_found",
+		"message": "failed to fetch project: not found",
+		"hint":    "Run 'linear project list' to see available projects",
+	}
+	for key, value := range want {
+		if got["error"][key] != value {
+			t.Errorf("error.%s = %q, want %q", key, got["error"][key], value)
+		}
+	}
+}
+
+func TestReportError_JSONOmitsEmptyHint(t *testing.T) {
+	var buf bytes.Buffer
+	reportError(&buf, nil, errors.New("boom"), true)
+
+	want := `{"error":{"code":"error","message":"boom"}}` + "\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestIssueCreate_MissingTitleIsUsageError(t *testing.T) {
+	t.Cleanup(func() {
+		issueTitle = ""
+		issueTeamID = ""
+	})
+	issueTitle = ""
+	issueTeamID = "ENG"
+
+	err := issueCreateCmd.RunE(issueCreateCmd, nil)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if code := classifyError(err); code != codeUsage {
+		t.Errorf("classifyError() = %q, want %q", code, codeUsage)
+	}
+}
+
+func TestExactArgsIsUsageError(t *testing.T) {
+	err := issueViewCmd.Args(issueViewCmd, nil)
+	if code := classifyError(err); code != codeUsage {
+		t.Errorf("classifyError() = %q, want %q", code, codeUsage)
+	}
+}
+
+func TestReportError_PartialFailureIsWarning(t *testing.T) {
+	err := partialFailure(fmt.Errorf("request failed: %w", context.DeadlineExceeded), "results are incomplete: fetched %d issues before the request failed", 250)
+
+	var buf bytes.Buffer
+	code := reportError(&buf, nil, err, false)
+
+	if code != 8 {
+		t.Errorf("exit code = %d, want 8", code)
+	}
+	want := "Warning: results are incomplete: fetched 250 issues before the request failed: request failed: context deadline exceeded\n"
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("output = %q, want prefix %q", buf.String(), want)
+	}
+}
+
+func TestPartialOK(t *testing.T) {
+	if fatal, partial := partialOK(nil); fatal != nil || partial != nil {
+		t.Errorf("partialOK(nil) = %v, %v", fatal, partial)
+	}
+
+	failed := issueFetchError(0, context.DeadlineExceeded)
+	if fatal, partial := partialOK(failed); fatal != failed || partial != nil {
+		t.Errorf("partialOK(nothing fetched) = %v, %v", fatal, partial)
+	}
+
+	incomplete := issueFetchError(100, context.DeadlineExceeded)
+	if fatal, partial := partialOK(incomplete); fatal != nil || partial != incomplete {
+		t.Errorf("partialOK(some fetched) = %v, %v", fatal, partial)
+	}
+}
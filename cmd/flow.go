@@ -0,0 +1,483 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flowTeam  string
+	flowSince string
+	flowUntil string
+)
+
+// flowBarWidth is the length of the longest bar in the throughput chart
+const flowBarWidth = 40
+
+// flowReport is the structured output of report flow
+type flowReport struct {
+	Team       string      `json:"team,omitempty"`
+	Since      time.Time   `json:"since"`
+	Until      time.Time   `json:"until"`
+	LeadTime   flowStats   `json:"leadTime"`
+	CycleTime  flowStats   `json:"cycleTime"`
+	States     []flowState `json:"states"`
+	Throughput []flowWeek  `json:"throughput"`
+	Issues     []flowIssue `json:"issues"`
+}
+
+// flowStats summarizes a set of times, in days
+type flowStats struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P75   float64 `json:"p75"`
+	P90   float64 `json:"p90"`
+	P95   float64 `json:"p95"`
+}
+
+// flowState summarizes the time issues spent in a workflow state
+type flowState struct {
+	State string `json:"state"`
+	flowStats
+}
+
+// flowWeek is the number of issues completed in the week starting on a
+// Monday
+type flowWeek struct {
+	Week      string `json:"week"`
+	Completed int    `json:"completed"`
+}
+
+// flowIssue holds the times of a completed issue, in days
+type flowIssue struct {
+	Identifier  string   `json:"identifier"`
+	Title       string   `json:"title"`
+	URL         string   `json:"url"`
+	CreatedAt   string   `json:"createdAt"`
+	StartedAt   string   `json:"startedAt,omitempty"`
+	CompletedAt string   `json:"completedAt"`
+	LeadTime    float64  `json:"leadTime"`
+	CycleTime   *float64 `json:"cycleTime"`
+	// StateTimes is the time spent in each state before completion, as far
+	// as the issue's history goes
+	StateTimes map[string]float64 `json:"stateTimes"`
+}
+
+var reportFlowCmd = &cobra.Command{
+	Use:   "flow",
+	Short: "Measure lead time, cycle time and throughput of completed issues",
+	Long: `Measure how work flows through a team from the issues completed in a
+time window and their state changes:
+
+  lead time    from creation to completion
+  cycle time   from the start of work to completion
+  state times  the time spent in each state before completion
+
+Each is summarized by its mean and percentiles, followed by a chart of the
+issues completed each week. --since and --until take a date, a duration
+back from now such as 90d, or a git tag; --until defaults to now.
+
+Only the 100 most recent changes of each issue are fetched, so the state
+times of busy issues start at the oldest move among them.
+
+--format csv gives a row per issue with its times in days, for
+spreadsheets; --format json gives the summary and the issues.
+
+Examples:
+  linear report flow --team ENG
+  linear report flow --team ENG --since 2025-07-01 --until 2025-10-01
+  linear report flow --team ENG --since 90d --format csv > flow.csv`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ws, err := openWorkspace()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+
+		since, err := parseReportTime(ctx, "--since", flowSince, false)
+		if err != nil {
+			return err
+		}
+		until := now()
+		if flowUntil != "" {
+			until, err = parseReportTime(ctx, "--until", flowUntil, true)
+			if err != nil {
+				return err
+			}
+		}
+		if !since.Before(until) {
+			return usageErrorf("--since must be before --until")
+		}
+
+		var team *linear.Team
+		if flowTeam != "" {
+			team, err = resolveTeam(ctx, ws, flowTeam)
+			if err != nil {
+				return err
+			}
+		}
+
+		issues, err := completedIssuesWithHistory(ctx, ws, team, since, until)
+		fatal, partial := partialOK(err)
+		if fatal != nil {
+			return fatal
+		}
+		report := buildFlow(issues, since, until)
+		if team != nil {
+			report.Team = team.Key
+		}
+
+		if usesHumanOutput() {
+			writeFlow(output.Stdout, report)
+			return partial
+		}
+		if err := printOutput(report, flowTable(report)); err != nil {
+			return err
+		}
+		return partial
+	},
+}
+
+// completedIssuesWithHistory fetches the issues completed in [since,
+// until) with their history, like completedIssues
+func completedIssuesWithHistory(ctx context.Context, ws *workspace, team *linear.Team, since, until time.Time) ([]linear.Issue, error) {
+	opts := linear.ListIssuesOptions{
+		Filter: linear.IssueFilter{}.CompletedAt(linear.Gte(since.UTC().Format(time.RFC3339)), linear.Lt(until.UTC().Format(time.RFC3339))),
+	}
+	if team != nil {
+		opts.TeamKey = team.Key
+	}
+	issues, err := ws.client.ListAllIssuesWithHistory(ctx, opts)
+	if err != nil {
+		return issues, issueFetchError(len(issues), err)
+	}
+	return issues, nil
+}
+
+// buildFlow measures completed issues
+func buildFlow(issues []linear.Issue, since, until time.Time) flowReport {
+	report := flowReport{Since: since, Until: until, States: []flowState{}, Issues: []flowIssue{}}
+
+	var leadTimes, cycleTimes []float64
+	stateTimes := map[string][]float64{}
+	stateRanks := map[string]int{}
+	completed := map[time.Time]int{}
+
+	for _, issue := range issues {
+		created, err := time.Parse(time.RFC3339, issue.CreatedAt)
+		if err != nil || issue.CompletedAt == nil {
+			continue
+		}
+		done, err := time.Parse(time.RFC3339, *issue.CompletedAt)
+		if err != nil {
+			continue
+		}
+
+		item := flowIssue{
+			Identifier:  issue.Identifier,
+			Title:       issue.Title,
+			URL:         issue.URL,
+			CreatedAt:   issue.CreatedAt,
+			CompletedAt: *issue.CompletedAt,
+			LeadTime:    days(done.Sub(created)),
+			StateTimes:  map[string]float64{},
+		}
+		leadTimes = append(leadTimes, item.LeadTime)
+
+		if started, ok := startedAt(issue); ok {
+			item.StartedAt = started.UTC().Format(time.RFC3339)
+			cycle := days(done.Sub(started))
+			item.CycleTime = &cycle
+			cycleTimes = append(cycleTimes, cycle)
+		}
+
+		for _, period := range statePeriods(issue, created, done) {
+			item.StateTimes[period.state.Name] += days(period.duration)
+			if _, ok := stateRanks[period.state.Name]; !ok {
+				stateRanks[period.state.Name] = stateTypeRank(&linear.State{Type: period.state.Type})
+			}
+		}
+		for name, t := range item.StateTimes {
+			stateTimes[name] = append(stateTimes[name], t)
+		}
+
+		completed[weekStart(done)]++
+		report.Issues = append(report.Issues, item)
+	}
+
+	slices.SortFunc(report.Issues, func(a, b flowIssue) int {
+		return cmp.Or(cmp.Compare(a.CompletedAt, b.CompletedAt), compareIdentifiers(a.Identifier, b.Identifier))
+	})
+
+	report.LeadTime = summarize(leadTimes)
+	report.CycleTime = summarize(cycleTimes)
+
+	names := make([]string, 0, len(stateTimes))
+	for name := range stateTimes {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(stateRanks[a], stateRanks[b]), strings.Compare(a, b))
+	})
+	for _, name := range names {
+		report.States = append(report.States, flowState{State: name, flowStats: summarize(stateTimes[name])})
+	}
+
+	for week := weekStart(since); week.Before(until); week = week.AddDate(0, 0, 7) {
+		report.Throughput = append(report.Throughput, flowWeek{Week: week.Format(time.DateOnly), Completed: completed[week]})
+	}
+	return report
+}
+
+// startedAt returns when work on an issue started: the time Linear
+// recorded, or else its first move to a started state
+func startedAt(issue linear.Issue) (time.Time, bool) {
+	if issue.StartedAt != nil {
+		if t, err := time.Parse(time.RFC3339, *issue.StartedAt); err == nil {
+			return t, true
+		}
+	}
+	var first time.Time
+	for _, change := range stateChanges(issue) {
+		if change.ToState.Type != "started" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, change.CreatedAt); err == nil && (first.IsZero() || t.Before(first)) {
+			first = t
+		}
+	}
+	return first, !first.IsZero()
+}
+
+// statePeriod is a stretch of time an issue spent in a state
+type statePeriod struct {
+	state    *linear.WorkflowState
+	duration time.Duration
+}
+
+// statePeriods returns the time an issue spent in each state from its
+// creation to its completion, as far as its history shows. When the
+// history may be cut short, only the time from its oldest move is
+// measured. Time in completed and canceled states is left out.
+func statePeriods(issue linear.Issue, created, done time.Time) []statePeriod {
+	var periods []statePeriod
+	add := func(state *linear.WorkflowState, from, to time.Time) {
+		if state == nil || state.Type == "completed" || state.Type == "canceled" || !to.After(from) {
+			return
+		}
+		periods = append(periods, statePeriod{state, to.Sub(from)})
+	}
+
+	changes := stateChanges(issue)
+	if len(changes) == 0 {
+		return nil
+	}
+	// Without older changes, the state before the oldest move and when it
+	// was entered are unknown
+	var state *linear.WorkflowState
+	if len(issue.History.Nodes) < linear.IssueHistoryLimit {
+		for _, change := range changes {
+			if change.FromState != nil {
+				state = change.FromState
+				break
+			}
+		}
+	}
+	from := created
+	for _, change := range changes {
+		at, err := time.Parse(time.RFC3339, change.CreatedAt)
+		if err != nil {
+			continue
+		}
+		if at.After(done) {
+			at = done
+		}
+		add(state, from, at)
+		state, from = change.ToState, at
+	}
+	add(state, from, done)
+	return periods
+}
+
+// stateChanges returns an issue's moves between states, oldest first,
+// leaving out the edits to other fields its history also holds
+func stateChanges(issue linear.Issue) []linear.IssueHistory {
+	if issue.History == nil {
+		return nil
+	}
+	var changes []linear.IssueHistory
+	for _, change := range issue.History.Nodes {
+		if change.ToState != nil {
+			changes = append(changes, change)
+		}
+	}
+	slices.SortFunc(changes, func(a, b linear.IssueHistory) int { return strings.Compare(a.CreatedAt, b.CreatedAt) })
+	return changes
+}
+
+// weekStart returns the start of the local week, on Monday, that t is in
+func weekStart(t time.Time) time.Time {
+	t = t.Local()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// days converts a duration to days, rounded to hundredths
+func days(d time.Duration) float64 {
+	return round2(d.Hours() / 24)
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+// summarize returns the count, mean and percentiles of values
+func summarize(values []float64) flowStats {
+	stats := flowStats{Count: len(values)}
+	if len(values) == 0 {
+		return stats
+	}
+	sorted := slices.Sorted(slices.Values(values))
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	stats.Mean = round2(sum / float64(len(sorted)))
+	stats.P50 = percentile(sorted, 50)
+	stats.P75 = percentile(sorted, 75)
+	stats.P90 = percentile(sorted, 90)
+	stats.P95 = percentile(sorted, 95)
+	return stats
+}
+
+// percentile returns the pth percentile of sorted values, interpolating
+// between the closest ranks
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(rank)
+	if lower+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return round2(sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower]))
+}
+
+// formatDays formats a time in days for people, in hours when it is under
+// a day
+func formatDays(d float64) string {
+	if d < 1 {
+		return strconv.FormatFloat(d*24, 'f', 1, 64) + "h"
+	}
+	return strconv.FormatFloat(d, 'f', 1, 64) + "d"
+}
+
+// writeFlow writes a flow report as summary tables and a throughput chart
+func writeFlow(w io.Writer, r flowReport) {
+	scope := "all teams"
+	if r.Team != "" {
+		scope = r.Team
+	}
+	// --until dates are exclusive ends of days, shown as the last day
+	last := r.Until.Add(-time.Nanosecond)
+	fmt.Fprintf(w, "Flow for %s from %s to %s: %s completed\n", scope, r.Since.Format(time.DateOnly), last.Format(time.DateOnly), plural(len(r.Issues), "issue"))
+	if len(r.Issues) == 0 {
+		return
+	}
+
+	headers := func(first string) []string {
+		return []string{first, "ISSUES", "MEAN", "P50", "P75", "P90", "P95"}
+	}
+	addRow := func(table *output.Table, name string, s flowStats) {
+		row := []string{name, strconv.Itoa(s.Count)}
+		for _, v := range []float64{s.Mean, s.P50, s.P75, s.P90, s.P95} {
+			row = append(row, formatDays(v))
+		}
+		table.AddRow(row)
+	}
+
+	times := output.NewTable(headers("TIME"))
+	addRow(times, "Lead time", r.LeadTime)
+	if r.CycleTime.Count > 0 {
+		addRow(times, "Cycle time", r.CycleTime)
+	}
+	fmt.Fprintln(w)
+	times.PrintTo(w)
+
+	if len(r.States) > 0 {
+		states := output.NewTable(headers("STATE"))
+		for _, s := range r.States {
+			addRow(states, s.State, s.flowStats)
+		}
+		fmt.Fprintln(w)
+		states.PrintTo(w)
+	}
+
+	fmt.Fprintln(w, "\nCompleted per week")
+	most := 0
+	for _, week := range r.Throughput {
+		most = max(most, week.Completed)
+	}
+	for _, week := range r.Throughput {
+		bar := 0
+		if most > 0 {
+			bar = int(math.Round(float64(week.Completed) * flowBarWidth / float64(most)))
+		}
+		if week.Completed > 0 {
+			bar = max(bar, 1)
+		}
+		fmt.Fprintf(w, "%s  %-*s  %d\n", week.Week, flowBarWidth, strings.Repeat("#", bar), week.Completed)
+	}
+}
+
+// flowTable lists the issues of a flow report with their times in days,
+// with a column for each state
+func flowTable(r flowReport) *output.Table {
+	headers := []string{"ID", "TITLE", "CREATED", "STARTED", "COMPLETED", "LEAD DAYS", "CYCLE DAYS"}
+	for _, s := range r.States {
+		headers = append(headers, strings.ToUpper(s.State)+" DAYS")
+	}
+	table := output.NewTable(headers)
+	for _, issue := range r.Issues {
+		cycle := ""
+		if issue.CycleTime != nil {
+			cycle = formatFloat(*issue.CycleTime)
+		}
+		row := []string{issue.Identifier, issue.Title, issue.CreatedAt, issue.StartedAt, issue.CompletedAt, formatFloat(issue.LeadTime), cycle}
+		for _, s := range r.States {
+			t, ok := issue.StateTimes[s.State]
+			if ok {
+				row = append(row, formatFloat(t))
+			} else {
+				row = append(row, "")
+			}
+		}
+		table.AddRow(row)
+	}
+	return table
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func init() {
+	reportFlowCmd.Flags().StringVar(&flowTeam, "team", "", "Only include issues of this team (e.g., ENG)")
+	reportFlowCmd.Flags().StringVar(&flowSince, "since", "90d", "Start of the window: a duration such as 90d, a date, or a git tag")
+	reportFlowCmd.Flags().StringVar(&flowUntil, "until", "", "End of the window, like --since (default: now)")
+
+	_ = reportFlowCmd.RegisterFlagCompletionFunc("team", completeTeams)
+
+	reportCmd.AddCommand(reportFlowCmd)
+}
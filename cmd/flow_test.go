@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukky/linear/linear"
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+// newFlowServer returns a server with three ENG issues completed in
+// September 2025 and one completed before
+func newFlowServer(t *testing.T) *lineartest.Server {
+	t.Helper()
+	fixNow(t, time.Date(2025, 10, 1, 12, 0, 0, 0, time.Local))
+
+	srv, team := newIssueServer(t)
+	states := srv.States(team.ID)
+	backlog, todo, started, done := states[0].ID, states[1].ID, states[2].ID, states[3].ID
+	day := func(d int) time.Time { return time.Date(2025, 9, d, 0, 0, 0, 0, time.Local) }
+
+	first := srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Search", StateID: done, CreatedAt: day(1), StartedAt: day(3), CompletedAt: day(5)})
+	srv.AddHistory(lineartest.History{IssueID: first.ID, FromStateID: backlog, ToStateID: todo, CreatedAt: day(2)})
+	srv.AddHistory(lineartest.History{IssueID: first.ID, FromStateID: todo, ToStateID: started, CreatedAt: day(3)})
+	// Edits other than moves are recorded without states
+	srv.AddHistory(lineartest.History{IssueID: first.ID, CreatedAt: day(4)})
+	srv.AddHistory(lineartest.History{IssueID: first.ID, FromStateID: started, ToStateID: done, CreatedAt: day(5)})
+
+	second := srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Export", StateID: done, CreatedAt: day(10), CompletedAt: day(18)})
+	srv.AddHistory(lineartest.History{IssueID: second.ID, CreatedAt: day(11)})
+	srv.AddHistory(lineartest.History{IssueID: second.ID, FromStateID: todo, ToStateID: started, CreatedAt: day(12)})
+	srv.AddHistory(lineartest.History{IssueID: second.ID, FromStateID: started, ToStateID: done, CreatedAt: day(18)})
+
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Typo", StateID: done, CreatedAt: day(7), CompletedAt: day(17)})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Old", StateID: done, CreatedAt: day(1).AddDate(0, -2, 0), CompletedAt: day(1).AddDate(0, -1, 0)})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Open", StateID: started, CreatedAt: day(1), StartedAt: day(2)})
+	return srv
+}
+
+func TestReportFlow(t *testing.T) {
+	srv := newFlowServer(t)
+
+	out, err := runCLI(t, srv, "report", "flow", "--team", "eng", "--since", "2025-09-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `Flow for ENG from 2025-09-01 to 2025-10-01: 3 issues completed
+
+TIME        ISSUES  MEAN  P50   P75   P90   P95
+----        ------  ----  ---   ---   ---   ---
+Lead time   3       7.3d  8.0d  9.0d  9.6d  9.8d
+Cycle time  2       4.0d  4.0d  5.0d  5.6d  5.8d
+
+STATE        ISSUES  MEAN  P50   P75   P90   P95
+-----        ------  ----  ---   ---   ---   ---
+Backlog      1       1.0d  1.0d  1.0d  1.0d  1.0d
+Todo         2       1.5d  1.5d  1.8d  1.9d  1.9d
+In Progress  2       4.0d  4.0d  5.0d  5.6d  5.8d
+
+Completed per week
+2025-09-01  ####################                      1
+2025-09-08                                            0
+2025-09-15  ########################################  2
+2025-09-22                                            0
+2025-09-29                                            0
+`
+	if out != want {
+		t.Errorf("output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestReportFlow_Export(t *testing.T) {
+	srv := newFlowServer(t)
+
+	out, err := runCLI(t, srv, "report", "flow", "--since", "2025-09-01", "--format", "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected a header and 3 rows, got:\n%s", out)
+	}
+	if lines[0] != "ID,TITLE,CREATED,STARTED,COMPLETED,LEAD DAYS,CYCLE DAYS,BACKLOG DAYS,TODO DAYS,IN PROGRESS DAYS" {
+		t.Errorf("header = %s", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "ENG-1,Search,") || !strings.HasSuffix(lines[1], ",4,2,1,1,2") {
+		t.Errorf("first row = %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "ENG-3,Typo,") || !strings.HasSuffix(lines[2], ",10,,,,") {
+		t.Errorf("second row = %s", lines[2])
+	}
+
+	out, err = runCLI(t, srv, "report", "flow", "--since", "2025-09-01", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report flowReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.LeadTime.Count != 3 || report.LeadTime.P50 != 8 || report.CycleTime.Count != 2 {
+		t.Errorf("lead time %+v, cycle time %+v", report.LeadTime, report.CycleTime)
+	}
+	if len(report.States) != 3 || report.States[2].State != "In Progress" || report.States[2].P90 != 5.6 {
+		t.Errorf("states = %+v", report.States)
+	}
+	if len(report.Throughput) != 5 || report.Throughput[2] != (flowWeek{Week: "2025-09-15", Completed: 2}) {
+		t.Errorf("throughput = %+v", report.Throughput)
+	}
+	if issue := report.Issues[2]; issue.Identifier != "ENG-2" || *issue.CycleTime != 6 || issue.StateTimes["Todo"] != 2 {
+		t.Errorf("issue = %+v", issue)
+	}
+}
+
+func TestReportFlow_Empty(t *testing.T) {
+	srv := newFlowServer(t)
+
+	out, err := runCLI(t, srv, "report", "flow", "--since", "2025-09-20", "--until", "2025-09-25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "Flow for all teams from 2025-09-20 to 2025-09-25: 0 issues completed\n" {
+		t.Errorf("output = %q", out)
+	}
+
+	if _, err := runCLI(t, srv, "report", "flow", "--since", "2025-09-25", "--until", "2025-09-20"); err == nil || !strings.Contains(err.Error(), "--since must be before --until") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestReportFlow_PartialFailure(t *testing.T) {
+	srv := newFlowServer(t)
+	eng := srv.Issues()[0].TeamID
+	done := srv.States(eng)[3].ID
+	for i := 0; i < 50; i++ {
+		srv.AddIssue(lineartest.Issue{TeamID: eng, Title: "More work", StateID: done, CreatedAt: time.Date(2025, 9, 20, 0, 0, 0, 0, time.Local), CompletedAt: time.Date(2025, 9, 22, 0, 0, 0, 0, time.Local)})
+	}
+	srv.Fail(lineartest.Fault{Operation: "issues", Status: http.StatusBadRequest, Message: "Query too complex", After: 1})
+
+	out, err := runCLI(t, srv, "report", "flow", "--since", "2025-09-01", "--json")
+	if code := classifyError(err); code != codePartialFailure {
+		t.Fatalf("classifyError(%v) = %s, want %s", err, code, codePartialFailure)
+	}
+	var report flowReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(report.Issues) != 50 || report.LeadTime.Count != 50 {
+		t.Errorf("printed %d issues, want the first page of 50", len(report.Issues))
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	for p, want := range map[float64]float64{0: 1, 50: 3, 75: 4, 90: 4.6, 100: 5} {
+		if got := percentile(values, p); got != want {
+			t.Errorf("percentile(%g) = %g, want %g", p, got, want)
+		}
+	}
+	if got := percentile([]float64{7}, 95); got != 7 {
+		t.Errorf("percentile of one value = %g", got)
+	}
+}
+
+func TestStatePeriods_TruncatedHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 9, d, 0, 0, 0, 0, time.UTC) }
+	todo := &linear.WorkflowState{Name: "Todo", Type: "unstarted"}
+	started := &linear.WorkflowState{Name: "In Progress", Type: "started"}
+	done := &linear.WorkflowState{Name: "Done", Type: "completed"}
+
+	issue := linear.Issue{History: &struct {
+		Nodes []linear.IssueHistory `json:"nodes"`
+	}{}}
+	add := func(d int, from, to *linear.WorkflowState) {
+		issue.History.Nodes = append(issue.History.Nodes, linear.IssueHistory{CreatedAt: day(d).Format(time.RFC3339), FromState: from, ToState: to})
+	}
+	add(5, started, done)
+	add(3, todo, started)
+
+	times := func() map[string]time.Duration {
+		got := map[string]time.Duration{}
+		for _, period := range statePeriods(issue, day(1), day(5)) {
+			got[period.state.Name] += period.duration
+		}
+		return got
+	}
+
+	// The whole history is known, so Todo was entered on creation
+	if got := times(); got["Todo"] != 48*time.Hour || got["In Progress"] != 48*time.Hour {
+		t.Errorf("complete history: %v", got)
+	}
+
+	// A full page of edits may hide older moves, so only the time from the
+	// oldest move is measured
+	for len(issue.History.Nodes) < linear.IssueHistoryLimit {
+		add(4, nil, nil)
+	}
+	if got := times(); len(got) != 1 || got["In Progress"] != 48*time.Hour {
+		t.Errorf("truncated history: %v", got)
+	}
+}
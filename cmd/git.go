@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dukky/linear/internal/git"
+	"github.com/dukky/linear/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gitHooksForce    bool
+	gitPRBodyExcerpt int
+)
+
+// hookMarker identifies commit-msg hooks written by install-hooks, which
+// may be overwritten
+const hookMarker = "# Installed by 'linear git install-hooks'"
+
+var gitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Link git commits and pull requests to issues",
+	Long:  `Reference Linear issues from commit messages and pull request descriptions.`,
+}
+
+var gitInstallHooksCmd = &cobra.Command{
+	Use:   "install-hooks",
+	Short: "Install a commit-msg hook that references the branch's issue",
+	Long: `Install a commit-msg hook in the current repository.
+
+The hook checks the issues referenced by trailers such as "Refs: ENG-123"
+or "Fixes ENG-123" and rejects the commit if one does not exist. Messages
+without such a trailer get "Refs: <issue>" for the issue the branch is
+named after, if there is one. If Linear cannot be reached the commit goes
+ahead unchecked.
+
+An existing commit-msg hook is only replaced with --force.
+
+Examples:
+  linear git install-hooks
+  linear git install-hooks --force`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		path, err := git.HookPath(ctx, "commit-msg")
+		if errors.Is(err, git.ErrNotRepository) {
+			return usageErrorf("git install-hooks must be run inside a git repository")
+		}
+		if err != nil {
+			return err
+		}
+
+		existing, err := os.ReadFile(path)
+		if err == nil && !gitHooksForce && !strings.Contains(string(existing), hookMarker) {
+			return withHint(usageErrorf("%s already exists", path), "Use --force to replace it, or call 'linear git commit-msg \"$1\"' from it")
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			executable = "linear"
+		}
+		hook := fmt.Sprintf("#!/bin/sh\n%s\nexec %s git commit-msg \"$1\"\n", hookMarker, shellQuote(executable))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create hooks directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(hook), 0o755); err != nil {
+			return fmt.Errorf("failed to write hook: %w", err)
+		}
+		// WriteFile keeps the mode of a file that already exists
+		if err := os.Chmod(path, 0o755); err != nil {
+			return fmt.Errorf("failed to make hook executable: %w", err)
+		}
+
+		fmt.Fprintf(output.Stdout, "Installed commit-msg hook at %s\n", path)
+		return nil
+	},
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// trailerPattern matches lines such as "Refs: ENG-1" or "Fixes ENG-1, ENG-2"
+var trailerPattern = regexp.MustCompile(`(?im)^(?:refs|references|fixes|closes|resolves):?[ \t]+([a-z][a-z0-9]*-[0-9]+(?:[ \t]*,?[ \t]*[a-z][a-z0-9]*-[0-9]+)*)[ \t]*$`)
+
+// commitMessageBody returns a commit message without its comments and
+// anything below the scissors line, as git will record it
+func commitMessageBody(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "# ") && strings.Contains(line, ">8") {
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// referencedIssues returns the identifiers in a commit message's
+// issue trailers
+func referencedIssues(message string) []string {
+	var identifiers []string
+	for _, match := range trailerPattern.FindAllStringSubmatch(message, -1) {
+		identifiers = append(identifiers, issueIdentifiers(match[1], nil)...)
+	}
+	return identifiers
+}
+
+var gitCommitMsgCmd = &cobra.Command{
+	Use:    "commit-msg <message-file>",
+	Short:  "Check or add the issue trailer of a commit message",
+	Long:   `Run by the hook 'linear git install-hooks' installs; see its help.`,
+	Hidden: true,
+	Args:   usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := args[0]
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read commit message: %w", err)
+		}
+
+		message := commitMessageBody(string(data))
+		// Leave empty messages to git, and fixups to the commits they fix
+		if message == "" || strings.HasPrefix(message, "fixup!") || strings.HasPrefix(message, "squash!") || strings.HasPrefix(message, "amend!") {
+			return nil
+		}
+
+		// Offline or signed out commits should not be blocked, only
+		// unchecked
+		warn := func(err error) error {
+			fmt.Fprintf(cmd.ErrOrStderr(), "linear: not checking issue references: %v\n", err)
+			return nil
+		}
+
+		ws, err := openWorkspace()
+		if err != nil {
+			return warn(err)
+		}
+		ctx := cmd.Context()
+
+		if identifiers := referencedIssues(message); len(identifiers) > 0 {
+			for _, identifier := range identifiers {
+				_, err := getIssue(ctx, ws, identifier)
+				if err == nil {
+					continue
+				}
+				if classifyError(err) == codeNotFound {
+					return withHint(notFoundErrorf("commit message references unknown issue %s", identifier), "Fix the trailer, or commit with --no-verify to skip the check")
+				}
+				return warn(err)
+			}
+			return nil
+		}
+
+		identifier, err := issueArg(ctx, ws, ".")
+		if err != nil {
+			// Not on an issue branch, so there is nothing to add
+			if classifyError(err) == codeNotFound || classifyError(err) == codeUsage {
+				return nil
+			}
+			return warn(err)
+		}
+		issue, err := getIssue(ctx, ws, identifier)
+		if err != nil {
+			if classifyError(err) == codeNotFound {
+				return nil
+			}
+			return warn(err)
+		}
+		return git.AddTrailer(ctx, file, "Refs: "+issue.Identifier)
+	},
+}
+
+// prBodyResult is the structured output of git pr-body
+type prBodyResult struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Body       string `json:"body"`
+}
+
+var gitPRBodyCmd = &cobra.Command{
+	Use:   "pr-body [issue-id]",
+	Short: "Print a pull request description for an issue",
+	Long: `Print a Markdown pull request description with the issue's title, link
+and the start of its description, ending with "Fixes <issue>" so that
+merging it completes the issue. Without an issue ID it uses the issue the
+current git branch is named after.
+
+Examples:
+  linear git pr-body
+  gh pr create --title "$(linear issue view . --template '{{.Title}}')" --body "$(linear git pr-body)"
+  linear git pr-body ENG-123 --excerpt 0`,
+	Args:              usageArgs(cobra.MaximumNArgs(1)),
+	ValidArgsFunction: completeIssueID,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if gitPRBodyExcerpt < 0 {
+			return usageErrorf("--excerpt must not be negative")
+		}
+
+		ws, err := openWorkspace()
+		if err != nil {
+			return err
+		}
+
+		arg := "."
+		if len(args) > 0 {
+			arg = args[0]
+		}
+		ctx := cmd.Context()
+		issueID, err := issueArg(ctx, ws, arg)
+		if err != nil {
+			return err
+		}
+		issue, err := getIssue(ctx, ws, issueID)
+		if err != nil {
+			return err
+		}
+
+		var body strings.Builder
+		fmt.Fprintf(&body, "**[%s](%s): %s**\n\n", issue.Identifier, issue.URL, issue.Title)
+		if issue.Description != nil && gitPRBodyExcerpt > 0 {
+			if text := excerpt(*issue.Description, gitPRBodyExcerpt); text != "" {
+				for _, line := range strings.Split(text, "\n") {
+					fmt.Fprintf(&body, "%s\n", strings.TrimRight("> "+line, " "))
+				}
+				body.WriteString("\n")
+			}
+		}
+		fmt.Fprintf(&body, "Fixes %s\n", issue.Identifier)
+
+		if !usesHumanOutput() {
+			result := prBodyResult{Identifier: issue.Identifier, Title: issue.Title, URL: issue.URL, Body: body.String()}
+			table := output.NewTable([]string{"ID", "TITLE", "URL"})
+			table.AddRow([]string{issue.Identifier, issue.Title, issue.URL})
+			return printOutput(result, table)
+		}
+
+		fmt.Fprint(output.Stdout, body.String())
+		return nil
+	},
+}
+
+// excerpt returns the leading paragraphs of Markdown text that fit in
+// limit characters, or the first paragraph cut short with an ellipsis
+func excerpt(text string, limit int) string {
+	paragraphs := strings.Split(strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n")), "\n\n")
+
+	var kept []string
+	length := 0
+	for _, paragraph := range paragraphs {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		if length+len(paragraph) > limit {
+			if len(kept) == 0 {
+				runes := []rune(paragraph)
+				if len(runes) > limit {
+					paragraph = strings.TrimSpace(string(runes[:limit])) + "…"
+				}
+				kept = append(kept, paragraph)
+			} else {
+				kept = append(kept, "…")
+			}
+			break
+		}
+		kept = append(kept, paragraph)
+		length += len(paragraph)
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+func init() {
+	gitInstallHooksCmd.Flags().BoolVar(&gitHooksForce, "force", false, "Replace an existing commit-msg hook")
+	gitPRBodyCmd.Flags().IntVar(&gitPRBodyExcerpt, "excerpt", 500, "Maximum length of the description excerpt; 0 leaves it out")
+
+	gitCmd.AddCommand(gitInstallHooksCmd)
+	gitCmd.AddCommand(gitCommitMsgCmd)
+	gitCmd.AddCommand(gitPRBodyCmd)
+	rootCmd.AddCommand(gitCmd)
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+func TestReferencedIssues(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{message: "Fix login\n\nRefs: ENG-1", want: "ENG-1"},
+		{message: "Fix login\n\nFixes eng-1, ENG-2", want: "ENG-1,ENG-2"},
+		{message: "Fix login\n\nCloses: ENG-1\nRefs: OPS-3", want: "ENG-1,OPS-3"},
+		{message: "Fix login\n\nFixes the crash in ENG-1 handling", want: ""},
+		{message: "Fix ENG-1", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := strings.Join(referencedIssues(tt.message), ","); got != tt.want {
+			t.Errorf("referencedIssues(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestCommitMessageBody(t *testing.T) {
+	message := "Fix login\n# Please enter the commit message\n\nDetails\n# ------------------------ >8 ------------------------\nRefs: ENG-9\n"
+	if got := commitMessageBody(message); got != "Fix login\n\nDetails" {
+		t.Errorf("commitMessageBody() = %q", got)
+	}
+}
+
+// commitMsg runs the commit-msg hook on message and returns the message
+// it leaves behind
+func commitMsg(t *testing.T, srv *lineartest.Server, message string) (string, error) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(file, []byte(message), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := runCLI(t, srv, "git", "commit-msg", file)
+	got, _ := os.ReadFile(file)
+	return string(got), err
+}
+
+func TestGitCommitMsg(t *testing.T) {
+	srv, team := newIssueServer(t)
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix login"})
+	newGitRepo(t)
+
+	// Off an issue branch the message is left alone
+	if got, err := commitMsg(t, srv, "Fix login\n"); err != nil || got != "Fix login\n" {
+		t.Errorf("on main: message = %q, error = %v", got, err)
+	}
+
+	gitRun(t, "switch", "--quiet", "--create", "eng-1-fix-login")
+
+	got, err := commitMsg(t, srv, "Fix login\n\n# Please enter the commit message\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "Fix login\n\nRefs: ENG-1\n\n# Please enter the commit message\n" {
+		t.Errorf("message = %q, want the branch's issue added", got)
+	}
+
+	// Existing references are checked, not added to
+	if got, err := commitMsg(t, srv, "Fix login\n\nFixes ENG-1\n"); err != nil || got != "Fix login\n\nFixes ENG-1\n" {
+		t.Errorf("message = %q, error = %v; want it unchanged", got, err)
+	}
+	if got, err := commitMsg(t, srv, "fixup! Fix login\n"); err != nil || got != "fixup! Fix login\n" {
+		t.Errorf("message = %q, error = %v; want fixups unchanged", got, err)
+	}
+
+	_, err = commitMsg(t, srv, "Fix login\n\nRefs: ENG-9\n")
+	if err == nil || classifyError(err) != codeNotFound || !strings.Contains(err.Error(), "ENG-9") {
+		t.Errorf("error = %v, want the unknown issue rejected", err)
+	}
+}
+
+func TestGitInstallHooks(t *testing.T) {
+	srv, _ := newIssueServer(t)
+	newGitRepo(t)
+	hook := filepath.Join(".git", "hooks", "commit-msg")
+
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCLI(t, srv, "git", "install-hooks"); err == nil || classifyError(err) != codeUsage {
+		t.Fatalf("error = %v, want existing hooks kept", err)
+	}
+
+	for _, args := range [][]string{{"--force"}, {}} {
+		if _, err := runCLI(t, srv, append([]string{"git", "install-hooks"}, args...)...); err != nil {
+			t.Fatalf("install-hooks %v: %v", args, err)
+		}
+	}
+
+	data, _ := os.ReadFile(hook)
+	if !strings.Contains(string(data), `git commit-msg "$1"`) {
+		t.Errorf("hook = %q", data)
+	}
+	if info, _ := os.Stat(hook); info.Mode().Perm()&0o111 == 0 {
+		t.Errorf("hook mode = %v, want executable", info.Mode())
+	}
+}
+
+func TestGitPRBody(t *testing.T) {
+	srv, team := newIssueServer(t)
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix login", Description: "Users see an error.\n\nSteps:\n1. Log in"})
+
+	out, err := runCLI(t, srv, "git", "pr-body", "ENG-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "**[ENG-1](https://linear.app/test/issue/ENG-1/fix-login): Fix login**\n\n" +
+		"> Users see an error.\n>\n> Steps:\n> 1. Log in\n\n" +
+		"Fixes ENG-1\n"
+	if out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+
+	out, err = runCLI(t, srv, "git", "pr-body", "ENG-1", "--excerpt", "25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "> Users see an error.\n>\n> …\n") {
+		t.Errorf("output = %q, want the excerpt cut after the first paragraph", out)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		text  string
+		limit int
+		want  string
+	}{
+		{text: "Short", limit: 10, want: "Short"},
+		{text: "One\n\nTwo\n\nThree", limit: 7, want: "One\n\nTwo\n\n…"},
+		{text: "A long first paragraph", limit: 6, want: "A long…"},
+		{text: "\n\n", limit: 10, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := excerpt(tt.text, tt.limit); got != tt.want {
+			t.Errorf("excerpt(%q, %d) = %q, want %q", tt.text, tt.limit, got, tt.want)
+		}
+	}
+}
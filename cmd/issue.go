@@ -2,12 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"time"
+	"strings"
 
-	"github.com/dukky/linear/internal/client"
 	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +26,13 @@ var (
 	issueUpdateAssignee    string
 	issueLimit             int
 	fetchAll               bool
+	issueColumnsFlag       []string
+	issueSortFlag          []string
+	issueViewRaw           bool
+	issueQueryFlag         string
+	issueSavedQueryFlag    string
+	issueStateFilter       string
+	issueLabelFilter       string
 )
 
 var issueCmd = &cobra.Command{
@@ -41,197 +48,150 @@ var issueListCmd = &cobra.Command{
 
 Use --team to filter by team key (e.g., --team ENG).
 Use --project to filter by project name or ID.
+Use --state to filter by workflow state name (e.g., --state "In Progress").
+Use --label to filter by label name (e.g., --label Bug).
 Use --limit to specify the number of issues to fetch (default: 50).
-Use --all to fetch all issues using pagination.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		c, err := client.NewClient()
+Use --all to fetch all issues using pagination.
+Use --columns to choose table columns (id, title, state, assignee, priority,
+labels, project, team, estimate, due, url, created, updated).
+Use --sort to order results client-side, prefixing a column with '-' for
+descending order (e.g., --sort updated,-priority).
+
+Use --query to filter with the query language, e.g.
+  --query '(label:bug OR label:incident) priority<=2 updated>-7d NOT state:Done'
+
+Terms are field:value, field=value, field!=value or comparisons with
+<, <=, > and >=; a bare word searches titles. Combine them with AND (or
+just a space), OR, NOT or a leading '-', and parentheses. label:a,b matches
+either value.
+
+Fields: title, description, team, state, type (state type), label,
+assignee, creator, project (me and none where they make sense), priority
+(0-4 or none, urgent, high, medium, low; priority<=2 means urgent or high),
+estimate, and the dates created, updated, started, completed, canceled and
+due (2025-01-31, timestamps, or relative dates such as -7d, -2w, -3m).
+
+Save queries by name under "queries:" in the config file and run them with
+--saved <name>.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := issueListFilter()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		ws, err := openWorkspace()
+		if err != nil {
+			return err
+		}
+		c := ws.client
 
-		// Resolve project filter if specified
-		var projectID string
-		if projectFilter != "" {
-			// Get team ID for scoping project lookup (if team filter provided)
-			var teamID string
-			if teamFilter != "" {
-				teamResp, err := c.GetTeamByKey(ctx, teamFilter)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error fetching team: %v\n", err)
-					os.Exit(1)
-				}
-				if len(teamResp.Teams.Nodes) == 0 {
-					fmt.Fprintf(os.Stderr, "Team not found: %s\n", teamFilter)
-					os.Exit(1)
-				}
-				teamID = teamResp.Teams.Nodes[0].ID
-			}
+		ctx := cmd.Context()
 
-			project, err := c.GetProjectByIdentifier(ctx, projectFilter, teamID)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching project: %v\n", err)
-				fmt.Fprintln(os.Stderr, "Tip: Run 'linear project list' to see available projects")
-				os.Exit(1)
-			}
-			projectID = project.ID
+		opts, err := issueListOptions(ctx, ws, filter)
+		if err != nil {
+			return err
 		}
 
-		var issues []client.Issue
+		var issues []linear.Issue
 
-		opts := client.ListIssuesOptions{
-			TeamKey:   teamFilter,
-			ProjectID: projectID,
-			Limit:     issueLimit,
-		}
+		var partial error
 
 		if fetchAll {
 			// Fetch all issues using pagination
 			allIssues, err := c.ListAllIssues(ctx, opts)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching issues: %v\n", err)
-				os.Exit(1)
+				var fatal error
+				if fatal, partial = partialOK(issueFetchError(len(allIssues), err)); fatal != nil {
+					return fatal
+				}
 			}
 			issues = allIssues
 		} else {
 			// Fetch with specified limit
 			resp, err := c.ListIssues(ctx, opts)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching issues: %v\n", err)
-				os.Exit(1)
+				return fmt.Errorf("failed to fetch issues: %w", err)
 			}
 			issues = resp.Issues.Nodes
 		}
 
-		if jsonOutput {
-			if err := output.PrintJSON(issues); err != nil {
-				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
-				os.Exit(1)
-			}
-			return
+		table, err := buildListTable(issueColumns, issues, issueColumnsFlag, issueSortFlag)
+		if err != nil {
+			return err
 		}
 
-		// Table output
-		table := output.NewTable([]string{"ID", "TITLE", "STATUS", "ASSIGNEE", "PRIORITY"})
-		for _, issue := range issues {
-			assignee := "-"
-			if issue.Assignee != nil {
-				assignee = issue.Assignee.Name
-			}
-
-			priority := "-"
-			if issue.PriorityLabel != "" {
-				priority = issue.PriorityLabel
-			}
+		if err := printOutput(issues, table); err != nil {
+			return err
+		}
+		return partial
+	},
+}
 
-			status := "-"
-			if issue.State != nil {
-				status = issue.State.Name
+// issueListOptions resolves --team and --project into list options for
+// filter, which is shared by issue list and tui
+func issueListOptions(ctx context.Context, ws *workspace, filter linear.IssueFilter) (linear.ListIssuesOptions, error) {
+	// Resolve project filter if specified
+	teamKey := teamFilter
+	var projectID string
+	if projectFilter != "" {
+		// Get team ID for scoping project lookup (if team filter provided)
+		var teamID string
+		if teamFilter != "" {
+			team, err := resolveTeam(ctx, ws, teamFilter)
+			if err != nil {
+				return linear.ListIssuesOptions{}, err
 			}
+			teamID, teamKey = team.ID, team.Key
+		}
 
-			table.AddRow([]string{
-				issue.Identifier,
-				output.TruncateString(issue.Title, 50),
-				status,
-				assignee,
-				priority,
-			})
+		project, err := resolveProject(ctx, ws, projectFilter, teamID)
+		if err != nil {
+			return linear.ListIssuesOptions{}, withHint(fmt.Errorf("failed to fetch project: %w", err), "Run 'linear project list' to see available projects")
 		}
-		table.Print()
-	},
+		projectID = project.ID
+	}
+
+	return linear.ListIssuesOptions{
+		Filter:    filter,
+		TeamKey:   teamKey,
+		ProjectID: projectID,
+		Limit:     issueLimit,
+	}, nil
 }
 
 var issueViewCmd = &cobra.Command{
 	Use:   "view <issue-id>",
 	Short: "View issue details",
-	Long: `View detailed information about a specific issue.
+	Long: `View detailed information about a specific issue. Pass "." for the
+issue the current git branch is named after.
+
+The description is rendered as formatted Markdown; use --raw to print
+the original Markdown source.
 
 Examples:
   linear issue view ENG-123
-  linear issue view <issue-uuid>`,
-	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		issueID := args[0]
-
-		c, err := client.NewClient()
+  linear issue view ENG-123 --raw
+  linear issue view <issue-uuid>
+  linear issue view .              # the issue of the current git branch`,
+	Args:              usageArgs(cobra.ExactArgs(1)),
+	ValidArgsFunction: completeIssueID,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ws, err := openWorkspace()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		resp, err := c.GetIssue(ctx, issueID)
+		ctx := cmd.Context()
+		issueID, err := issueArg(ctx, ws, args[0])
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error fetching issue: %v\n", err)
-			os.Exit(1)
-		}
-
-		if resp.Issue == nil {
-			fmt.Fprintf(os.Stderr, "Issue not found: %s\n", issueID)
-			os.Exit(1)
-		}
-
-		issue := resp.Issue
-
-		if jsonOutput {
-			if err := output.PrintJSON(issue); err != nil {
-				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
-				os.Exit(1)
-			}
-			return
-		}
-
-		// Human-readable output
-		fmt.Printf("ID:          %s\n", issue.Identifier)
-		fmt.Printf("Title:       %s\n", issue.Title)
-
-		if issue.State != nil {
-			fmt.Printf("Status:      %s\n", issue.State.Name)
-		}
-
-		if issue.Assignee != nil {
-			fmt.Printf("Assignee:    %s\n", issue.Assignee.Name)
-		}
-
-		if issue.PriorityLabel != "" {
-			fmt.Printf("Priority:    %s\n", issue.PriorityLabel)
-		}
-
-		if issue.Team != nil {
-			fmt.Printf("Team:        %s (%s)\n", issue.Team.Name, issue.Team.Key)
-		}
-
-		if issue.Project != nil {
-			fmt.Printf("Project:     %s\n", issue.Project.Name)
-		}
-
-		if issue.Creator != nil {
-			fmt.Printf("Creator:     %s\n", issue.Creator.Name)
-		}
-
-		fmt.Printf("Created:     %s\n", issue.CreatedAt)
-		fmt.Printf("Updated:     %s\n", issue.UpdatedAt)
-
-		if issue.CompletedAt != nil && *issue.CompletedAt != "" {
-			fmt.Printf("Completed:   %s\n", *issue.CompletedAt)
+			return err
 		}
 
-		fmt.Printf("URL:         %s\n", issue.URL)
-
-		if issue.Description != nil && *issue.Description != "" {
-			fmt.Printf("\nDescription:\n%s\n", *issue.Description)
-		}
-
-		if len(issue.Labels.Nodes) > 0 {
-			fmt.Printf("\nLabels:\n")
-			for _, label := range issue.Labels.Nodes {
-				fmt.Printf("  - %s\n", label.Name)
-			}
+		issue, err := getIssue(ctx, ws, issueID)
+		if err != nil {
+			return err
 		}
+		return printIssue(issue)
 	},
 }
 
@@ -240,57 +200,52 @@ var issueCreateCmd = &cobra.Command{
 	Short: "Create a new issue",
 	Long: `Create a new issue in Linear.
 
+On an interactive terminal, leaving out --team, or giving a team, project or
+assignee that is ambiguous or matches nothing, opens a picker listing the
+candidates; --no-interactive fails instead, as scripts do.
+
 Examples:
   linear issue create --team ENG --title "Fix bug" --description "Bug details"
   linear issue create --team ENG --title "New feature" --project "Mobile App"
   linear issue create --team ENG --title "Task" --project "4e26961e-967f-458f-8fa2-4240035aa178"`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if issueTitle == "" {
-			fmt.Fprintln(os.Stderr, "Error: --title is required")
-			os.Exit(1)
+			return usageErrorf("--title is required")
 		}
 
-		if issueTeamID == "" {
-			fmt.Fprintln(os.Stderr, "Error: --team is required")
-			os.Exit(1)
+		// Without --team, an interactive terminal offers a picker
+		if issueTeamID == "" && !interactive() {
+			return usageErrorf("--team is required")
 		}
 
-		c, err := client.NewClient()
+		ws, err := openWorkspace()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			return err
 		}
+		c := ws.client
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		ctx := cmd.Context()
 
 		// Get team by key to get the team ID
-		teamResp, err := c.GetTeamByKey(ctx, issueTeamID)
+		team, err := resolveTeam(ctx, ws, issueTeamID)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error fetching team: %v\n", err)
-			os.Exit(1)
-		}
-		if len(teamResp.Teams.Nodes) == 0 {
-			fmt.Fprintf(os.Stderr, "Team not found: %s\n", issueTeamID)
-			os.Exit(1)
+			return err
 		}
 
-		teamID := teamResp.Teams.Nodes[0].ID
+		teamID := team.ID
 
 		// Resolve project if specified
 		var projectID string
 		if issueProjectIdentifier != "" {
-			project, err := c.GetProjectByIdentifier(ctx, issueProjectIdentifier, teamID)
+			project, err := resolveProject(ctx, ws, issueProjectIdentifier, teamID)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching project: %v\n", err)
-				fmt.Fprintf(os.Stderr, "Tip: Run 'linear project list --team %s' to see available projects\n", issueTeamID)
-				os.Exit(1)
+				return withHint(fmt.Errorf("failed to fetch project: %w", err), fmt.Sprintf("Run 'linear project list --team %s' to see available projects", team.Key))
 			}
 			projectID = project.ID
 		}
 
 		// Create the issue
-		input := client.CreateIssueInput{
+		input := linear.CreateIssueInput{
 			Title:  issueTitle,
 			TeamID: teamID,
 		}
@@ -304,63 +259,61 @@ Examples:
 		}
 
 		if issueAssignee != "" {
-			user, err := c.GetUserByEmail(ctx, issueAssignee)
+			user, err := resolveUser(ctx, ws, issueAssignee)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching user by email: %v\n", err)
-				os.Exit(1)
+				return fmt.Errorf("failed to fetch user by email: %w", err)
 			}
 			input.AssigneeID = user.ID
 		}
 
 		resp, err := c.CreateIssue(ctx, input)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating issue: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("failed to create issue: %w", err)
 		}
+		ws.invalidate("issues")
 
 		if !resp.IssueCreate.Success {
-			fmt.Fprintln(os.Stderr, "Error: Failed to create issue")
-			os.Exit(1)
+			return errors.New("failed to create issue")
 		}
 
 		if resp.IssueCreate.Issue == nil {
-			fmt.Fprintln(os.Stderr, "Error: Issue was created but no details returned")
-			os.Exit(1)
+			return errors.New("issue was created but no details were returned")
 		}
 
 		issue := resp.IssueCreate.Issue
 
-		if jsonOutput {
-			if err := output.PrintJSON(issue); err != nil {
-				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
-				os.Exit(1)
-			}
-			return
+		if !usesHumanOutput() {
+			table := output.NewTable([]string{"ID", "TITLE", "URL"})
+			table.AddRow([]string{issue.Identifier, issue.Title, issue.URL})
+			return printOutput(issue, table)
 		}
 
 		// Human-readable output
-		fmt.Printf("Issue created successfully!\n")
-		fmt.Printf("ID:    %s\n", issue.Identifier)
-		fmt.Printf("Title: %s\n", issue.Title)
-		fmt.Printf("URL:   %s\n", issue.URL)
+		fmt.Fprintf(output.Stdout, "Issue created successfully!\n")
+		fmt.Fprintf(output.Stdout, "ID:    %s\n", issue.Identifier)
+		fmt.Fprintf(output.Stdout, "Title: %s\n", issue.Title)
+		fmt.Fprintf(output.Stdout, "URL:   %s\n", issue.URL)
+
+		return nil
 	},
 }
 
 var issueUpdateCmd = &cobra.Command{
 	Use:   "update <issue-id>",
 	Short: "Update an existing issue",
-	Long: `Update fields on an existing issue.
+	Long: `Update fields on an existing issue. Pass "." for the issue the current
+git branch is named after.
 
 Examples:
   linear issue update ENG-123 --title "Updated title"
   linear issue update ENG-123 --description "New details"
   linear issue update ENG-123 --priority 1
   linear issue update ENG-123 --project "Mobile App"
-  linear issue update ENG-123 --assignee "user@example.com"`,
-	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		issueID := args[0]
-
+  linear issue update ENG-123 --assignee "user@example.com"
+  linear issue update . --priority 2`,
+	Args:              usageArgs(cobra.ExactArgs(1)),
+	ValidArgsFunction: completeIssueID,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		titleChanged := cmd.Flags().Changed("title")
 		descriptionChanged := cmd.Flags().Changed("description")
 		priorityChanged := cmd.Flags().Changed("priority")
@@ -368,35 +321,34 @@ Examples:
 		assigneeChanged := cmd.Flags().Changed("assignee")
 
 		if !titleChanged && !descriptionChanged && !priorityChanged && !projectChanged && !assigneeChanged {
-			fmt.Fprintln(os.Stderr, "Error: specify at least one field to update (--title, --description, --priority, --project, --assignee)")
-			os.Exit(1)
+			return usageErrorf("specify at least one field to update (--title, --description, --priority, --project, --assignee)")
 		}
 
 		if titleChanged && issueUpdateTitle == "" {
-			fmt.Fprintln(os.Stderr, "Error: --title cannot be empty")
-			os.Exit(1)
+			return usageErrorf("--title cannot be empty")
 		}
 
 		if projectChanged && issueUpdateProject == "" {
-			fmt.Fprintln(os.Stderr, "Error: --project cannot be empty")
-			os.Exit(1)
+			return usageErrorf("--project cannot be empty")
 		}
 
 		if priorityChanged && (issueUpdatePriority < 0 || issueUpdatePriority > 4) {
-			fmt.Fprintln(os.Stderr, "Error: --priority must be between 0 and 4")
-			os.Exit(1)
+			return usageErrorf("--priority must be between 0 and 4")
 		}
 
-		c, err := client.NewClient()
+		ws, err := openWorkspace()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			return err
 		}
+		c := ws.client
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		ctx := cmd.Context()
+		issueID, err := issueArg(ctx, ws, args[0])
+		if err != nil {
+			return err
+		}
 
-		input := client.UpdateIssueInput{}
+		input := linear.UpdateIssueInput{}
 
 		if titleChanged {
 			input.Title = &issueUpdateTitle
@@ -412,13 +364,11 @@ Examples:
 
 		if assigneeChanged {
 			if issueUpdateAssignee == "" {
-				fmt.Fprintf(os.Stderr, "Error: --assignee must not be empty\n")
-				os.Exit(1)
+				return usageErrorf("--assignee must not be empty")
 			} else {
-				user, err := c.GetUserByEmail(ctx, issueUpdateAssignee)
+				user, err := resolveUser(ctx, ws, issueUpdateAssignee)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error fetching user by email: %v\n", err)
-					os.Exit(1)
+					return fmt.Errorf("failed to fetch user by email: %w", err)
 				}
 				input.AssigneeID = &user.ID
 			}
@@ -427,12 +377,10 @@ Examples:
 		if projectChanged {
 			issueResp, err := c.GetIssue(ctx, issueID)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching issue: %v\n", err)
-				os.Exit(1)
+				return fmt.Errorf("failed to fetch issue: %w", err)
 			}
 			if issueResp.Issue == nil {
-				fmt.Fprintf(os.Stderr, "Issue not found: %s\n", issueID)
-				os.Exit(1)
+				return notFoundErrorf("issue not found: %s", issueID)
 			}
 
 			teamID := ""
@@ -440,11 +388,9 @@ Examples:
 				teamID = issueResp.Issue.Team.ID
 			}
 
-			project, err := c.GetProjectByIdentifier(ctx, issueUpdateProject, teamID)
+			project, err := resolveProject(ctx, ws, issueUpdateProject, teamID)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching project: %v\n", err)
-				fmt.Fprintln(os.Stderr, "Tip: Run 'linear project list' to see available projects")
-				os.Exit(1)
+				return withHint(fmt.Errorf("failed to fetch project: %w", err), "Run 'linear project list' to see available projects")
 			}
 
 			input.ProjectID = &project.ID
@@ -452,42 +398,132 @@ Examples:
 
 		resp, err := c.UpdateIssue(ctx, issueID, input)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error updating issue: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("failed to update issue: %w", err)
 		}
+		ws.invalidate("issues")
 
 		if !resp.IssueUpdate.Success {
-			fmt.Fprintln(os.Stderr, "Error: Failed to update issue")
-			os.Exit(1)
+			return errors.New("failed to update issue")
 		}
 
 		if resp.IssueUpdate.Issue == nil {
-			fmt.Fprintln(os.Stderr, "Error: Issue was updated but no details returned")
-			os.Exit(1)
+			return errors.New("issue was updated but no details were returned")
 		}
 
 		issue := resp.IssueUpdate.Issue
 
-		if jsonOutput {
-			if err := output.PrintJSON(issue); err != nil {
-				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
-				os.Exit(1)
-			}
-			return
+		if !usesHumanOutput() {
+			table := output.NewTable([]string{"ID", "TITLE", "URL"})
+			table.AddRow([]string{issue.Identifier, issue.Title, issue.URL})
+			return printOutput(issue, table)
 		}
 
-		fmt.Printf("Issue updated successfully!\n")
-		fmt.Printf("ID:    %s\n", issue.Identifier)
-		fmt.Printf("Title: %s\n", issue.Title)
-		fmt.Printf("URL:   %s\n", issue.URL)
+		fmt.Fprintf(output.Stdout, "Issue updated successfully!\n")
+		fmt.Fprintf(output.Stdout, "ID:    %s\n", issue.Identifier)
+		fmt.Fprintf(output.Stdout, "Title: %s\n", issue.Title)
+		fmt.Fprintf(output.Stdout, "URL:   %s\n", issue.URL)
+
+		return nil
 	},
 }
 
+// printIssue shows an issue in full, as issue view does
+func printIssue(issue *linear.Issue) error {
+	if !usesHumanOutput() {
+		return printOutput(issue, issueSummaryTable(issue))
+	}
+
+	// Human-readable output
+	fmt.Fprintf(output.Stdout, "ID:          %s\n", issue.Identifier)
+	fmt.Fprintf(output.Stdout, "Title:       %s\n", issue.Title)
+
+	if issue.State != nil {
+		fmt.Fprintf(output.Stdout, "Status:      %s\n", output.Chip(issue.State.Color, issue.State.Name))
+	}
+
+	if issue.Assignee != nil {
+		fmt.Fprintf(output.Stdout, "Assignee:    %s\n", issue.Assignee.Name)
+	}
+
+	if issue.PriorityLabel != "" {
+		priority := issue.PriorityLabel
+		if output.ColorEnabled() {
+			priority = output.PriorityIcon(issue.Priority) + " " + priority
+		}
+		fmt.Fprintf(output.Stdout, "Priority:    %s\n", priority)
+	}
+
+	if issue.Team != nil {
+		fmt.Fprintf(output.Stdout, "Team:        %s (%s)\n", issue.Team.Name, issue.Team.Key)
+	}
+
+	if issue.Project != nil {
+		fmt.Fprintf(output.Stdout, "Project:     %s\n", issue.Project.Name)
+	}
+
+	if issue.Creator != nil {
+		fmt.Fprintf(output.Stdout, "Creator:     %s\n", issue.Creator.Name)
+	}
+
+	fmt.Fprintf(output.Stdout, "Created:     %s\n", issue.CreatedAt)
+	fmt.Fprintf(output.Stdout, "Updated:     %s\n", issue.UpdatedAt)
+
+	if issue.CompletedAt != nil && *issue.CompletedAt != "" {
+		fmt.Fprintf(output.Stdout, "Completed:   %s\n", *issue.CompletedAt)
+	}
+
+	fmt.Fprintf(output.Stdout, "URL:         %s\n", issue.URL)
+
+	if issue.Description != nil && *issue.Description != "" {
+		description := *issue.Description
+		if !issueViewRaw {
+			description = output.RenderMarkdown(description, output.TerminalMarkdownOptions())
+		}
+		fmt.Fprintf(output.Stdout, "\nDescription:\n%s\n", strings.TrimRight(description, "\n"))
+	}
+
+	if len(issue.Labels.Nodes) > 0 {
+		fmt.Fprintf(output.Stdout, "\nLabels:\n")
+		for _, label := range issue.Labels.Nodes {
+			fmt.Fprintf(output.Stdout, "  - %s\n", output.Chip(label.Color, label.Name))
+		}
+	}
+
+	return nil
+}
+
+// issueSummaryTable renders a single issue as a one-row table for the
+// tabular output formats
+func issueSummaryTable(issue *linear.Issue) *output.Table {
+	table := output.NewTable([]string{"ID", "TITLE", "STATUS", "ASSIGNEE", "PRIORITY", "URL"})
+
+	status := ""
+	if issue.State != nil {
+		status = issue.State.Name
+	}
+
+	assignee := ""
+	if issue.Assignee != nil {
+		assignee = issue.Assignee.Name
+	}
+
+	table.AddRow([]string{issue.Identifier, issue.Title, status, assignee, issue.PriorityLabel, issue.URL})
+	return table
+}
+
 func init() {
 	issueListCmd.Flags().StringVar(&teamFilter, "team", "", "Filter by team key (e.g., ENG)")
 	issueListCmd.Flags().StringVar(&projectFilter, "project", "", "Filter by project name or ID")
 	issueListCmd.Flags().IntVar(&issueLimit, "limit", 50, "Maximum number of issues to fetch (default: 50)")
 	issueListCmd.Flags().BoolVar(&fetchAll, "all", false, "Fetch all issues using pagination")
+	issueListCmd.Flags().StringSliceVar(&issueColumnsFlag, "columns", nil, "Comma-separated columns for table, CSV and Markdown output")
+	issueListCmd.Flags().StringSliceVar(&issueSortFlag, "sort", nil, "Comma-separated columns to sort by; prefix with '-' for descending")
+	issueListCmd.Flags().StringVar(&issueStateFilter, "state", "", "Filter by workflow state name (e.g., \"In Progress\")")
+	issueListCmd.Flags().StringVar(&issueLabelFilter, "label", "", "Filter by label name")
+	issueListCmd.Flags().StringVarP(&issueQueryFlag, "query", "q", "", "Filter with a query, e.g. 'label:bug priority<=2 -state:Done'")
+	issueListCmd.Flags().StringVar(&issueSavedQueryFlag, "saved", "", "Filter with a query saved under this name in the config file")
+
+	issueViewCmd.Flags().BoolVar(&issueViewRaw, "raw", false, "Print the description as raw Markdown")
 
 	issueCreateCmd.Flags().StringVar(&issueTitle, "title", "", "Issue title (required)")
 	issueCreateCmd.Flags().StringVar(&issueDesc, "description", "", "Issue description")
@@ -501,6 +537,16 @@ func init() {
 	issueUpdateCmd.Flags().StringVar(&issueUpdateProject, "project", "", "Updated project name or ID")
 	issueUpdateCmd.Flags().StringVar(&issueUpdateAssignee, "assignee", "", "Updated issue assignee (email)")
 
+	_ = issueListCmd.RegisterFlagCompletionFunc("team", completeTeams)
+	_ = issueListCmd.RegisterFlagCompletionFunc("project", completeProjects)
+	_ = issueListCmd.RegisterFlagCompletionFunc("state", completeStates)
+	_ = issueListCmd.RegisterFlagCompletionFunc("label", completeLabels)
+	_ = issueCreateCmd.RegisterFlagCompletionFunc("team", completeTeams)
+	_ = issueCreateCmd.RegisterFlagCompletionFunc("project", completeProjects)
+	_ = issueCreateCmd.RegisterFlagCompletionFunc("assignee", completeUsers)
+	_ = issueUpdateCmd.RegisterFlagCompletionFunc("project", completeProjects)
+	_ = issueUpdateCmd.RegisterFlagCompletionFunc("assignee", completeUsers)
+
 	issueCmd.AddCommand(issueListCmd)
 	issueCmd.AddCommand(issueViewCmd)
 	issueCmd.AddCommand(issueCreateCmd)
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear/lineartest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// runCLI runs the root command with args against a fake Linear API and
+// returns what it printed
+func runCLI(t *testing.T, srv *lineartest.Server, args ...string) (string, error) {
+	t.Helper()
+	return runCLIConfig(t, srv, "", args...)
+}
+
+// runCLIConfig is runCLI with the given config file contents
+func runCLIConfig(t *testing.T, srv *lineartest.Server, config string, args ...string) (string, error) {
+	t.Helper()
+
+	t.Setenv("LINEAR_API_KEY", lineartest.DefaultAPIKey)
+	t.Setenv("LINEAR_API_URL", srv.URL)
+	return execCLIConfig(t, config, args...)
+}
+
+// execCLI runs the root command with args in the current environment and
+// returns what it printed
+func execCLI(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return execCLIConfig(t, "", args...)
+}
+
+// execCLIConfig is execCLI with the given config file contents
+func execCLIConfig(t *testing.T, config string, args ...string) (string, error) {
+	t.Helper()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("LINEAR_CONFIG", configPath)
+	setCacheDir(t)
+
+	var buf bytes.Buffer
+	originalStdout := output.Stdout
+	output.Stdout = &buf
+	defer func() {
+		output.Stdout = originalStdout
+		cancelTimeout()
+		resetFlags(rootCmd)
+	}()
+
+	resetFlags(rootCmd)
+	rootCmd.SetArgs(append(args, "--no-pager", "--color", "never"))
+	err := rootCmd.ExecuteContext(context.Background())
+	return buf.String(), err
+}
+
+// testCacheDir is the cache directory shared by a test's runs after
+// keepCache; otherwise each run starts with an empty cache
+var testCacheDir string
+
+// keepCache makes the rest of a test's runs share one cache directory
+func keepCache(t *testing.T) {
+	testCacheDir = t.TempDir()
+	t.Cleanup(func() { testCacheDir = "" })
+}
+
+// setCacheDir points LINEAR_CACHE_DIR at the cache for the next run
+func setCacheDir(t *testing.T) {
+	dir := testCacheDir
+	if dir == "" {
+		dir = t.TempDir()
+	}
+	t.Setenv("LINEAR_CACHE_DIR", dir)
+}
+
+// resetFlags restores every flag to its default so runs don't leak into
+// each other
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if v, ok := f.Value.(pflag.SliceValue); ok {
+			_ = v.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
+
+func newIssueServer(t *testing.T) (*lineartest.Server, lineartest.Team) {
+	t.Helper()
+
+	srv := lineartest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddUser(lineartest.User{Name: "Ada Lovelace", Email: "ada@example.com"})
+	srv.AddUser(lineartest.User{Name: "Bob Smith", Email: "bob@example.com"})
+	team := srv.AddTeam(lineartest.Team{Key: "ENG", Name: "Engineering"})
+	srv.AddProject(lineartest.Project{Name: "Mobile App", TeamIDs: []string{team.ID}})
+	return srv, team
+}
+
+func TestIssueCreateThenUpdate(t *testing.T) {
+	srv, _ := newIssueServer(t)
+
+	out, err := runCLI(t, srv, "issue", "create", "--team", "ENG", "--title", "Crash on launch", "--assignee", "bob@example.com")
+	if err != nil {
+		t.Fatalf("issue create: %v", err)
+	}
+	if !strings.Contains(out, "ID:    ENG-1") {
+		t.Errorf("unexpected create output:\n%s", out)
+	}
+
+	if _, err := runCLI(t, srv, "issue", "update", "ENG-1", "--project", "mobile app", "--priority", "2"); err != nil {
+		t.Fatalf("issue update: %v", err)
+	}
+
+	out, err = runCLI(t, srv, "issue", "view", "ENG-1")
+	if err != nil {
+		t.Fatalf("issue view: %v", err)
+	}
+	for _, want := range []string{"Title:       Crash on launch", "Status:      Backlog", "Assignee:    Bob Smith", "Priority:    High", "Project:     Mobile App"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("issue view output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestIssueList_JSON(t *testing.T) {
+	srv, team := newIssueServer(t)
+	other := srv.AddTeam(lineartest.Team{Key: "OPS"})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "First"})
+	srv.AddIssue(lineartest.Issue{TeamID: other.ID, Title: "Elsewhere"})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Second"})
+
+	out, err := runCLI(t, srv, "issue", "list", "--team", "ENG", "--jq", "[.[].identifier]")
+	if err != nil {
+		t.Fatalf("issue list: %v", err)
+	}
+
+	var ids []string
+	if err := json.Unmarshal([]byte(out), &ids); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if strings.Join(ids, ",") != "ENG-1,ENG-2" {
+		t.Errorf("identifiers = %v", ids)
+	}
+}
+
+func TestIssueView_NotFound(t *testing.T) {
+	srv, _ := newIssueServer(t)
+
+	_, err := runCLI(t, srv, "issue", "view", "ENG-404")
+	if code := classifyError(err); code != codeNotFound {
+		t.Errorf("classifyError(%v) = %s, want %s", err, code, codeNotFound)
+	}
+}
+
+func TestIssueListAll_PartialFailure(t *testing.T) {
+	srv, team := newIssueServer(t)
+	for i := 0; i < 150; i++ {
+		srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Issue"})
+	}
+	srv.Fail(lineartest.Fault{Operation: "issues", Status: http.StatusBadRequest, Message: "Query too complex", After: 1})
+
+	out, err := runCLI(t, srv, "issue", "list", "--all", "--format", "json")
+	if code := classifyError(err); code != codePartialFailure {
+		t.Fatalf("classifyError(%v) = %s, want %s", err, code, codePartialFailure)
+	}
+
+	var issues []map[string]any
+	if err := json.Unmarshal([]byte(out), &issues); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(issues) != 100 {
+		t.Errorf("printed %d issues, want the first page of 100", len(issues))
+	}
+}
+
+func TestIssueList_Query(t *testing.T) {
+	srv, team := newIssueServer(t)
+	bug := srv.AddLabel(lineartest.Label{Name: "Bug"})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Crash", Priority: 1, LabelIDs: []string{bug.ID}})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Typo", Priority: 4, LabelIDs: []string{bug.ID}, StateID: srv.States(team.ID)[3].ID})
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Feature", Priority: 2})
+
+	config := "queries:\n  bugs: label:bug\n"
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"query", []string{"--query", "label:bug priority<=2"}, "ENG-1"},
+		{"saved", []string{"--saved", "bugs"}, "ENG-1,ENG-2"},
+		{"saved and query", []string{"--saved", "bugs", "-q", "NOT crash"}, "ENG-2"},
+		{"label", []string{"--label", "BUG"}, "ENG-1,ENG-2"},
+		{"state", []string{"--state", "done"}, "ENG-2"},
+		{"label and query", []string{"--label", "bug", "-q", "priority<=2"}, "ENG-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"issue", "list", "--jq", `[.[].identifier] | sort | join(",")`}, tt.args...)
+			out, err := runCLIConfig(t, srv, config, args...)
+			if err != nil {
+				t.Fatalf("issue list: %v", err)
+			}
+			if got := strings.Trim(strings.TrimSpace(out), `"`); got != tt.want {
+				t.Errorf("issues = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssueList_QueryErrors(t *testing.T) {
+	srv, _ := newIssueServer(t)
+
+	_, err := runCLI(t, srv, "issue", "list", "--query", "label:bug OR")
+	if code := classifyError(err); code != codeUsage {
+		t.Errorf("classifyError(%v) = %s, want %s", err, code, codeUsage)
+	}
+	if err == nil || !strings.Contains(err.Error(), "label:bug OR\n              ^") {
+		t.Errorf("error should point at the problem:\n%v", err)
+	}
+
+	_, err = runCLI(t, srv, "issue", "list", "--saved", "triage")
+	if code := classifyError(err); code != codeUsage {
+		t.Errorf("classifyError(%v) = %s, want %s", err, code, codeUsage)
+	}
+	if len(srv.Requests()) != 0 {
+		t.Errorf("invalid queries should fail before any request, got %d", len(srv.Requests()))
+	}
+}
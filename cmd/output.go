@@ -0,0 +1,45 @@
+package cmd
+
+import "github.com/dukky/linear/internal/output"
+
+// printOutput renders data with the selected template or output format.
+// Structured formats are trimmed to --fields when it is set, and JSON is
+// filtered through --jq.
+func printOutput(data interface{}, table *output.Table) error {
+	if outputTemplate != nil {
+		return output.RenderTemplate(output.Stdout, outputTemplate, data)
+	}
+
+	if outputFormat.IsStructured() && len(fieldsFlag) > 0 {
+		projected, err := output.SelectFields(data, fieldsFlag)
+		if err != nil {
+			return err
+		}
+		data = projected
+	}
+
+	if outputQuery != nil {
+		return output.WriteQuery(output.Stdout, outputQuery, data)
+	}
+	return output.Print(outputFormat, data, table)
+}
+
+// buildListTable sorts items and renders the selected columns as a table
+func buildListTable[T any](columns *output.ColumnSet[T], items []T, columnNames, sortSpec []string) (*output.Table, error) {
+	selected, err := columns.Select(columnNames)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sortSpec) > 0 {
+		keys, err := output.ParseSortSpec(sortSpec)
+		if err != nil {
+			return nil, err
+		}
+		if err := columns.Sort(items, keys); err != nil {
+			return nil, err
+		}
+	}
+
+	return columns.Table(selected, items), nil
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dukky/linear/internal/tui"
+	"github.com/dukky/linear/linear"
+	"golang.org/x/term"
+)
+
+// noInteractiveFlag turns off pickers, so missing or ambiguous values fail
+// as they do in scripts
+var noInteractiveFlag bool
+
+// promptTerminal reports whether someone can answer a picker, which reads
+// stdin and draws on stderr so that stdout can still be piped; tests
+// replace it
+var promptTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// interactive reports whether commands may ask the user to pick missing
+// or ambiguous values
+func interactive() bool {
+	return !noInteractiveFlag && promptTerminal()
+}
+
+// pickItem shows menu until the user chooses from it, reporting false if
+// they cancel; tests replace it
+var pickItem = func(ctx context.Context, menu *tui.Menu) (bool, error) {
+	return tui.Pick(ctx, os.Stdin, os.Stderr, menu)
+}
+
+// pick offers items narrowed by filter and returns the chosen value, or ""
+// if the user cancels
+func pick(ctx context.Context, title, filter string, items []tui.Item) (string, error) {
+	menu := tui.NewMenu(title, items)
+	menu.SetFilter(filter)
+	chosen, err := pickItem(ctx, menu)
+	if err != nil || !chosen {
+		return "", err
+	}
+	item, _ := menu.Current()
+	return item.Value, nil
+}
+
+// resolveTeam returns the team with key. On an interactive terminal an
+// empty or unknown key lets the user pick a team instead; otherwise, or if
+// they cancel, it fails as it would without a picker.
+func resolveTeam(ctx context.Context, ws *workspace, key string) (*linear.Team, error) {
+	var failure error
+	if key == "" {
+		failure = usageErrorf("--team is required")
+	} else {
+		team, err := ws.team(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch team: %w", err)
+		}
+		if team != nil {
+			return team, nil
+		}
+		failure = notFoundErrorf("team not found: %s", key)
+	}
+	if !interactive() {
+		return nil, failure
+	}
+
+	teams, err := ws.teams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch teams: %w", err)
+	}
+	items := make([]tui.Item, 0, len(teams))
+	for _, team := range teams {
+		items = append(items, tui.Item{Label: team.Key, Detail: team.Name, Value: team.ID})
+	}
+
+	id, err := pick(ctx, "Pick a team", key, items)
+	if err != nil {
+		return nil, err
+	}
+	for i := range teams {
+		if teams[i].ID == id {
+			return &teams[i], nil
+		}
+	}
+	return nil, failure
+}
+
+// resolveProject resolves a project name or ID, within a team when teamID
+// is set. On an interactive terminal a name matching several projects, or
+// none, lets the user pick one; otherwise, or if they cancel, the lookup's
+// error is returned.
+func resolveProject(ctx context.Context, ws *workspace, identifier, teamID string) (*linear.Project, error) {
+	project, err := ws.project(ctx, identifier, teamID)
+	if err == nil || !interactive() {
+		return project, err
+	}
+
+	// Offer the projects the error lists, or all of them with the name
+	// typed into the filter
+	var candidates []linear.Project
+	var filter string
+	var ambiguous *linear.AmbiguousProjectError
+	switch {
+	case errors.As(err, &ambiguous):
+		candidates = ambiguous.Candidates
+	case errors.Is(err, linear.ErrNotFound):
+		all, fetchErr := ws.projects(ctx, teamID)
+		if fetchErr != nil {
+			return nil, err
+		}
+		candidates, filter = all, identifier
+	default:
+		return nil, err
+	}
+
+	items := make([]tui.Item, 0, len(candidates))
+	for _, project := range candidates {
+		items = append(items, tui.Item{Label: project.Name, Detail: project.ID, Value: project.ID})
+	}
+
+	id, pickErr := pick(ctx, fmt.Sprintf("Which project did you mean by %q?", identifier), filter, items)
+	if pickErr != nil {
+		return nil, pickErr
+	}
+	for i := range candidates {
+		if candidates[i].ID == id {
+			return &candidates[i], nil
+		}
+	}
+	return nil, err
+}
+
+// resolveUser returns the user with an email address. On an interactive
+// terminal an address matching nobody, such as a name, lets the user pick
+// someone instead; otherwise, or if they cancel, the lookup's error is
+// returned.
+func resolveUser(ctx context.Context, ws *workspace, email string) (*linear.User, error) {
+	user, err := ws.user(ctx, email)
+	if err == nil || !interactive() || !errors.Is(err, linear.ErrNotFound) {
+		return user, err
+	}
+
+	users, fetchErr := ws.users(ctx)
+	if fetchErr != nil {
+		return nil, err
+	}
+	items := make([]tui.Item, 0, len(users))
+	for _, u := range users {
+		items = append(items, tui.Item{Label: u.Name, Detail: u.Email, Value: u.ID})
+	}
+
+	id, pickErr := pick(ctx, fmt.Sprintf("Who did you mean by %q?", email), email, items)
+	if pickErr != nil {
+		return nil, pickErr
+	}
+	for i := range users {
+		if users[i].ID == id {
+			return &users[i], nil
+		}
+	}
+	return nil, err
+}
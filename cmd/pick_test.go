@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dukky/linear/internal/tui"
+	"github.com/dukky/linear/linear"
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+// shownMenu records a menu a fake picker was shown
+type shownMenu struct {
+	title  string
+	filter string
+	labels []string
+}
+
+// fakePicker pretends to run on a terminal and answers pickers with the
+// item labeled choice, or cancels when choice is empty. It returns the
+// menus it was shown.
+func fakePicker(t *testing.T, choice string) *[]shownMenu {
+	t.Helper()
+
+	originalTerminal, originalPick := promptTerminal, pickItem
+	t.Cleanup(func() { promptTerminal, pickItem = originalTerminal, originalPick })
+
+	var shown []shownMenu
+	promptTerminal = func() bool { return true }
+	pickItem = func(ctx context.Context, menu *tui.Menu) (bool, error) {
+		var labels []string
+		for _, item := range menu.Items {
+			labels = append(labels, item.Label)
+		}
+		shown = append(shown, shownMenu{title: menu.Title, filter: menu.Filter(), labels: labels})
+
+		if choice == "" {
+			return false, nil
+		}
+		menu.SetFilter("")
+		for {
+			item, ok := menu.Current()
+			if !ok {
+				t.Fatalf("the picker does not offer %q: %q", choice, labels)
+			}
+			if item.Label == choice {
+				return true, nil
+			}
+			before := item
+			menu.HandleKey(tui.Key{Type: tui.KeyDown})
+			if after, _ := menu.Current(); after == before {
+				t.Fatalf("the picker does not offer %q: %q", choice, labels)
+			}
+		}
+	}
+	return &shown
+}
+
+func TestPicker_MissingTeam(t *testing.T) {
+	srv, _ := newIssueServer(t)
+	srv.AddTeam(lineartest.Team{Key: "OPS", Name: "Operations"})
+	shown := fakePicker(t, "OPS")
+
+	if _, err := runCLI(t, srv, "issue", "create", "--title", "Renew certificates"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*shown) != 1 || strings.Join((*shown)[0].labels, ",") != "ENG,OPS" {
+		t.Errorf("pickers shown = %+v, want one listing the teams", *shown)
+	}
+	if issues := srv.Issues(); len(issues) != 1 || issues[0].Identifier != "OPS-1" {
+		t.Errorf("issues = %+v, want OPS-1", issues)
+	}
+}
+
+func TestPicker_AmbiguousProject(t *testing.T) {
+	srv, team := newIssueServer(t)
+	platform := srv.AddProject(lineartest.Project{Name: "Mobile Platform", TeamIDs: []string{team.ID}})
+	srv.AddProject(lineartest.Project{Name: "Web", TeamIDs: []string{team.ID}})
+	shown := fakePicker(t, "Mobile Platform")
+
+	if _, err := runCLI(t, srv, "issue", "create", "--team", "ENG", "--title", "Crash", "--project", "mobile"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the candidates from the error are offered
+	if len(*shown) != 1 || strings.Join((*shown)[0].labels, ",") != "Mobile App,Mobile Platform" {
+		t.Errorf("pickers shown = %+v, want the two Mobile projects", *shown)
+	}
+	if issues := srv.Issues(); len(issues) != 1 || issues[0].ProjectID != platform.ID {
+		t.Errorf("issues = %+v, want one in Mobile Platform", issues)
+	}
+}
+
+func TestPicker_UnknownAssignee(t *testing.T) {
+	srv, team := newIssueServer(t)
+	issue := srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Crash"})
+	shown := fakePicker(t, "Bob Smith")
+
+	if _, err := runCLI(t, srv, "issue", "update", "ENG-1", "--assignee", "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*shown) != 1 || (*shown)[0].filter != "bob" {
+		t.Errorf("pickers shown = %+v, want one narrowed to bob", *shown)
+	}
+	got, _ := srv.Issue(issue.ID)
+	if got.AssigneeID == "" {
+		t.Error("the issue was not assigned")
+	}
+}
+
+func TestPicker_FallsBackToErrors(t *testing.T) {
+	srv, team := newIssueServer(t)
+	srv.AddProject(lineartest.Project{Name: "Mobile Platform", TeamIDs: []string{team.ID}})
+	args := []string{"issue", "create", "--team", "ENG", "--title", "Crash", "--project", "mobile"}
+
+	t.Run("no-interactive", func(t *testing.T) {
+		shown := fakePicker(t, "Mobile App")
+		_, err := runCLI(t, srv, append(args, "--no-interactive")...)
+		if !errors.Is(err, linear.ErrAmbiguous) {
+			t.Errorf("error = %v, want the ambiguous project error", err)
+		}
+		if len(*shown) != 0 {
+			t.Errorf("pickers shown = %+v, want none", *shown)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		fakePicker(t, "")
+		_, err := runCLI(t, srv, args...)
+		if !errors.Is(err, linear.ErrAmbiguous) {
+			t.Errorf("error = %v, want the ambiguous project error", err)
+		}
+	})
+
+	t.Run("missing team", func(t *testing.T) {
+		fakePicker(t, "")
+		_, err := runCLI(t, srv, "issue", "create", "--title", "Crash")
+		if err == nil || classifyError(err) != codeUsage || !strings.Contains(err.Error(), "--team is required") {
+			t.Errorf("error = %v, want --team is required", err)
+		}
+	})
+
+	if len(srv.Issues()) != 0 {
+		t.Errorf("issues = %+v, want none created", srv.Issues())
+	}
+}
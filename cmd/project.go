@@ -1,18 +1,16 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"time"
 
-	"github.com/dukky/linear/internal/client"
-	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
 	"github.com/spf13/cobra"
 )
 
 var (
-	projectTeamFilter string
+	projectTeamFilter  string
+	projectColumnsFlag []string
+	projectSortFlag    []string
 )
 
 var projectCmd = &cobra.Command{
@@ -25,66 +23,55 @@ var projectListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List projects",
 	Long:  "List all projects in your Linear workspace, optionally filtered by team",
-	Run: func(cmd *cobra.Command, args []string) {
-		c, err := client.NewClient()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ws, err := openWorkspace()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			return err
 		}
+		c := ws.client
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		ctx := cmd.Context()
 
-		var resp *client.ProjectsResponse
+		var resp *linear.ProjectsResponse
 
 		if projectTeamFilter != "" {
 			// Get team by key first
-			teamResp, err := c.GetTeamByKey(ctx, projectTeamFilter)
+			team, err := resolveTeam(ctx, ws, projectTeamFilter)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching team: %v\n", err)
-				os.Exit(1)
-			}
-			if len(teamResp.Teams.Nodes) == 0 {
-				fmt.Fprintf(os.Stderr, "Team not found: %s\n", projectTeamFilter)
-				os.Exit(1)
+				return err
 			}
 
-			teamID := teamResp.Teams.Nodes[0].ID
+			teamID := team.ID
 
 			// Get projects for the team
 			resp, err = c.GetProjectsByTeam(ctx, teamID)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching projects: %v\n", err)
-				os.Exit(1)
+				return fmt.Errorf("failed to fetch projects: %w", err)
 			}
 		} else {
 			// Get all projects
 			resp, err = c.ListProjects(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching projects: %v\n", err)
-				os.Exit(1)
+				return fmt.Errorf("failed to fetch projects: %w", err)
 			}
 		}
 
-		if jsonOutput {
-			if err := output.PrintJSON(resp.Projects.Nodes); err != nil {
-				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
-				os.Exit(1)
-			}
-			return
+		projects := resp.Projects.Nodes
+		table, err := buildListTable(projectColumns, projects, projectColumnsFlag, projectSortFlag)
+		if err != nil {
+			return err
 		}
 
-		// Table output
-		table := output.NewTable([]string{"ID", "NAME"})
-		for _, project := range resp.Projects.Nodes {
-			table.AddRow([]string{project.ID, project.Name})
-		}
-		table.Print()
+		return printOutput(projects, table)
 	},
 }
 
 func init() {
 	projectListCmd.Flags().StringVar(&projectTeamFilter, "team", "", "Filter projects by team key (e.g., ENG)")
+	projectListCmd.Flags().StringSliceVar(&projectColumnsFlag, "columns", nil, "Comma-separated columns for table, CSV and Markdown output (id, name)")
+	projectListCmd.Flags().StringSliceVar(&projectSortFlag, "sort", nil, "Comma-separated columns to sort by; prefix with '-' for descending")
+
+	_ = projectListCmd.RegisterFlagCompletionFunc("team", completeTeams)
 
 	projectCmd.AddCommand(projectListCmd)
 	rootCmd.AddCommand(projectCmd)
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/dukky/linear/internal/config"
+	"github.com/dukky/linear/internal/query"
+	"github.com/dukky/linear/linear"
+)
+
+// issueListFilter builds the issue filter for --state, --label, --saved and
+// --query; issues must match all of those given
+func issueListFilter() (linear.IssueFilter, error) {
+	var filters []linear.IssueFilter
+
+	if issueStateFilter != "" {
+		filters = append(filters, linear.IssueFilter{}.State(linear.StateFilter{}.Name(linear.EqIgnoreCase(issueStateFilter))))
+	}
+
+	if issueLabelFilter != "" {
+		filters = append(filters, linear.IssueFilter{}.Labels(linear.Some(linear.LabelFilter{}.Name(linear.EqIgnoreCase(issueLabelFilter)))))
+	}
+
+	if issueSavedQueryFlag != "" {
+		src, ok := cfg.Query(issueSavedQueryFlag)
+		if !ok {
+			return linear.IssueFilter{}, withHint(usageErrorf("no saved query named %q", issueSavedQueryFlag), savedQueriesHint())
+		}
+		f, err := compileQuery(src)
+		if err != nil {
+			return linear.IssueFilter{}, usageErrorf("saved query %q: %w", issueSavedQueryFlag, err)
+		}
+		filters = append(filters, f)
+	}
+
+	if issueQueryFlag != "" {
+		f, err := compileQuery(issueQueryFlag)
+		if err != nil {
+			return linear.IssueFilter{}, usageErrorf("%w", err)
+		}
+		filters = append(filters, f)
+	}
+
+	switch len(filters) {
+	case 0:
+		return linear.IssueFilter{}, nil
+	case 1:
+		return filters[0], nil
+	}
+	return linear.IssueFilter{}.And(filters...), nil
+}
+
+// compileQuery compiles src, showing where a syntax error is
+func compileQuery(src string) (linear.IssueFilter, error) {
+	f, err := query.Compile(src)
+	var qerr *query.Error
+	if errors.As(err, &qerr) {
+		return f, &queryError{err: qerr}
+	}
+	return f, err
+}
+
+// queryError reports a query error with the query and a caret under the
+// offending position
+type queryError struct {
+	err *query.Error
+}
+
+func (e *queryError) Error() string {
+	return e.err.Error() + "\n  " + strings.ReplaceAll(e.err.Caret(), "\n", "\n  ")
+}
+
+func (e *queryError) Unwrap() error {
+	return e.err
+}
+
+// savedQueriesHint lists the saved queries, or explains how to add one
+func savedQueriesHint() string {
+	var names []string
+	if cfg != nil {
+		for name := range cfg.Queries {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		path, _ := config.Path()
+		return "Save queries under \"queries:\" in " + path
+	}
+	sort.Strings(names)
+	return "Saved queries: " + strings.Join(names, ", ")
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/dukky/linear/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// now is the current time reports are relative to; tests replace it
+var now = time.Now
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize issues for releases, standups and planning",
+	Long: `Build reports from issues, such as changelogs for releases, standup
+summaries and flow metrics.`,
+}
+
+// relativeTime matches durations back from now such as 12h, 7d or 3m
+var relativeTime = regexp.MustCompile(`^(\d+)([hdwmy])$`)
+
+// parseReportTime parses the value of a --since or --until flag: a
+// duration back from now (12h, 7d, 2w, 3m, 1y), a date, a timestamp, or a
+// git tag or other ref, which stands for the date of its commit. A date
+// passed with end set means the end of that day.
+func parseReportTime(ctx context.Context, flag, value string, end bool) (time.Time, error) {
+	if m := relativeTime.FindStringSubmatch(value); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		t := now()
+		switch m[2] {
+		case "h":
+			return t.Add(-time.Duration(n) * time.Hour), nil
+		case "d":
+			return t.AddDate(0, 0, -n), nil
+		case "w":
+			return t.AddDate(0, 0, -7*n), nil
+		case "m":
+			return t.AddDate(0, -n, 0), nil
+		default:
+			return t.AddDate(-n, 0, 0), nil
+		}
+	}
+	if day, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		if end {
+			day = day.AddDate(0, 0, 1)
+		}
+		return day, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := git.CommitTime(ctx, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, usageErrorf("invalid %s %q (use a date such as 2025-09-01, a duration such as 7d, or a git tag)", flag, value)
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fixNow pins the time reports are relative to
+func fixNow(t *testing.T, at time.Time) {
+	t.Helper()
+	original := now
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = original })
+}
+
+func TestParseReportTime(t *testing.T) {
+	at := time.Date(2025, 10, 1, 12, 0, 0, 0, time.Local)
+	fixNow(t, at)
+	newGitRepo(t)
+	gitRun(t, "tag", "v1.4")
+	ctx := context.Background()
+
+	tests := []struct {
+		value string
+		end   bool
+		want  time.Time
+	}{
+		{value: "12h", want: at.Add(-12 * time.Hour)},
+		{value: "7d", want: time.Date(2025, 9, 24, 12, 0, 0, 0, time.Local)},
+		{value: "2w", want: time.Date(2025, 9, 17, 12, 0, 0, 0, time.Local)},
+		{value: "3m", want: time.Date(2025, 7, 1, 12, 0, 0, 0, time.Local)},
+		{value: "2025-09-01", want: time.Date(2025, 9, 1, 0, 0, 0, 0, time.Local)},
+		{value: "2025-09-30", end: true, want: time.Date(2025, 10, 1, 0, 0, 0, 0, time.Local)},
+		{value: "2025-09-01T08:00:00Z", want: time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseReportTime(ctx, "--since", tt.value, tt.end)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseReportTime(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+
+	if tagged, err := parseReportTime(ctx, "--since", "v1.4", false); err != nil || time.Since(tagged) > time.Hour {
+		t.Errorf("parseReportTime(v1.4) = %v, %v; want the tag's commit date", tagged, err)
+	}
+	if _, err := parseReportTime(ctx, "--since", "yesterday", false); err == nil || classifyError(err) != codeUsage {
+		t.Errorf("error = %v, want a usage error", err)
+	}
+}
@@ -1,15 +1,37 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"text/template"
+	"time"
 
+	"github.com/dukky/linear/internal/config"
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
 	"github.com/spf13/cobra"
 )
 
 var (
-	jsonOutput bool
-	rootCmd    = &cobra.Command{
+	jsonOutput       bool
+	formatFlag       string
+	fieldsFlag       []string
+	templateFlag     string
+	templateFileFlag string
+	jqFlag           string
+	colorFlag        string
+	noPagerFlag      bool
+	timeoutFlag      time.Duration
+	requestTimeout                      = linear.DefaultRequestTimeout
+	cancelTimeout    context.CancelFunc = func() {}
+	outputFormat                        = output.FormatTable
+	outputTemplate   *template.Template
+	outputQuery      *output.Query
+	cfg              *config.Config
+	rootCmd          = &cobra.Command{
 		Use:   "linear",
 		Short: "Linear CLI - Manage Linear issues, projects, and teams from the command line",
 		Long: `A command-line interface for Linear issue tracking.
@@ -17,18 +39,220 @@ var (
 Authenticate with your Linear API key using 'linear auth login' or set the
 LINEAR_API_KEY environment variable.
 
-Perfect for use with Claude Code and human workflows.`,
+Perfect for use with Claude Code and human workflows.
+
+Exit codes:
+  0    success
+  1    unexpected error
+  2    invalid flags or arguments
+  3    missing or rejected API key
+  4    issue, team, project or user not found
+  5    identifier matches more than one project
+  6    rate limited by the Linear API
+  7    network error or timeout
+  8    partial failure (some results could not be fetched)
+  130  interrupted with Ctrl-C
+
+With --json, errors are written to stderr as
+{"error": {"code": "...", "message": "...", "hint": "..."}}.`,
+		PersistentPreRunE: setupRoot,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
 	}
 )
 
+// Execute runs the root command and exits with a status describing any
+// failure (see exitCodes)
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	// The first Ctrl-C cancels in-flight requests; restoring the default
+	// handler afterwards lets a second one exit immediately
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	cancelTimeout()
+	stop()
+	closeTraceFile()
+
+	if pagerErr := output.StopPager(); pagerErr != nil && err == nil {
+		err = pagerErr
+	}
+	if err != nil {
+		os.Exit(reportError(os.Stderr, cmd, err, jsonErrorsRequested()))
 	}
 }
 
 func init() {
+	rootCmd.SetFlagErrorFunc(flagError)
+
 	// Global flags
-	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format (alias for --format json)")
+	rootCmd.PersistentFlags().StringVar(&formatFlag, "format", string(output.FormatTable), "Output format: table, json, ndjson, csv, tsv, yaml, markdown, or a named format from the config file")
+	rootCmd.PersistentFlags().StringSliceVar(&fieldsFlag, "fields", nil, "Comma-separated JSON fields to include in json, ndjson and yaml output (e.g. identifier,title,state.name)")
+	rootCmd.PersistentFlags().StringVar(&templateFlag, "template", "", "Format output with a Go template (e.g. '{{.Identifier}} {{.Title}}')")
+	rootCmd.PersistentFlags().StringVar(&templateFileFlag, "template-file", "", "Format output with a Go template read from a file")
+	rootCmd.PersistentFlags().StringVar(&colorFlag, "color", string(output.ColorAuto), "Use colors in output: auto, always, never (auto respects NO_COLOR)")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 0, "Maximum time for the whole command, e.g. 2m (default: no limit)")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", linear.DefaultRequestTimeout, "Maximum time for each API request")
+	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "Log each API request to stderr with credentials redacted (or set LINEAR_DEBUG=1)")
+	rootCmd.PersistentFlags().StringVar(&traceFileFlag, "trace-file", "", "Append full API request/response pairs to a file as JSON lines")
+	rootCmd.PersistentFlags().BoolVar(&noPagerFlag, "no-pager", false, "Do not pipe long output through a pager ($LINEAR_PAGER, $PAGER or less)")
+	rootCmd.PersistentFlags().BoolVar(&noCacheFlag, "no-cache", false, "Look up teams, users, projects, states and labels without the local cache")
+	rootCmd.PersistentFlags().BoolVar(&noInteractiveFlag, "no-interactive", false, "Never ask to pick a missing or ambiguous team, project or assignee; fail instead")
+	rootCmd.PersistentFlags().StringVar(&jqFlag, "jq", "", "Filter JSON output with a jq expression (e.g. '.[] | select(.priority <= 2) | .identifier')")
+}
+
+// setupRoot loads the config file and resolves global output flags
+func setupRoot(cmd *cobra.Command, args []string) error {
+	loaded, err := config.Load()
+	if err != nil {
+		return err
+	}
+	cfg = loaded
+
+	if err := applyTimeouts(cmd); err != nil {
+		return &cmdError{code: codeUsage, err: err}
+	}
+
+	if err := openTraceFile(); err != nil {
+		return err
+	}
+
+	colorMode, err := output.ParseColorMode(colorFlag)
+	if err != nil {
+		return &cmdError{code: codeUsage, err: err}
+	}
+	output.SetColorMode(colorMode)
+
+	if err := resolveOutputFormat(cmd); err != nil {
+		return &cmdError{code: codeUsage, err: err}
+	}
+
+	// Structured output is meant for programs, so it is never paged
+	if !noPagerFlag && !outputFormat.IsStructured() {
+		output.StartPager()
+	}
+	return nil
+}
+
+// applyTimeouts bounds the command's context by --timeout and resolves the
+// per-request timeout, falling back to the config file for unset flags
+func applyTimeouts(cmd *cobra.Command) error {
+	timeout := timeoutFlag
+	if !cmd.Flags().Changed("timeout") {
+		timeout = cfg.Timeout
+	}
+	if !cmd.Flags().Changed("request-timeout") && cfg.RequestTimeout != 0 {
+		requestTimeout = cfg.RequestTimeout
+	}
+
+	if timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative")
+	}
+	if requestTimeout < 0 {
+		return fmt.Errorf("--request-timeout must not be negative")
+	}
+
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		cancelTimeout = cancel
+		cmd.SetContext(ctx)
+	}
+	return nil
+}
+
+// resolveOutputFormat validates --format, --template, --template-file and
+// --jq and applies the --json alias
+func resolveOutputFormat(cmd *cobra.Command) error {
+	format, namedTemplate, err := parseFormatFlag(formatFlag)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		if cmd.Flags().Changed("format") && format != output.FormatJSON {
+			return fmt.Errorf("--json cannot be combined with --format %s", formatFlag)
+		}
+		format = output.FormatJSON
+	}
+
+	templateText := namedTemplate
+	templateSources := 0
+	if namedTemplate != "" {
+		templateSources++
+	}
+	if cmd.Flags().Changed("template") {
+		templateText = templateFlag
+		templateSources++
+	}
+	if templateFileFlag != "" {
+		content, err := os.ReadFile(templateFileFlag)
+		if err != nil {
+			return fmt.Errorf("failed to read template file: %w", err)
+		}
+		templateText = string(content)
+		templateSources++
+	}
+
+	if templateSources > 1 {
+		return fmt.Errorf("use only one of --template, --template-file or a named --format template")
+	}
+
+	outputTemplate = nil
+	if templateSources == 1 {
+		if jsonOutput || format != output.FormatTable {
+			return fmt.Errorf("templates cannot be combined with --json or --format %s", format)
+		}
+		tmpl, err := output.ParseTemplate(templateText)
+		if err != nil {
+			return err
+		}
+		outputTemplate = tmpl
+	}
+
+	outputQuery = nil
+	if jqFlag != "" {
+		if outputTemplate != nil {
+			return fmt.Errorf("--jq cannot be combined with templates")
+		}
+		if cmd.Flags().Changed("format") && format != output.FormatJSON {
+			return fmt.Errorf("--jq cannot be combined with --format %s", format)
+		}
+		query, err := output.ParseQuery(jqFlag)
+		if err != nil {
+			return err
+		}
+		outputQuery = query
+		format = output.FormatJSON
+	}
+
+	if len(fieldsFlag) > 0 && !format.IsStructured() {
+		return fmt.Errorf("--fields only applies to json, ndjson and yaml output; use --columns for tables")
+	}
+
+	outputFormat = format
+	return nil
+}
+
+// parseFormatFlag resolves a --format value into a built-in format or a
+// named template from the config file
+func parseFormatFlag(value string) (output.Format, string, error) {
+	format, err := output.ParseFormat(value)
+	if err == nil {
+		return format, "", nil
+	}
+
+	if tmpl, ok := cfg.Format(value); ok {
+		return output.FormatTable, tmpl, nil
+	}
+	return "", "", err
+}
+
+// usesHumanOutput reports whether a command should print its default
+// human-readable output rather than a structured format or template
+func usesHumanOutput() bool {
+	return outputFormat == output.FormatTable && outputTemplate == nil
 }
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukky/linear/internal/config"
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
+	"github.com/spf13/cobra"
+)
+
+// newOutputFlagsCommand returns a command bound to the global output flag
+// variables, so each test starts from unset flags
+func newOutputFlagsCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+
+	t.Cleanup(func() {
+		jsonOutput = false
+		formatFlag = string(output.FormatTable)
+		fieldsFlag = nil
+		templateFlag = ""
+		templateFileFlag = ""
+		jqFlag = ""
+		outputQuery = nil
+		outputFormat = output.FormatTable
+		outputTemplate = nil
+		cfg = nil
+	})
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "")
+	cmd.Flags().StringVar(&formatFlag, "format", string(output.FormatTable), "")
+	cmd.Flags().StringSliceVar(&fieldsFlag, "fields", nil, "")
+	cmd.Flags().StringVar(&templateFlag, "template", "", "")
+	cmd.Flags().StringVar(&templateFileFlag, "template-file", "", "")
+	cmd.Flags().StringVar(&jqFlag, "jq", "", "")
+
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	return cmd
+}
+
+func TestResolveOutputFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantFormat output.Format
+		wantErr    string
+	}{
+		{name: "default", wantFormat: output.FormatTable},
+		{name: "json alias", args: []string{"--json"}, wantFormat: output.FormatJSON},
+		{name: "json alias with matching format", args: []string{"--json", "--format", "json"}, wantFormat: output.FormatJSON},
+		{name: "csv", args: []string{"--format", "csv"}, wantFormat: output.FormatCSV},
+		{name: "json conflicts with csv", args: []string{"--json", "--format", "csv"}, wantErr: "--json cannot be combined"},
+		{name: "unknown format", args: []string{"--format", "xml"}, wantErr: "unknown output format"},
+		{name: "fields with yaml", args: []string{"--format", "yaml", "--fields", "id"}, wantFormat: output.FormatYAML},
+		{name: "fields with table", args: []string{"--fields", "id"}, wantErr: "--fields only applies"},
+		{name: "template with json", args: []string{"--json", "--template", "{{.ID}}"}, wantErr: "templates cannot be combined"},
+		{name: "invalid template", args: []string{"--template", "{{.ID"}, wantErr: "invalid template"},
+		{name: "jq implies json", args: []string{"--jq", ".[].id"}, wantFormat: output.FormatJSON},
+		{name: "jq with fields", args: []string{"--jq", ".[]", "--fields", "id"}, wantFormat: output.FormatJSON},
+		{name: "jq with csv", args: []string{"--jq", ".", "--format", "csv"}, wantErr: "--jq cannot be combined"},
+		{name: "jq with template", args: []string{"--jq", ".", "--template", "{{.ID}}"}, wantErr: "--jq cannot be combined"},
+		{name: "invalid jq", args: []string{"--jq", ".foo |"}, wantErr: "invalid jq expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newOutputFlagsCommand(t, tt.args...)
+
+			err := resolveOutputFormat(cmd)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if outputFormat != tt.wantFormat {
+				t.Errorf("outputFormat = %q, want %q", outputFormat, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestResolveOutputFormat_Template(t *testing.T) {
+	cmd := newOutputFlagsCommand(t, "--template", "{{.Identifier}}")
+
+	if err := resolveOutputFormat(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if outputTemplate == nil {
+		t.Fatal("expected template to be set")
+	}
+	if usesHumanOutput() {
+		t.Error("templates should disable human-readable output")
+	}
+}
+
+func TestResolveOutputFormat_TemplateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issue.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Identifier}}"), 0o600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	cmd := newOutputFlagsCommand(t, "--template-file", path)
+	if err := resolveOutputFormat(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if outputTemplate == nil {
+		t.Fatal("expected template to be set")
+	}
+}
+
+func TestResolveOutputFormat_NamedFormatFromConfig(t *testing.T) {
+	cmd := newOutputFlagsCommand(t, "--format", "slack")
+	cfg = &config.Config{Formats: map[string]string{"slack": "<{{.URL}}|{{.Identifier}}>"}}
+
+	if err := resolveOutputFormat(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if outputTemplate == nil {
+		t.Fatal("expected named format to set a template")
+	}
+	if outputFormat != output.FormatTable {
+		t.Errorf("outputFormat = %q, want table", outputFormat)
+	}
+}
+
+func TestResolveOutputFormat_MultipleTemplateSources(t *testing.T) {
+	cmd := newOutputFlagsCommand(t, "--format", "slack", "--template", "{{.ID}}")
+	cfg = &config.Config{Formats: map[string]string{"slack": "{{.URL}}"}}
+
+	err := resolveOutputFormat(cmd)
+	if err == nil || !strings.Contains(err.Error(), "use only one of") {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestApplyTimeouts(t *testing.T) {
+	tests := []struct {
+		name               string
+		args               []string
+		config             config.Config
+		wantDeadline       bool
+		wantRequestTimeout time.Duration
+		wantErr            bool
+	}{
+		{name: "defaults", wantRequestTimeout: linear.DefaultRequestTimeout},
+		{name: "timeout flag", args: []string{"--timeout", "1m"}, wantDeadline: true, wantRequestTimeout: linear.DefaultRequestTimeout},
+		{name: "config timeouts", config: config.Config{Timeout: time.Minute, RequestTimeout: 5 * time.Second}, wantDeadline: true, wantRequestTimeout: 5 * time.Second},
+		{name: "flags override config", args: []string{"--timeout", "0", "--request-timeout", "10s"}, config: config.Config{Timeout: time.Minute, RequestTimeout: 5 * time.Second}, wantRequestTimeout: 10 * time.Second},
+		{name: "negative timeout", args: []string{"--timeout", "-1s"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				timeoutFlag = 0
+				requestTimeout = linear.DefaultRequestTimeout
+				cancelTimeout()
+				cancelTimeout = func() {}
+				cfg = nil
+			})
+
+			cmd := &cobra.Command{Use: "test"}
+			cmd.Flags().DurationVar(&timeoutFlag, "timeout", 0, "")
+			cmd.Flags().DurationVar(&requestTimeout, "request-timeout", linear.DefaultRequestTimeout, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+			cmd.SetContext(context.Background())
+			cfg = &tt.config
+
+			err := applyTimeouts(cmd)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyTimeouts() error = %v", err)
+			}
+
+			if _, ok := cmd.Context().Deadline(); ok != tt.wantDeadline {
+				t.Errorf("context has deadline = %v, want %v", ok, tt.wantDeadline)
+			}
+			if requestTimeout != tt.wantRequestTimeout {
+				t.Errorf("requestTimeout = %v, want %v", requestTimeout, tt.wantRequestTimeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/linear"
+	"github.com/spf13/cobra"
+)
+
+var (
+	standupUser  string
+	standupSince string
+)
+
+// standupEntry is an issue in a standup report
+type standupEntry struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	// Detail says what happened or why the issue is listed, such as
+	// "Todo → In Progress" or "2 comments"
+	Detail string `json:"detail,omitempty"`
+}
+
+// standup is the structured output of report standup
+type standup struct {
+	User       string         `json:"user"`
+	Since      time.Time      `json:"since"`
+	Completed  []standupEntry `json:"completed"`
+	Moved      []standupEntry `json:"moved"`
+	Created    []standupEntry `json:"created"`
+	Commented  []standupEntry `json:"commented"`
+	InProgress []standupEntry `json:"inProgress"`
+	Blocked    []standupEntry `json:"blocked"`
+}
+
+var reportStandupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Summarize what someone did recently and is working on",
+	Long: `Summarize a user's recent activity for a standup: the issues they
+completed, moved to another state, created or commented on since --since,
+then the issues assigned to them that are in progress or blocked. Issues
+are blocked when their state or one of their labels is named like
+"Blocked".
+
+The report is plain text ready to paste into chat; --format markdown
+links the issues, and --format json gives the sections for other tools.
+
+Examples:
+  linear report standup
+  linear report standup --since 3d --format markdown
+  linear report standup --user bob@example.com`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ws, err := openWorkspace()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+
+		since, err := parseReportTime(ctx, "--since", standupSince, false)
+		if err != nil {
+			return err
+		}
+
+		var user *linear.User
+		if standupUser != "" {
+			user, err = resolveUser(ctx, ws, standupUser)
+		} else {
+			user, err = ws.viewer(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch user: %w", err)
+		}
+
+		report, err := buildStandup(ctx, ws, user, since)
+		if err != nil {
+			return err
+		}
+
+		if usesHumanOutput() || (outputFormat == output.FormatMarkdown && outputTemplate == nil) {
+			writeStandup(output.Stdout, report, outputFormat == output.FormatMarkdown)
+			return nil
+		}
+
+		table := output.NewTable([]string{"SECTION", "ID", "TITLE", "DETAIL", "URL"})
+		for _, section := range report.sections() {
+			for _, entry := range section.entries {
+				table.AddRow([]string{section.title, entry.Identifier, entry.Title, entry.Detail, entry.URL})
+			}
+		}
+		return printOutput(report, table)
+	},
+}
+
+// standupSection is a titled list of a standup's entries
+type standupSection struct {
+	title   string
+	entries []standupEntry
+}
+
+// sections returns the recent activity, then the current work
+func (s standup) sections() []standupSection {
+	return []standupSection{
+		{"Completed", s.Completed},
+		{"Moved", s.Moved},
+		{"Created", s.Created},
+		{"Commented on", s.Commented},
+		{"In progress", s.InProgress},
+		{"Blocked", s.Blocked},
+	}
+}
+
+// buildStandup collects what user did since a time and is working on
+func buildStandup(ctx context.Context, ws *workspace, user *linear.User, since time.Time) (standup, error) {
+	report := standup{User: user.Name, Since: since}
+	sinceFilter := linear.Gte(since.UTC().Format(time.RFC3339))
+
+	// Anything the user did touched the issue, so only recently updated
+	// issues need their history checked
+	updated, err := ws.client.ListAllIssuesWithHistory(ctx, linear.ListIssuesOptions{
+		Filter: linear.IssueFilter{}.UpdatedAt(sinceFilter),
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	listed := map[string]bool{}
+	for _, issue := range updated {
+		entry := standupEntry{Identifier: issue.Identifier, Title: issue.Title, URL: issue.URL}
+
+		var moves []linear.IssueHistory
+		completed := false
+		if issue.History != nil {
+			for _, change := range issue.History.Nodes {
+				// Edits to other fields are recorded too, without states
+				if change.ToState == nil || change.Actor == nil || change.Actor.ID != user.ID || !atOrAfter(change.CreatedAt, since) {
+					continue
+				}
+				moves = append(moves, change)
+				if change.ToState.Type == "completed" {
+					completed = true
+				}
+			}
+		}
+		slices.SortFunc(moves, func(a, b linear.IssueHistory) int { return strings.Compare(a.CreatedAt, b.CreatedAt) })
+
+		// Issues completed by integrations, such as merged pull requests,
+		// count for their assignee
+		assigned := issue.Assignee != nil && issue.Assignee.ID == user.ID
+		if !completed && assigned && issue.CompletedAt != nil && atOrAfter(*issue.CompletedAt, since) {
+			completed = true
+		}
+
+		switch {
+		case completed:
+			report.Completed = append(report.Completed, entry)
+		case len(moves) > 0:
+			entry.Detail = workflowStateName(moves[0].FromState) + " → " + workflowStateName(moves[len(moves)-1].ToState)
+			report.Moved = append(report.Moved, entry)
+		case issue.Creator != nil && issue.Creator.ID == user.ID && atOrAfter(issue.CreatedAt, since):
+			report.Created = append(report.Created, entry)
+		default:
+			continue
+		}
+		listed[issue.Identifier] = true
+	}
+
+	comments, err := ws.client.ListAllComments(ctx, linear.CommentFilter{}.
+		User(linear.UserFilter{}.ID(linear.Eq(user.ID))).
+		CreatedAt(sinceFilter))
+	if err != nil {
+		return report, fmt.Errorf("failed to fetch comments: %w", err)
+	}
+	counts := map[string]int{}
+	for _, comment := range comments {
+		issue := comment.Issue
+		if issue == nil || listed[issue.Identifier] {
+			continue
+		}
+		if counts[issue.Identifier] == 0 {
+			report.Commented = append(report.Commented, standupEntry{Identifier: issue.Identifier, Title: issue.Title, URL: issue.URL})
+		}
+		counts[issue.Identifier]++
+	}
+	for i, entry := range report.Commented {
+		report.Commented[i].Detail = plural(counts[entry.Identifier], "comment")
+	}
+
+	open, err := ws.client.ListAllIssues(ctx, linear.ListIssuesOptions{
+		Filter: linear.IssueFilter{}.
+			Assignee(linear.UserFilter{}.ID(linear.Eq(user.ID))).
+			State(linear.StateFilter{}.Type(linear.Nin("completed", "canceled"))),
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to fetch assigned issues: %w", err)
+	}
+	for _, issue := range open {
+		entry := standupEntry{Identifier: issue.Identifier, Title: issue.Title, URL: issue.URL}
+		if reason := blockedBy(issue); reason != "" {
+			entry.Detail = reason
+			report.Blocked = append(report.Blocked, entry)
+		} else if issue.State != nil && issue.State.Type == "started" {
+			report.InProgress = append(report.InProgress, entry)
+		}
+	}
+
+	for _, entries := range []*[]standupEntry{&report.Completed, &report.Moved, &report.Created, &report.Commented, &report.InProgress, &report.Blocked} {
+		slices.SortFunc(*entries, func(a, b standupEntry) int { return compareIdentifiers(a.Identifier, b.Identifier) })
+	}
+	return report, nil
+}
+
+// blockedBy returns the state or label marking an issue as blocked, or ""
+func blockedBy(issue linear.Issue) string {
+	if issue.State != nil && strings.Contains(strings.ToLower(issue.State.Name), "blocked") {
+		return issue.State.Name
+	}
+	for _, label := range issue.Labels.Nodes {
+		if strings.Contains(strings.ToLower(label.Name), "blocked") {
+			return label.Name
+		}
+	}
+	return ""
+}
+
+// atOrAfter reports whether an API timestamp is not before t
+func atOrAfter(timestamp string, t time.Time) bool {
+	parsed, err := time.Parse(time.RFC3339, timestamp)
+	return err == nil && !parsed.Before(t)
+}
+
+// workflowStateName returns the name of a state from an issue's history
+func workflowStateName(state *linear.WorkflowState) string {
+	if state == nil {
+		return "?"
+	}
+	return state.Name
+}
+
+// plural formats a count of things, e.g. "1 comment" or "2 comments"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// writeStandup writes a standup report as plain text, or as Markdown with
+// linked issues
+func writeStandup(w io.Writer, s standup, markdown bool) {
+	heading := func(title string) string {
+		if markdown {
+			return "**" + title + "**"
+		}
+		return title
+	}
+
+	sections := s.sections()
+	parts := []struct {
+		title    string
+		sections []standupSection
+	}{
+		{fmt.Sprintf("%s since %s", s.User, s.Since.Local().Format("Mon 2 Jan 15:04")), sections[:4]},
+		{"Now", sections[4:]},
+	}
+
+	for i, part := range parts {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, heading(part.title))
+
+		empty := true
+		for _, section := range part.sections {
+			if len(section.entries) == 0 {
+				continue
+			}
+			empty = false
+			fmt.Fprintf(w, "\n%s\n", heading(section.title))
+			for _, entry := range section.entries {
+				id := entry.Identifier
+				if markdown {
+					id = fmt.Sprintf("[%s](%s)", entry.Identifier, entry.URL)
+				}
+				line := fmt.Sprintf("- %s %s", id, entry.Title)
+				if entry.Detail != "" {
+					line += " (" + entry.Detail + ")"
+				}
+				fmt.Fprintln(w, line)
+			}
+		}
+		if empty {
+			fmt.Fprintf(w, "\nNothing to report.\n")
+		}
+	}
+}
+
+func init() {
+	reportStandupCmd.Flags().StringVar(&standupUser, "user", "", "Report on this user (email; default: you)")
+	reportStandupCmd.Flags().StringVar(&standupSince, "since", "1d", "Start of the window: a duration such as 1d, a date, or a git tag")
+
+	_ = reportStandupCmd.RegisterFlagCompletionFunc("user", completeUsers)
+
+	reportCmd.AddCommand(reportStandupCmd)
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+// newStandupServer returns a server where Ada was busy in the day before
+// 2025-10-01 12:00
+func newStandupServer(t *testing.T) *lineartest.Server {
+	t.Helper()
+	at := time.Date(2025, 10, 1, 12, 0, 0, 0, time.Local)
+	fixNow(t, at)
+
+	srv := lineartest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.SetClock(func() time.Time { return at })
+	ada := srv.AddUser(lineartest.User{Name: "Ada Lovelace", Email: "ada@example.com"})
+	bob := srv.AddUser(lineartest.User{Name: "Bob Smith", Email: "bob@example.com"})
+	team := srv.AddTeam(lineartest.Team{Key: "ENG", Name: "Engineering"})
+	blocked := srv.AddLabel(lineartest.Label{Name: "Blocked"})
+	states := srv.States(team.ID)
+	todo, started, done := states[1].ID, states[2].ID, states[3].ID
+	earlier := at.AddDate(0, 0, -10)
+	hoursAgo := func(h int) time.Time { return at.Add(-time.Duration(h) * time.Hour) }
+
+	add := func(i lineartest.Issue) lineartest.Issue {
+		i.TeamID = team.ID
+		if i.CreatedAt.IsZero() {
+			i.CreatedAt, i.CreatorID = earlier, bob.ID
+		}
+		i.UpdatedAt = hoursAgo(1)
+		return srv.AddIssue(i)
+	}
+
+	add(lineartest.Issue{Title: "Fix crash", AssigneeID: ada.ID, StateID: done, CompletedAt: hoursAgo(3)})
+	darkMode := add(lineartest.Issue{Title: "Dark mode", AssigneeID: ada.ID, StateID: started})
+	srv.AddHistory(lineartest.History{IssueID: darkMode.ID, ActorID: ada.ID, FromStateID: todo, ToStateID: started, CreatedAt: hoursAgo(20)})
+	add(lineartest.Issue{Title: "New idea", CreatedAt: hoursAgo(4), CreatorID: ada.ID})
+	login := add(lineartest.Issue{Title: "Login bug"})
+	srv.AddComment(lineartest.Comment{IssueID: login.ID, UserID: ada.ID, Body: "Reproduced", CreatedAt: hoursAgo(5)})
+	srv.AddComment(lineartest.Comment{IssueID: login.ID, UserID: ada.ID, Body: "Fixed on main", CreatedAt: hoursAgo(2)})
+	old := add(lineartest.Issue{Title: "Old discussion"})
+	srv.AddComment(lineartest.Comment{IssueID: old.ID, UserID: ada.ID, Body: "Thoughts?", CreatedAt: earlier})
+	add(lineartest.Issue{Title: "Waiting on API", AssigneeID: ada.ID, StateID: todo, LabelIDs: []string{blocked.ID}})
+	bobs := add(lineartest.Issue{Title: "Bob's work", AssigneeID: bob.ID, StateID: started})
+	srv.AddHistory(lineartest.History{IssueID: bobs.ID, ActorID: bob.ID, FromStateID: todo, ToStateID: started, CreatedAt: hoursAgo(6)})
+
+	// Edits other than moves are recorded without states
+	srv.AddHistory(lineartest.History{IssueID: darkMode.ID, ActorID: ada.ID, CreatedAt: hoursAgo(10)})
+	retitled := add(lineartest.Issue{Title: "Retitled", StateID: todo})
+	srv.AddHistory(lineartest.History{IssueID: retitled.ID, ActorID: ada.ID, CreatedAt: hoursAgo(7)})
+	return srv
+}
+
+func TestReportStandup(t *testing.T) {
+	srv := newStandupServer(t)
+
+	out, err := runCLI(t, srv, "report", "standup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `Ada Lovelace since Tue 30 Sep 12:00
+
+Completed
+- ENG-1 Fix crash
+
+Moved
+- ENG-2 Dark mode (Todo → In Progress)
+
+Created
+- ENG-3 New idea
+
+Commented on
+- ENG-4 Login bug (2 comments)
+
+Now
+
+In progress
+- ENG-2 Dark mode
+
+Blocked
+- ENG-6 Waiting on API (Blocked)
+`
+	if out != want {
+		t.Errorf("output:\n%s\nwant:\n%s", out, want)
+	}
+
+	// A longer window reaches the older comment
+	out, err = runCLI(t, srv, "report", "standup", "--since", "2w", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got standup
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Commented) != 2 || got.Commented[1].Identifier != "ENG-5" || got.Commented[1].Detail != "1 comment" {
+		t.Errorf("commented = %+v", got.Commented)
+	}
+}
+
+func TestReportStandup_OtherUser(t *testing.T) {
+	srv := newStandupServer(t)
+
+	out, err := runCLI(t, srv, "report", "standup", "--user", "bob@example.com", "--format", "markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `**Bob Smith since Tue 30 Sep 12:00**
+
+**Moved**
+- [ENG-7](https://linear.app/test/issue/ENG-7/bob-s-work) Bob's work (Todo → In Progress)
+
+**Now**
+
+**In progress**
+- [ENG-7](https://linear.app/test/issue/ENG-7/bob-s-work) Bob's work
+`
+	if out != want {
+		t.Errorf("output:\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -1,16 +1,16 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"time"
 
-	"github.com/dukky/linear/internal/client"
-	"github.com/dukky/linear/internal/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	teamColumnsFlag []string
+	teamSortFlag    []string
+)
+
 var teamCmd = &cobra.Command{
 	Use:   "team",
 	Short: "Manage teams",
@@ -21,43 +21,32 @@ var teamListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all teams",
 	Long:  "List all teams in your Linear workspace",
-	Run: func(cmd *cobra.Command, args []string) {
-		c, err := client.NewClient()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newClient()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			return err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		ctx := cmd.Context()
 		resp, err := c.ListTeams(ctx)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error fetching teams: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("failed to fetch teams: %w", err)
 		}
 
-		if jsonOutput {
-			if err := output.PrintJSON(resp.Teams.Nodes); err != nil {
-				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
-				os.Exit(1)
-			}
-			return
+		teams := resp.Teams.Nodes
+		table, err := buildListTable(teamColumns, teams, teamColumnsFlag, teamSortFlag)
+		if err != nil {
+			return err
 		}
 
-		// Table output
-		table := output.NewTable([]string{"KEY", "NAME", "DESCRIPTION"})
-		for _, team := range resp.Teams.Nodes {
-			desc := ""
-			if team.Description != nil {
-				desc = output.FormatMultilineString(*team.Description, 50)
-			}
-			table.AddRow([]string{team.Key, team.Name, desc})
-		}
-		table.Print()
+		return printOutput(teams, table)
 	},
 }
 
 func init() {
+	teamListCmd.Flags().StringSliceVar(&teamColumnsFlag, "columns", nil, "Comma-separated columns for table, CSV and Markdown output (key, name, description, id)")
+	teamListCmd.Flags().StringSliceVar(&teamSortFlag, "sort", nil, "Comma-separated columns to sort by; prefix with '-' for descending")
+
 	teamCmd.AddCommand(teamListCmd)
 	rootCmd.AddCommand(teamCmd)
 }
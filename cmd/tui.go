@@ -0,0 +1,823 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/internal/tui"
+	"github.com/dukky/linear/linear"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and triage issues in a full-screen interface",
+	Long: `Browse issues in a full-screen interface, with the selected issue's
+description and comments shown beside the list.
+
+Issues are filtered with the same flags as 'linear issue list' (--team,
+--project, --state, --label, --query and --saved); further pages load as you
+scroll. --limit sets the page size.
+
+Keys:
+  j/k, up/down     move through issues (pgup/pgdown, g/G to jump)
+  ctrl+d/ctrl+u    scroll the details
+  s                change the state
+  a                change the assignee
+  p                change the priority
+  l                add or remove labels (tab toggles, enter applies)
+  o                open the issue in the browser
+  b                copy the issue's git branch name
+  r                reload the list
+  ?                show the keys
+  q                quit
+
+In pickers, type to narrow the choices, then press enter; esc cancels.
+
+Examples:
+  linear tui --team ENG
+  linear tui --query 'assignee:me -state:Done'`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+			return withHint(usageErrorf("linear tui needs an interactive terminal"), "Use 'linear issue list' in scripts")
+		}
+
+		filter, err := issueListFilter()
+		if err != nil {
+			return err
+		}
+
+		ws, err := openWorkspace()
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+
+		opts, err := issueListOptions(ctx, ws, filter)
+		if err != nil {
+			return err
+		}
+
+		m := newTUIModel(ctx, ws, opts, tuiTitle())
+		return tui.Run(ctx, os.Stdin, os.Stdout, m, m.fetchPage())
+	},
+}
+
+// tuiTitle describes the list's filters for the header
+func tuiTitle() string {
+	parts := []string{"linear"}
+	for _, filter := range []string{teamFilter, projectFilter, issueStateFilter, issueLabelFilter, issueSavedQueryFlag, issueQueryFlag} {
+		if filter != "" {
+			parts = append(parts, filter)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// tuiPageThreshold is how close the cursor gets to the end of the list
+// before the next page is fetched
+const tuiPageThreshold = 10
+
+// tuiDetailDelay is how long the cursor rests on an issue before its
+// comments are fetched, so scrolling through the list doesn't fetch each one
+var tuiDetailDelay = 150 * time.Millisecond
+
+// priorityNames are Linear's priorities, indexed by value
+var priorityNames = []string{"No priority", "Urgent", "High", "Medium", "Low"}
+
+// issueDetail is what the detail pane shows beyond the listed issue
+type issueDetail struct {
+	issue    *linear.Issue
+	comments []linear.Comment
+	err      error
+}
+
+// tuiModel is the state of linear tui. Its commands only capture values,
+// so all state is changed by Update.
+type tuiModel struct {
+	ctx   context.Context
+	ws    *workspace
+	opts  linear.ListIssuesOptions
+	title string
+
+	issues  []linear.Issue
+	cursor  int
+	offset  int
+	hasMore bool
+	loading bool
+	// generation increases on reload so that pages of the old list are
+	// dropped
+	generation int
+
+	// details holds a nil entry while an issue's details are loading
+	details map[string]*issueDetail
+	scroll  int
+
+	menu  *tui.Menu
+	apply func(menu *tui.Menu) tui.Cmd
+	help  bool
+
+	status    string
+	statusErr bool
+}
+
+// Messages delivered to tuiModel by its commands
+type (
+	pageMsg struct {
+		generation int
+		replace    bool
+		issues     []linear.Issue
+		pageInfo   linear.PageInfo
+		err        error
+	}
+	detailRequestMsg struct{ id string }
+	detailMsg        struct {
+		id     string
+		detail *issueDetail
+	}
+	// issueMsg carries an issue refetched after an update
+	issueMsg struct {
+		issue  *linear.Issue
+		status string
+	}
+	menuMsg struct {
+		menu  *tui.Menu
+		apply func(menu *tui.Menu) tui.Cmd
+	}
+	statusMsg struct {
+		text string
+		err  error
+	}
+)
+
+func newTUIModel(ctx context.Context, ws *workspace, opts linear.ListIssuesOptions, title string) *tuiModel {
+	return &tuiModel{
+		ctx:     ctx,
+		ws:      ws,
+		opts:    opts,
+		title:   title,
+		details: map[string]*issueDetail{},
+	}
+}
+
+// current returns the selected issue
+func (m *tuiModel) current() (linear.Issue, bool) {
+	if m.cursor >= len(m.issues) {
+		return linear.Issue{}, false
+	}
+	return m.issues[m.cursor], true
+}
+
+// fetchPage requests the page after the issues listed so far
+func (m *tuiModel) fetchPage() tui.Cmd {
+	m.loading = true
+	ctx, client, opts := m.ctx, m.ws.client, m.opts
+	generation := m.generation
+	return func() tui.Msg {
+		msg := pageMsg{generation: generation, replace: opts.After == ""}
+		resp, err := client.ListIssues(ctx, opts)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.issues = resp.Issues.Nodes
+		msg.pageInfo = resp.Issues.PageInfo
+		return msg
+	}
+}
+
+// reload fetches the list again from the first page, keeping the old list
+// until the new one arrives
+func (m *tuiModel) reload() tui.Cmd {
+	m.generation++
+	m.opts.After = ""
+	m.details = map[string]*issueDetail{}
+	m.status = "Reloading…"
+	return m.fetchPage()
+}
+
+// selected starts the work for a newly selected issue: the next page when
+// the cursor nears the end of the list, and the issue's details once the
+// cursor rests on it
+func (m *tuiModel) selected() tui.Cmd {
+	var cmds []tui.Cmd
+	if m.hasMore && !m.loading && m.cursor >= len(m.issues)-tuiPageThreshold {
+		cmds = append(cmds, m.fetchPage())
+	}
+	if issue, ok := m.current(); ok {
+		if _, ok := m.details[issue.ID]; !ok {
+			ctx, id := m.ctx, issue.ID
+			cmds = append(cmds, func() tui.Msg {
+				select {
+				case <-time.After(tuiDetailDelay):
+				case <-ctx.Done():
+				}
+				return detailRequestMsg{id: id}
+			})
+		}
+	}
+	return tui.Batch(cmds...)
+}
+
+func (m *tuiModel) fetchDetail(id string) tui.Cmd {
+	m.details[id] = nil
+	ctx, client := m.ctx, m.ws.client
+	return func() tui.Msg {
+		detail := &issueDetail{}
+		resp, err := client.GetIssue(ctx, id)
+		if err == nil && resp.Issue == nil {
+			err = errors.New("issue not found")
+		}
+		if err != nil {
+			detail.err = err
+			return detailMsg{id: id, detail: detail}
+		}
+		detail.issue = resp.Issue
+
+		comments, err := client.ListComments(ctx, id)
+		if err != nil {
+			detail.err = err
+		} else if comments.Issue != nil {
+			detail.comments = comments.Issue.Comments.Nodes
+			slices.SortStableFunc(detail.comments, func(a, b linear.Comment) int {
+				return cmp.Compare(a.CreatedAt, b.CreatedAt)
+			})
+		}
+		return detailMsg{id: id, detail: detail}
+	}
+}
+
+// replaceIssue swaps a listed issue for a fresher copy
+func (m *tuiModel) replaceIssue(issue *linear.Issue) {
+	for i := range m.issues {
+		if m.issues[i].ID == issue.ID {
+			m.issues[i] = *issue
+		}
+	}
+	if detail := m.details[issue.ID]; detail != nil {
+		detail.issue = issue
+	}
+}
+
+func (m *tuiModel) move(delta int) tui.Cmd {
+	if len(m.issues) == 0 {
+		return nil
+	}
+	cursor := min(max(m.cursor+delta, 0), len(m.issues)-1)
+	if cursor == m.cursor {
+		return nil
+	}
+	m.cursor = cursor
+	m.scroll = 0
+	return m.selected()
+}
+
+func (m *tuiModel) setStatus(text string, err error) {
+	m.status, m.statusErr = text, err != nil
+	if err != nil {
+		m.status = text + ": " + err.Error()
+	}
+}
+
+func (m *tuiModel) Update(msg tui.Msg) tui.Cmd {
+	switch msg := msg.(type) {
+	case tui.Key:
+		return m.handleKey(msg)
+
+	case pageMsg:
+		if msg.generation != m.generation {
+			return nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.hasMore = false
+			m.setStatus("Failed to fetch issues", msg.err)
+			return nil
+		}
+		if msg.replace {
+			// Stay on the selected issue if it is still listed
+			selected, _ := m.current()
+			m.issues = msg.issues
+			m.cursor = max(slices.IndexFunc(m.issues, func(i linear.Issue) bool { return i.ID == selected.ID }), 0)
+			m.status = ""
+		} else {
+			m.issues = append(m.issues, msg.issues...)
+		}
+		m.hasMore = msg.pageInfo.HasNextPage && msg.pageInfo.EndCursor != "" && msg.pageInfo.EndCursor != m.opts.After
+		m.opts.After = msg.pageInfo.EndCursor
+		return m.selected()
+
+	case detailRequestMsg:
+		issue, ok := m.current()
+		if _, loaded := m.details[msg.id]; !ok || issue.ID != msg.id || loaded {
+			return nil
+		}
+		return m.fetchDetail(msg.id)
+
+	case detailMsg:
+		m.details[msg.id] = msg.detail
+		if msg.detail.issue != nil {
+			m.replaceIssue(msg.detail.issue)
+		}
+
+	case issueMsg:
+		m.replaceIssue(msg.issue)
+		m.setStatus(msg.status, nil)
+
+	case menuMsg:
+		m.menu, m.apply = msg.menu, msg.apply
+		m.status = ""
+
+	case statusMsg:
+		m.setStatus(msg.text, msg.err)
+	}
+	return nil
+}
+
+func (m *tuiModel) handleKey(key tui.Key) tui.Cmd {
+	if m.menu != nil {
+		switch m.menu.HandleKey(key) {
+		case tui.MenuCanceled:
+			m.menu, m.apply = nil, nil
+		case tui.MenuChosen:
+			menu, apply := m.menu, m.apply
+			m.menu, m.apply = nil, nil
+			return apply(menu)
+		}
+		return nil
+	}
+
+	if m.help {
+		m.help = false
+		return nil
+	}
+
+	switch key.String() {
+	case "q", "ctrl+c":
+		return tui.Quit
+	case "j", "down":
+		return m.move(1)
+	case "k", "up":
+		return m.move(-1)
+	case "pgdown", "ctrl+f":
+		return m.move(10)
+	case "pgup", "ctrl+b":
+		return m.move(-10)
+	case "g", "home":
+		return m.move(-len(m.issues))
+	case "G", "end":
+		return m.move(len(m.issues))
+	case "ctrl+d":
+		m.scroll += 10
+		return nil
+	case "ctrl+u":
+		m.scroll = max(m.scroll-10, 0)
+		return nil
+	case "r":
+		return m.reload()
+	case "?":
+		m.help = true
+		return nil
+	}
+
+	issue, ok := m.current()
+	if !ok {
+		return nil
+	}
+	switch key.String() {
+	case "s":
+		return m.stateMenu(issue)
+	case "a":
+		return m.assigneeMenu(issue)
+	case "p":
+		m.priorityMenu(issue)
+	case "l":
+		return m.labelMenu(issue)
+	case "o":
+		return func() tui.Msg {
+			if err := openURL(issue.URL); err != nil {
+				return statusMsg{text: "Failed to open " + issue.Identifier, err: err}
+			}
+			return statusMsg{text: "Opened " + issue.URL}
+		}
+	case "b":
+		return m.copyBranch(issue)
+	}
+	return nil
+}
+
+// update applies input to issue, then refetches it to show the result
+func (m *tuiModel) update(issue linear.Issue, input linear.UpdateIssueInput, done string) tui.Cmd {
+	m.setStatus("Updating "+issue.Identifier+"…", nil)
+	ctx, ws := m.ctx, m.ws
+	return func() tui.Msg {
+		resp, err := ws.client.UpdateIssue(ctx, issue.ID, input)
+		ws.invalidate("issues")
+		if err == nil && !resp.IssueUpdate.Success {
+			err = errors.New("the update was not applied")
+		}
+		if err != nil {
+			return statusMsg{text: "Failed to update " + issue.Identifier, err: err}
+		}
+
+		fresh, err := ws.client.GetIssue(ctx, issue.ID)
+		if err != nil || fresh.Issue == nil {
+			// The update worked; the list catches up on reload
+			return statusMsg{text: done}
+		}
+		return issueMsg{issue: fresh.Issue, status: done}
+	}
+}
+
+func (m *tuiModel) stateMenu(issue linear.Issue) tui.Cmd {
+	ctx, ws := m.ctx, m.ws
+	return func() tui.Msg {
+		states, err := ws.states(ctx)
+		if err != nil {
+			return statusMsg{text: "Failed to fetch states", err: err}
+		}
+		states = slices.DeleteFunc(slices.Clone(states), func(s linear.WorkflowState) bool {
+			return issue.Team != nil && s.Team != nil && s.Team.ID != issue.Team.ID
+		})
+		slices.SortStableFunc(states, func(a, b linear.WorkflowState) int {
+			return cmp.Compare(stateTypeRank(&linear.State{Type: a.Type}), stateTypeRank(&linear.State{Type: b.Type}))
+		})
+
+		items := make([]tui.Item, 0, len(states))
+		var current string
+		for _, s := range states {
+			items = append(items, tui.Item{Label: s.Name, Detail: s.Type, Value: s.ID})
+			if issue.State != nil && s.Name == issue.State.Name {
+				current = s.ID
+			}
+		}
+		menu := tui.NewMenu("Move "+issue.Identifier+" to", items)
+		menu.Select(current)
+
+		return menuMsg{menu: menu, apply: func(menu *tui.Menu) tui.Cmd {
+			item, _ := menu.Current()
+			return m.update(issue, linear.UpdateIssueInput{StateID: &item.Value}, "Moved "+issue.Identifier+" to "+item.Label)
+		}}
+	}
+}
+
+func (m *tuiModel) assigneeMenu(issue linear.Issue) tui.Cmd {
+	ctx, ws := m.ctx, m.ws
+	return func() tui.Msg {
+		users, err := ws.users(ctx)
+		if err != nil {
+			return statusMsg{text: "Failed to fetch users", err: err}
+		}
+
+		items := make([]tui.Item, 0, len(users))
+		for _, u := range users {
+			items = append(items, tui.Item{Label: u.Name, Detail: u.Email, Value: u.ID})
+		}
+		menu := tui.NewMenu("Assign "+issue.Identifier+" to", items)
+		if issue.Assignee != nil {
+			menu.Select(issue.Assignee.ID)
+		}
+
+		return menuMsg{menu: menu, apply: func(menu *tui.Menu) tui.Cmd {
+			item, _ := menu.Current()
+			return m.update(issue, linear.UpdateIssueInput{AssigneeID: &item.Value}, "Assigned "+issue.Identifier+" to "+item.Label)
+		}}
+	}
+}
+
+func (m *tuiModel) priorityMenu(issue linear.Issue) {
+	items := make([]tui.Item, len(priorityNames))
+	for i, name := range priorityNames {
+		items[i] = tui.Item{Label: name, Value: strconv.Itoa(i)}
+	}
+	m.menu = tui.NewMenu("Priority of "+issue.Identifier, items)
+	m.menu.Select(strconv.Itoa(issue.Priority))
+	m.apply = func(menu *tui.Menu) tui.Cmd {
+		item, _ := menu.Current()
+		priority, _ := strconv.Atoi(item.Value)
+		return m.update(issue, linear.UpdateIssueInput{Priority: &priority}, "Set the priority of "+issue.Identifier+" to "+item.Label)
+	}
+}
+
+func (m *tuiModel) labelMenu(issue linear.Issue) tui.Cmd {
+	ctx, ws := m.ctx, m.ws
+	return func() tui.Msg {
+		labels, err := ws.labels(ctx)
+		if err != nil {
+			return statusMsg{text: "Failed to fetch labels", err: err}
+		}
+
+		current := map[string]bool{}
+		for _, label := range issue.Labels.Nodes {
+			current[label.ID] = true
+		}
+
+		// Offer workspace labels and those of the issue's team
+		var items []tui.Item
+		for _, label := range labels {
+			if label.Team != nil && issue.Team != nil && label.Team.ID != issue.Team.ID {
+				continue
+			}
+			items = append(items, tui.Item{Label: label.Name, Value: label.ID, Checked: current[label.ID]})
+		}
+		menu := tui.NewMenu("Labels of "+issue.Identifier, items)
+		menu.Multi = true
+
+		return menuMsg{menu: menu, apply: func(menu *tui.Menu) tui.Cmd {
+			var input linear.UpdateIssueInput
+			checked := map[string]bool{}
+			for _, item := range menu.Checked() {
+				checked[item.Value] = true
+				if !current[item.Value] {
+					input.AddedLabelIDs = append(input.AddedLabelIDs, item.Value)
+				}
+			}
+			for id := range current {
+				if !checked[id] {
+					input.RemovedLabelIDs = append(input.RemovedLabelIDs, id)
+				}
+			}
+			if len(input.AddedLabelIDs) == 0 && len(input.RemovedLabelIDs) == 0 {
+				m.setStatus("Labels unchanged", nil)
+				return nil
+			}
+			slices.Sort(input.RemovedLabelIDs)
+			return m.update(issue, input, "Updated the labels of "+issue.Identifier)
+		}}
+	}
+}
+
+// copyBranch copies the issue's branch name, fetching it unless the
+// details are loaded
+func (m *tuiModel) copyBranch(issue linear.Issue) tui.Cmd {
+	var branch string
+	if detail := m.details[issue.ID]; detail != nil && detail.issue != nil {
+		branch = detail.issue.BranchName
+	}
+	ctx, client := m.ctx, m.ws.client
+	return func() tui.Msg {
+		if branch == "" {
+			resp, err := client.GetIssue(ctx, issue.ID)
+			if err != nil {
+				return statusMsg{text: "Failed to fetch the branch name", err: err}
+			}
+			if resp.Issue == nil || resp.Issue.BranchName == "" {
+				return statusMsg{text: issue.Identifier + " has no branch name", err: errors.New("not found")}
+			}
+			branch = resp.Issue.BranchName
+		}
+		sequence, err := copyText(branch)
+		if err != nil {
+			return statusMsg{text: "Failed to copy " + branch, err: err}
+		}
+		status := statusMsg{text: "Copied " + branch}
+		if sequence != "" {
+			return tui.Output{Sequence: sequence, Then: status}
+		}
+		return status
+	}
+}
+
+// tuiSeparator divides the list from the details
+const tuiSeparator = " │ "
+
+func (m *tuiModel) View(width, height int) string {
+	if width < 20 || height < 5 {
+		return "Terminal too small"
+	}
+	body := height - 2
+
+	// Narrow terminals show one pane at a time
+	listWidth := width
+	if width >= 80 {
+		listWidth = min(max(width*2/5, 36), 72)
+	}
+	paneWidth := width - listWidth - len([]rune(tuiSeparator))
+	if listWidth == width {
+		paneWidth = width
+	}
+
+	var pane []string
+	switch {
+	case m.menu != nil:
+		pane = m.menu.View(paneWidth, body)
+	case m.help:
+		pane = tuiHelp
+	case listWidth < width:
+		pane = m.detailView(paneWidth, body)
+	}
+
+	var lines []string
+	switch {
+	case listWidth == width && pane != nil:
+		lines = pane
+	case listWidth == width:
+		lines = m.listView(width, body)
+	default:
+		lines = tui.Columns(tuiSeparator, []int{listWidth, paneWidth}, m.listView(listWidth, body), pane)
+	}
+	if len(lines) > body {
+		lines = lines[:body]
+	}
+	for len(lines) < body {
+		lines = append(lines, "")
+	}
+
+	header := tui.Reverse(tui.Fit(" "+m.title+"  "+m.countLabel(), width))
+	return strings.Join(append(append([]string{header}, lines...), m.footer(width)), "\n")
+}
+
+func (m *tuiModel) countLabel() string {
+	more := ""
+	if m.hasMore {
+		more = "+"
+	}
+	return fmt.Sprintf("%d%s issues", len(m.issues), more)
+}
+
+func (m *tuiModel) footer(width int) string {
+	if m.status != "" {
+		if m.statusErr {
+			return output.TruncateWidth(output.Colorize("red", m.status), width)
+		}
+		return output.TruncateWidth(m.status, width)
+	}
+	return output.TruncateWidth(tui.Dim("j/k move · s state · a assignee · p priority · l labels · o open · b branch · r reload · ? keys · q quit"), width)
+}
+
+var tuiHelp = []string{
+	tui.Bold("Keys"),
+	"",
+	"j/k, up/down    move through issues",
+	"pgup/pgdown     move by a page",
+	"g/G             first/last issue",
+	"ctrl+d/ctrl+u   scroll the details",
+	"s               change the state",
+	"a               change the assignee",
+	"p               change the priority",
+	"l               add or remove labels",
+	"o               open in the browser",
+	"b               copy the branch name",
+	"r               reload the list",
+	"q               quit",
+	"",
+	"Press any key to close",
+}
+
+func (m *tuiModel) listView(width, height int) []string {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+
+	idWidth := 0
+	for _, issue := range m.issues {
+		idWidth = max(idWidth, output.DisplayWidth(issue.Identifier))
+	}
+
+	lines := make([]string, 0, height)
+	for i := m.offset; i < len(m.issues) && len(lines) < height; i++ {
+		issue := m.issues[i]
+		row := tui.Fit(issue.Identifier, idWidth) + " " + priorityCell(issue.Priority) + " " + issue.Title
+		if i == m.cursor {
+			row = tui.Reverse(tui.Fit(row, width))
+		}
+		lines = append(lines, row)
+	}
+
+	switch {
+	case len(lines) >= height:
+	case m.loading && len(m.issues) == 0:
+		lines = append(lines, tui.Dim("Loading issues…"))
+	case m.loading:
+		lines = append(lines, tui.Dim("Loading more…"))
+	case len(m.issues) == 0:
+		lines = append(lines, tui.Dim("No issues match"))
+	}
+	return lines
+}
+
+// priorityCell shows a priority in three cells, as an icon when colors
+// can tell the levels apart
+func priorityCell(priority int) string {
+	if output.ColorEnabled() {
+		return output.PriorityIcon(priority)
+	}
+	switch priority {
+	case 1:
+		return "!!!"
+	case 2:
+		return "Hi "
+	case 3:
+		return "Med"
+	case 4:
+		return "Low"
+	}
+	return "---"
+}
+
+func (m *tuiModel) detailView(width, height int) []string {
+	issue, ok := m.current()
+	if !ok {
+		return nil
+	}
+	lines := m.detailLines(issue, width)
+	m.scroll = min(m.scroll, max(len(lines)-height, 0))
+	return lines[m.scroll:]
+}
+
+func (m *tuiModel) detailLines(issue linear.Issue, width int) []string {
+	lines := []string{tui.Bold(issue.Identifier)}
+	for _, line := range tui.Wrap(issue.Title, width) {
+		lines = append(lines, tui.Bold(line))
+	}
+	lines = append(lines, "")
+
+	field := func(name, value string) {
+		if value != "" {
+			lines = append(lines, tui.Dim(fmt.Sprintf("%-9s", name))+" "+value)
+		}
+	}
+	if issue.State != nil {
+		field("State", output.Chip(issue.State.Color, issue.State.Name))
+	}
+	field("Priority", issue.PriorityLabel)
+	assignee := "Unassigned"
+	if issue.Assignee != nil {
+		assignee = issue.Assignee.Name
+	}
+	field("Assignee", assignee)
+	if issue.Project != nil {
+		field("Project", issue.Project.Name)
+	}
+	var labels []string
+	for _, label := range issue.Labels.Nodes {
+		labels = append(labels, output.Chip(label.Color, label.Name))
+	}
+	field("Labels", strings.Join(labels, ", "))
+
+	detail, requested := m.details[issue.ID]
+	if detail != nil && detail.issue != nil {
+		field("Branch", detail.issue.BranchName)
+	}
+	lines = append(lines, "")
+
+	opts := output.MarkdownOptions{Width: width, Color: output.ColorEnabled()}
+	if issue.Description != nil && strings.TrimSpace(*issue.Description) != "" {
+		lines = append(lines, strings.Split(strings.TrimRight(output.RenderMarkdown(*issue.Description, opts), "\n"), "\n")...)
+	} else {
+		lines = append(lines, tui.Dim("No description"))
+	}
+	lines = append(lines, "")
+
+	switch {
+	case !requested || detail == nil:
+		lines = append(lines, tui.Dim("Loading comments…"))
+	case detail.err != nil:
+		lines = append(lines, output.Colorize("red", "Failed to load comments: "+detail.err.Error()))
+	case len(detail.comments) == 0:
+		lines = append(lines, tui.Dim("No comments"))
+	default:
+		lines = append(lines, tui.Bold(fmt.Sprintf("Comments (%d)", len(detail.comments))))
+		for _, comment := range detail.comments {
+			author := "Unknown"
+			if comment.User != nil {
+				author = comment.User.Name
+			}
+			date, _, _ := strings.Cut(comment.CreatedAt, "T")
+			lines = append(lines, "", tui.Bold(author)+" "+tui.Dim(date))
+			lines = append(lines, strings.Split(strings.TrimRight(output.RenderMarkdown(comment.Body, opts), "\n"), "\n")...)
+		}
+	}
+	return lines
+}
+
+func init() {
+	tuiCmd.Flags().StringVar(&teamFilter, "team", "", "Filter by team key (e.g., ENG)")
+	tuiCmd.Flags().StringVar(&projectFilter, "project", "", "Filter by project name or ID")
+	tuiCmd.Flags().StringVar(&issueStateFilter, "state", "", "Filter by workflow state name (e.g., \"In Progress\")")
+	tuiCmd.Flags().StringVar(&issueLabelFilter, "label", "", "Filter by label name")
+	tuiCmd.Flags().StringVarP(&issueQueryFlag, "query", "q", "", "Filter with a query, e.g. 'label:bug priority<=2 -state:Done'")
+	tuiCmd.Flags().StringVar(&issueSavedQueryFlag, "saved", "", "Filter with a query saved under this name in the config file")
+	tuiCmd.Flags().IntVar(&issueLimit, "limit", 50, "Number of issues to fetch per page")
+
+	_ = tuiCmd.RegisterFlagCompletionFunc("team", completeTeams)
+	_ = tuiCmd.RegisterFlagCompletionFunc("project", completeProjects)
+	_ = tuiCmd.RegisterFlagCompletionFunc("state", completeStates)
+	_ = tuiCmd.RegisterFlagCompletionFunc("label", completeLabels)
+
+	rootCmd.AddCommand(tuiCmd)
+}
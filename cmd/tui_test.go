@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dukky/linear/internal/output"
+	"github.com/dukky/linear/internal/tui"
+	"github.com/dukky/linear/linear"
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+// drive runs cmd and delivers its messages to m until no work is left,
+// one command at a time, and returns what it asked to write to the terminal
+func drive(m tui.Model, cmd tui.Cmd) (written string) {
+	queue := []tui.Cmd{cmd}
+	for len(queue) > 0 {
+		cmd := queue[0]
+		queue = queue[1:]
+		if cmd == nil {
+			continue
+		}
+		msg := cmd()
+		if batch, ok := msg.(tui.BatchMsg); ok {
+			queue = append(queue, batch...)
+			continue
+		}
+		if o, ok := msg.(tui.Output); ok {
+			written += o.Sequence
+			if msg = o.Then; msg == nil {
+				continue
+			}
+		}
+		queue = append(queue, m.Update(msg))
+	}
+	return written
+}
+
+// press types keys into m, finishing the work each one starts, and
+// returns what it asked to write to the terminal
+func press(m tui.Model, keys string) (written string) {
+	for _, key := range tui.ParseKeys([]byte(keys)) {
+		written += drive(m, m.Update(key))
+	}
+	return written
+}
+
+// newTestTUI opens the model on a fake server's ENG team
+func newTestTUI(t *testing.T, srv *lineartest.Server, limit int) *tuiModel {
+	t.Helper()
+
+	t.Setenv("LINEAR_API_KEY", lineartest.DefaultAPIKey)
+	t.Setenv("LINEAR_API_URL", srv.URL)
+	setCacheDir(t)
+	output.SetColorMode(output.ColorNever)
+
+	delay := tuiDetailDelay
+	tuiDetailDelay = 0
+	t.Cleanup(func() { tuiDetailDelay = delay })
+
+	ws, err := openWorkspace()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newTUIModel(context.Background(), ws, linear.ListIssuesOptions{TeamKey: "ENG", Limit: limit}, "linear · ENG")
+	drive(m, m.fetchPage())
+	return m
+}
+
+func TestTUI_Pagination(t *testing.T) {
+	srv, team := newIssueServer(t)
+	for i := range 25 {
+		srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: fmt.Sprintf("Issue %d", i+1)})
+	}
+
+	m := newTestTUI(t, srv, 5)
+
+	// Pages load until there are enough issues below the cursor
+	if len(m.issues) != 15 || !m.hasMore {
+		t.Fatalf("loaded %d issues (more: %v), want 15 and more", len(m.issues), m.hasMore)
+	}
+
+	press(m, "G")
+	if len(m.issues) != 25 || m.hasMore {
+		t.Fatalf("loaded %d issues (more: %v) after moving to the end, want all 25", len(m.issues), m.hasMore)
+	}
+	if m.cursor != 14 {
+		t.Errorf("cursor = %d, want it to stay on the last issue it moved to", m.cursor)
+	}
+
+	press(m, "G")
+	if m.cursor != 24 {
+		t.Errorf("cursor = %d, want the last issue", m.cursor)
+	}
+}
+
+func TestTUI_Details(t *testing.T) {
+	srv, team := newIssueServer(t)
+	issue := srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix login", Description: "Users see **an error**"})
+	srv.AddComment(lineartest.Comment{IssueID: issue.ID, Body: "Looking into it"})
+
+	m := newTestTUI(t, srv, 50)
+	view := m.View(120, 20)
+
+	for _, want := range []string{"linear · ENG  1 issues", "ENG-1 --- Fix login", "Users see an error", "Comments (1)", "Looking into it", "Branch    eng-1-fix-login"} {
+		if !strings.Contains(output.StripANSI(view), want) {
+			t.Errorf("view does not contain %q:\n%s", want, output.StripANSI(view))
+		}
+	}
+
+	// Narrow terminals show only the list
+	if view := output.StripANSI(m.View(60, 20)); strings.Contains(view, "Looking into it") {
+		t.Errorf("narrow view shows the details:\n%s", view)
+	}
+}
+
+func TestTUI_Triage(t *testing.T) {
+	srv, team := newIssueServer(t)
+	bug := srv.AddLabel(lineartest.Label{Name: "Bug"})
+	other := srv.AddTeam(lineartest.Team{Key: "OPS", Name: "Operations"})
+	srv.AddLabel(lineartest.Label{Name: "Pager", TeamID: other.ID})
+	issue := srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix login"})
+
+	m := newTestTUI(t, srv, 50)
+
+	press(m, "sdone\r")
+	press(m, "purgent\r")
+	press(m, "abob\r")
+
+	// Labels of other teams are not offered
+	press(m, "l")
+	if m.menu == nil || len(m.menu.Items) != 1 {
+		t.Fatalf("label menu = %+v, want only the workspace label", m.menu)
+	}
+	press(m, "\t\r")
+
+	got, _ := srv.Issue(issue.ID)
+	if state := stateOf(srv, got); state != "Done" {
+		t.Errorf("state = %q, want Done", state)
+	}
+	if got.Priority != 1 {
+		t.Errorf("priority = %d, want 1", got.Priority)
+	}
+	if len(got.LabelIDs) != 1 || got.LabelIDs[0] != bug.ID {
+		t.Errorf("labels = %v, want Bug", got.LabelIDs)
+	}
+	if m.status != "Updated the labels of ENG-1" {
+		t.Errorf("status = %q", m.status)
+	}
+
+	// The list shows the updated issue
+	listed := m.issues[0]
+	if listed.State == nil || listed.State.Name != "Done" || listed.Assignee == nil || listed.Assignee.Name != "Bob Smith" {
+		t.Errorf("listed issue = %+v", listed)
+	}
+
+	// Removing the label again
+	press(m, "l\t\r")
+	got, _ = srv.Issue(issue.ID)
+	if len(got.LabelIDs) != 0 {
+		t.Errorf("labels = %v after unchecking, want none", got.LabelIDs)
+	}
+
+	// Escape leaves the issue alone
+	press(m, "pnone\x1b")
+	if got, _ := srv.Issue(issue.ID); got.Priority != 1 || m.menu != nil {
+		t.Errorf("priority = %d after canceling, want 1", got.Priority)
+	}
+}
+
+func stateOf(srv *lineartest.Server, issue lineartest.Issue) string {
+	for _, s := range srv.States(issue.TeamID) {
+		if s.ID == issue.StateID {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+func TestTUI_OpenAndCopy(t *testing.T) {
+	srv, team := newIssueServer(t)
+	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix login"})
+
+	var opened, copied string
+	originalOpen, originalCopy := openURL, copyText
+	openURL = func(url string) error { opened = url; return nil }
+	copyText = func(text string) (string, error) { copied = text; return "", nil }
+	t.Cleanup(func() { openURL, copyText = originalOpen, originalCopy })
+
+	m := newTestTUI(t, srv, 50)
+	press(m, "ob")
+
+	if opened != "https://linear.app/test/issue/ENG-1/fix-login" {
+		t.Errorf("opened %q", opened)
+	}
+	if copied != "eng-1-fix-login" {
+		t.Errorf("copied %q", copied)
+	}
+
+	copyText = func(string) (string, error) { return "\x1b]52;c;Y29weQ==\a", nil }
+	if written := press(m, "b"); written != "\x1b]52;c;Y29weQ==\a" {
+		t.Errorf("wrote %q, want the OSC 52 sequence", written)
+	}
+	if m.statusErr || m.status != "Copied eng-1-fix-login" {
+		t.Errorf("status = %q, want the copy confirmed", m.status)
+	}
+
+	copyText = func(string) (string, error) { return "", errors.New("no clipboard") }
+	press(m, "b")
+	if !m.statusErr || !strings.Contains(m.status, "no clipboard") {
+		t.Errorf("status = %q, want the clipboard error", m.status)
+	}
+}
+
+func TestTUI_NeedsTerminal(t *testing.T) {
+	srv, _ := newIssueServer(t)
+
+	_, err := runCLI(t, srv, "tui", "--team", "ENG")
+	if err == nil || classifyError(err) != codeUsage {
+		t.Fatalf("error = %v, want a usage error", err)
+	}
+}
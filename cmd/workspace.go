@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dukky/linear/internal/cache"
+	"github.com/dukky/linear/linear"
+)
+
+// noCacheFlag looks reference data up from the API instead of the cache,
+// though results still refresh the cache
+var noCacheFlag bool
+
+// cacheTTLs is how long each kind of reference data is reused before it
+// is fetched again; keys scoped with a suffix (e.g. "projects-<team>")
+// share the TTL of their kind
+var cacheTTLs = map[string]time.Duration{
+	"teams":    time.Hour,
+	"users":    time.Hour,
+	"states":   time.Hour,
+	"labels":   time.Hour,
+	"viewer":   time.Hour,
+	"projects": 10 * time.Minute,
+	"issues":   time.Minute,
+}
+
+// cacheTTL returns the TTL for a cache key
+func cacheTTL(key string) time.Duration {
+	kind, _, _ := strings.Cut(key, "-")
+	return cacheTTLs[kind]
+}
+
+// cachedKinds lists the kinds of cached reference data
+func cachedKinds() []string {
+	kinds := make([]string, 0, len(cacheTTLs))
+	for kind := range cacheTTLs {
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+// workspace is an API client paired with the reference data cache of the
+// workspace its API key belongs to
+type workspace struct {
+	client *linear.Client
+	// cache is nil if there is no cache directory
+	cache *cache.Cache
+	// noCache is set by --no-cache
+	noCache bool
+}
+
+// openWorkspace creates a client for the stored API key, with its cache
+func openWorkspace() (*workspace, error) {
+	key, err := apiKey()
+	if err != nil {
+		return nil, err
+	}
+	client, err := newClientWithKey(key)
+	if err != nil {
+		return nil, err
+	}
+	ws := &workspace{client: client, noCache: noCacheFlag}
+	if dir, err := workspaceCacheDir(key); err == nil {
+		ws.cache = cache.New(dir)
+	}
+	return ws, nil
+}
+
+// workspaceCacheDir returns the cache directory for the workspace an API
+// key belongs to, so that switching keys or endpoints never mixes up
+// workspaces' data
+func workspaceCacheDir(apiKey string) (string, error) {
+	dir, err := cache.Dir()
+	if err != nil {
+		return "", err
+	}
+	endpoint := os.Getenv("LINEAR_API_URL")
+	if endpoint == "" {
+		endpoint = linear.DefaultEndpoint
+	}
+	sum := sha256.Sum256([]byte(endpoint + "\n" + apiKey))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])), nil
+}
+
+// invalidate drops cached entries that a mutation may have changed
+func (w *workspace) invalidate(keys ...string) {
+	if w.cache == nil {
+		return
+	}
+	// Not worth failing the command over; the entries expire on their own
+	_ = w.cache.Invalidate(keys...)
+}
+
+// useCache reports whether lookups may be answered from the cache
+func (w *workspace) useCache() bool {
+	return w.cache != nil && !w.noCache
+}
+
+// fetchCached returns the reference data cached under key, calling fetch
+// when it is missing or expired; with --no-cache it always calls fetch
+// and refreshes the cache with the result
+func fetchCached[T any](w *workspace, key string, fetch func() (T, error)) (T, error) {
+	if !w.useCache() {
+		v, err := fetch()
+		if err == nil && w.cache != nil {
+			_ = w.cache.Store(key, v)
+		}
+		return v, err
+	}
+	return cache.Fetch(w.cache, key, cacheTTL(key), fetch)
+}
+
+func (w *workspace) teams(ctx context.Context) ([]linear.Team, error) {
+	return fetchCached(w, "teams", func() ([]linear.Team, error) {
+		resp, err := w.client.ListTeams(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Teams.Nodes, nil
+	})
+}
+
+// projects returns the projects of a team, or of the workspace when
+// teamID is empty
+func (w *workspace) projects(ctx context.Context, teamID string) ([]linear.Project, error) {
+	key := "projects"
+	if teamID != "" {
+		key += "-" + teamID
+	}
+	return fetchCached(w, key, func() ([]linear.Project, error) {
+		var resp *linear.ProjectsResponse
+		var err error
+		if teamID != "" {
+			resp, err = w.client.GetProjectsByTeam(ctx, teamID)
+		} else {
+			resp, err = w.client.ListProjects(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return resp.Projects.Nodes, nil
+	})
+}
+
+func (w *workspace) users(ctx context.Context) ([]linear.User, error) {
+	return fetchCached(w, "users", func() ([]linear.User, error) {
+		resp, err := w.client.ListUsers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Users.Nodes, nil
+	})
+}
+
+func (w *workspace) states(ctx context.Context) ([]linear.WorkflowState, error) {
+	return fetchCached(w, "states", func() ([]linear.WorkflowState, error) {
+		resp, err := w.client.ListWorkflowStates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return resp.WorkflowStates.Nodes, nil
+	})
+}
+
+func (w *workspace) labels(ctx context.Context) ([]linear.Label, error) {
+	return fetchCached(w, "labels", func() ([]linear.Label, error) {
+		resp, err := w.client.ListLabels(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return resp.IssueLabels.Nodes, nil
+	})
+}
+
+// viewer returns the user the API key belongs to
+func (w *workspace) viewer(ctx context.Context) (*linear.User, error) {
+	return fetchCached(w, "viewer", func() (*linear.User, error) {
+		return w.client.GetViewer(ctx)
+	})
+}
+
+// team returns the team with key, or nil if there is none. Teams missing
+// from the cache are looked up directly, in case they were just created.
+func (w *workspace) team(ctx context.Context, key string) (*linear.Team, error) {
+	if w.useCache() {
+		teams, err := w.teams(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range teams {
+			if strings.EqualFold(teams[i].Key, key) {
+				return &teams[i], nil
+			}
+		}
+	}
+
+	resp, err := w.client.GetTeamByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Teams.Nodes) == 0 {
+		return nil, nil
+	}
+	return &resp.Teams.Nodes[0], nil
+}
+
+// user returns the user with an email address, falling back to a direct
+// lookup for users missing from the cache
+func (w *workspace) user(ctx context.Context, email string) (*linear.User, error) {
+	if w.useCache() {
+		users, err := w.users(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range users {
+			if strings.EqualFold(users[i].Email, email) {
+				return &users[i], nil
+			}
+		}
+	}
+	return w.client.GetUserByEmail(ctx, email)
+}
+
+// project resolves a project name or ID, within a team when teamID is
+// set. Only exact matches are taken from the cache; anything else is
+// looked up directly, which also reports ambiguous names.
+func (w *workspace) project(ctx context.Context, identifier, teamID string) (*linear.Project, error) {
+	if w.useCache() {
+		projects, err := w.projects(ctx, teamID)
+		if err != nil {
+			return nil, err
+		}
+		identifier := strings.TrimSpace(identifier)
+		var match *linear.Project
+		matches := 0
+		for i := range projects {
+			if projects[i].ID == identifier || strings.EqualFold(projects[i].Name, identifier) {
+				match = &projects[i]
+				matches++
+			}
+		}
+		if matches == 1 {
+			return match, nil
+		}
+	}
+	return w.client.GetProjectByIdentifier(ctx, identifier, teamID)
+}
@@ -14,6 +14,10 @@ const (
 	envVarName     = "LINEAR_API_KEY"
 )
 
+// ErrNoAPIKey is returned when no API key is configured in the environment
+// or the keyring
+var ErrNoAPIKey = errors.New("no API key found. Run 'linear auth login' or set LINEAR_API_KEY environment variable")
+
 // KeyringProvider is an interface for accessing keyring operations
 type KeyringProvider interface {
 	Get(key string) (keyring.Item, error)
@@ -66,7 +70,7 @@ func GetAPIKey() (string, error) {
 	item, err := ring.Get(keyringKey)
 	if err != nil {
 		if errors.Is(err, keyring.ErrKeyNotFound) {
-			return "", errors.New("no API key found. Run 'linear auth login' or set LINEAR_API_KEY environment variable")
+			return "", ErrNoAPIKey
 		}
 		return "", fmt.Errorf("failed to retrieve API key from keyring: %w", err)
 	}
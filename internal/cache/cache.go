@@ -0,0 +1,279 @@
+// Package cache keeps Linear reference data (teams, projects, users and so
+// on) on disk, so that commands and shell completion don't fetch it again
+// on every run, and keep working offline after the first fetch.
+//
+// Entries are written atomically and changes are serialized with a lock
+// file, so concurrent CLI processes can share a cache directory.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const envVarName = "LINEAR_CACHE_DIR"
+
+// lockName is the lock file in each cache directory
+const lockName = ".lock"
+
+// Dir returns the cache directory.
+// LINEAR_CACHE_DIR overrides the default of <user cache dir>/linear.
+func Dir() (string, error) {
+	if dir := os.Getenv(envVarName); dir != "" {
+		return dir, nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(dir, "linear"), nil
+}
+
+// Cache stores JSON entries as files in a directory, one per key
+type Cache struct {
+	dir string
+	now func() time.Time
+}
+
+// New returns a cache that stores entries in dir, creating it when the
+// first entry is written
+func New(dir string) *Cache {
+	return &Cache{dir: dir, now: time.Now}
+}
+
+// Dir returns the directory holding the cache's entries
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Entry describes a cached value
+type Entry struct {
+	Key       string
+	FetchedAt time.Time
+	Size      int64
+}
+
+// entry is the file format of a cached value
+type entry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// fileName returns the name of the file holding key
+func fileName(key string) string {
+	return strings.NewReplacer("/", "_", "\\", "_").Replace(key) + ".json"
+}
+
+// path returns the file holding key
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, fileName(key))
+}
+
+// Load decodes the entry for key into v and returns when it was fetched.
+// A missing entry is reported as an error wrapping fs.ErrNotExist.
+func (c *Cache) Load(key string, v any) (time.Time, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse cache entry %s: %w", key, err)
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse cache entry %s: %w", key, err)
+	}
+	return e.FetchedAt, nil
+}
+
+// Store saves v under key, replacing the file atomically so that
+// concurrent readers never see a partial entry
+func (c *Cache) Store(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(entry{FetchedAt: c.now().UTC(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	unlock, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache entry %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("failed to write cache entry %s: %w", key, err)
+	}
+	return nil
+}
+
+// Invalidate removes the entries for keys, along with entries scoped under
+// them: invalidating "projects" also removes "projects-<team>"
+func (c *Cache) Invalidate(keys ...string) error {
+	if _, err := os.Stat(c.dir); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	unlock, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, key := range keys {
+		scoped, err := filepath.Glob(filepath.Join(c.dir, strings.TrimSuffix(fileName(key), ".json")+"-*.json"))
+		if err != nil {
+			return err
+		}
+		for _, path := range append(scoped, c.path(key)) {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to remove cache entry: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Clear removes every entry and returns how many there were
+func (c *Cache) Clear() (int, error) {
+	files, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	unlock, err := c.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	removed := 0
+	for _, f := range files {
+		if f.IsDir() || f.Name() == lockName {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.dir, f.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+		if isEntry(f.Name()) {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// Entries lists the cached entries, sorted by key
+func (c *Cache) Entries() ([]Entry, error) {
+	files, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		if f.IsDir() || !isEntry(f.Name()) {
+			continue
+		}
+		key := strings.TrimSuffix(f.Name(), ".json")
+		data, err := os.ReadFile(filepath.Join(c.dir, f.Name()))
+		if errors.Is(err, fs.ErrNotExist) {
+			// Invalidated by another process since the directory was read
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var e entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("failed to parse cache entry %s: %w", key, err)
+		}
+		entries = append(entries, Entry{Key: key, FetchedAt: e.FetchedAt, Size: int64(len(data))})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// isEntry reports whether a file in the cache directory holds an entry,
+// rather than the lock or a partly written entry
+func isEntry(name string) bool {
+	return strings.HasSuffix(name, ".json") && !strings.HasPrefix(name, ".")
+}
+
+// lock takes the cache's lock file, waiting while another process holds
+// it, and returns a function that releases it
+func (c *Cache) lock() (func(), error) {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(c.dir, lockName), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache lock: %w", err)
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock cache: %w", err)
+	}
+	return func() {
+		_ = unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// Fetch returns the value cached under key if it is younger than ttl, and
+// otherwise calls fetch and caches its result. When fetch fails, a stale
+// entry is returned instead of the error, so lookups keep working offline.
+// A nil cache always calls fetch.
+func Fetch[T any](c *Cache, key string, ttl time.Duration, fetch func() (T, error)) (T, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	var cached T
+	fetchedAt, loadErr := c.Load(key, &cached)
+	if loadErr == nil && c.now().Sub(fetchedAt) < ttl {
+		return cached, nil
+	}
+
+	v, err := fetch()
+	if err != nil {
+		if loadErr == nil {
+			return cached, nil
+		}
+		return v, err
+	}
+
+	// A read-only or full disk should not fail the lookup itself
+	_ = c.Store(key, v)
+	return v, nil
+}
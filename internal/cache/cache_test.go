@@ -0,0 +1,219 @@
+package cache
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestCache returns a cache in a temporary directory with a clock the
+// test can move
+func newTestCache(t *testing.T) (*Cache, *time.Time) {
+	t.Helper()
+	now := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	c := New(filepath.Join(t.TempDir(), "linear"))
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCache_StoreLoad(t *testing.T) {
+	c, now := newTestCache(t)
+
+	if _, err := c.Load("teams", new([]string)); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Load() of a missing entry error = %v, want fs.ErrNotExist", err)
+	}
+
+	if err := c.Store("teams", []string{"ENG", "OPS"}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	var got []string
+	fetchedAt, err := c.Load("teams", &got)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 2 || got[1] != "OPS" {
+		t.Errorf("Load() = %v", got)
+	}
+	if !fetchedAt.Equal(*now) {
+		t.Errorf("Load() fetched at %v, want %v", fetchedAt, *now)
+	}
+
+	info, err := os.Stat(c.dir)
+	if err != nil {
+		t.Fatalf("cache directory not created: %v", err)
+	}
+	if info.Mode().Perm() != 0o700 {
+		t.Errorf("cache directory mode = %v, want 0700", info.Mode().Perm())
+	}
+}
+
+func TestFetch(t *testing.T) {
+	c, now := newTestCache(t)
+
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"ENG"}, nil
+	}
+
+	for range 2 {
+		got, err := Fetch(c, "teams", time.Minute, fetch)
+		if err != nil || len(got) != 1 {
+			t.Fatalf("Fetch() = %v, %v", got, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetched %d times within the TTL, want 1", calls)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	if _, err := Fetch(c, "teams", time.Minute, fetch); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("fetched %d times after the TTL, want 2", calls)
+	}
+}
+
+func TestFetch_StaleOnError(t *testing.T) {
+	c, now := newTestCache(t)
+	offline := errors.New("offline")
+
+	if _, err := Fetch(c, "teams", time.Minute, func() ([]string, error) { return nil, offline }); !errors.Is(err, offline) {
+		t.Fatalf("Fetch() with nothing cached error = %v, want %v", err, offline)
+	}
+
+	if _, err := Fetch(c, "teams", time.Minute, func() ([]string, error) { return []string{"ENG"}, nil }); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	*now = now.Add(time.Hour)
+	got, err := Fetch(c, "teams", time.Minute, func() ([]string, error) { return nil, offline })
+	if err != nil {
+		t.Fatalf("Fetch() with a stale entry error = %v", err)
+	}
+	if len(got) != 1 || got[0] != "ENG" {
+		t.Errorf("Fetch() = %v, want the stale entry", got)
+	}
+}
+
+func TestFetch_NilCache(t *testing.T) {
+	calls := 0
+	for range 2 {
+		if _, err := Fetch(nil, "teams", time.Hour, func() (int, error) { calls++; return 1, nil }); err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("fetched %d times without a cache, want 2", calls)
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	c, _ := newTestCache(t)
+
+	for _, key := range []string{"projects", "projects-team-1", "projectsx", "teams"} {
+		if err := c.Store(key, key); err != nil {
+			t.Fatalf("Store(%s) error = %v", key, err)
+		}
+	}
+	if err := c.Invalidate("projects", "users"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+
+	entries, err := c.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	var keys []string
+	for _, e := range entries {
+		keys = append(keys, e.Key)
+	}
+	if got := strings.Join(keys, ","); got != "projectsx,teams" {
+		t.Errorf("entries after Invalidate() = %s, want projectsx,teams", got)
+	}
+
+	if err := New(filepath.Join(t.TempDir(), "missing")).Invalidate("teams"); err != nil {
+		t.Errorf("Invalidate() of an empty cache error = %v", err)
+	}
+}
+
+func TestCache_ClearAndEntries(t *testing.T) {
+	c, now := newTestCache(t)
+
+	if entries, err := c.Entries(); err != nil || len(entries) != 0 {
+		t.Fatalf("Entries() of a new cache = %v, %v", entries, err)
+	}
+
+	if err := c.Store("teams", []string{"ENG"}); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(time.Minute)
+	if err := c.Store("labels", []string{"Bug"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := c.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Key != "labels" || entries[1].Key != "teams" {
+		t.Fatalf("Entries() = %+v, want labels and teams", entries)
+	}
+	if !entries[0].FetchedAt.Equal(*now) || entries[0].Size == 0 {
+		t.Errorf("Entries()[0] = %+v", entries[0])
+	}
+
+	removed, err := c.Clear()
+	if err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Clear() removed %d entries, want 2", removed)
+	}
+	if entries, _ := c.Entries(); len(entries) != 0 {
+		t.Errorf("Entries() after Clear() = %+v", entries)
+	}
+}
+
+func TestCache_ConcurrentWriters(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "linear")
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Separate caches stand in for separate CLI processes
+			c := New(dir)
+			for j := range 20 {
+				if err := c.Store("teams", []int{i, j}); err != nil {
+					t.Errorf("Store() error = %v", err)
+				}
+				if j%5 == 0 {
+					if err := c.Invalidate("users"); err != nil {
+						t.Errorf("Invalidate() error = %v", err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var got []int
+	if _, err := New(dir).Load("teams", &got); err != nil || len(got) != 2 {
+		t.Errorf("Load() after concurrent writes = %v, %v", got, err)
+	}
+	files, _ := os.ReadDir(dir)
+	for _, f := range files {
+		if strings.HasPrefix(f.Name(), ".tmp-") {
+			t.Errorf("left a temporary file behind: %s", f.Name())
+		}
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package cache
+
+import "os"
+
+// lockFile does nothing where file locks are unavailable; entries are
+// still replaced atomically
+func lockFile(f *os.File) error {
+	return nil
+}
+
+// unlockFile does nothing where file locks are unavailable
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const envVarName = "LINEAR_CONFIG"
+
+// Config holds user preferences read from the config file
+type Config struct {
+	// Formats maps a name to a Go template, usable as --format <name>
+	Formats map[string]string `yaml:"formats"`
+	// Queries maps a name to an issue query, usable as --saved <name>
+	Queries map[string]string `yaml:"queries"`
+	// Timeout limits how long a whole command may run (e.g. "2m")
+	Timeout time.Duration `yaml:"timeout"`
+	// RequestTimeout limits each API request (e.g. "30s")
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+}
+
+// Path returns the location of the config file.
+// LINEAR_CONFIG overrides the default of <user config dir>/linear/config.yaml.
+func Path() (string, error) {
+	if path := os.Getenv(envVarName); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "linear", "config.yaml"), nil
+}
+
+// Load reads the config file, returning an empty config if it does not exist
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads the config file at path, returning an empty config if it does not exist
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Format returns the named template from the config file
+func (c *Config) Format(name string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	tmpl, ok := c.Formats[name]
+	return tmpl, ok
+}
+
+// Query returns the named saved query from the config file
+func (c *Config) Query(name string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	q, ok := c.Queries[name]
+	return q, ok
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadFile_Missing(t *testing.T) {
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if cfg == nil || len(cfg.Formats) != 0 {
+		t.Errorf("expected empty config, got %+v", cfg)
+	}
+}
+
+func TestLoadFile_Formats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `formats:
+  short: "{{.Identifier}}\t{{.URL}}"
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+
+	tmpl, ok := cfg.Format("short")
+	if !ok {
+		t.Fatal("expected named format 'short'")
+	}
+	if tmpl != "{{.Identifier}}\t{{.URL}}" {
+		t.Errorf("Format() = %q", tmpl)
+	}
+
+	if _, ok := cfg.Format("missing"); ok {
+		t.Error("expected missing format lookup to fail")
+	}
+}
+
+func TestLoadFile_Queries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `queries:
+  triage: "(label:bug OR label:incident) priority<=2 -state:Done"
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+
+	q, ok := cfg.Query("triage")
+	if !ok || q != "(label:bug OR label:incident) priority<=2 -state:Done" {
+		t.Errorf("Query() = %q, %v", q, ok)
+	}
+	if _, ok := cfg.Query("missing"); ok {
+		t.Error("expected missing query lookup to fail")
+	}
+	var nilConfig *Config
+	if _, ok := nilConfig.Query("triage"); ok {
+		t.Error("nil config should not return queries")
+	}
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("formats: [unclosed"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	if _, err := LoadFile(path); err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestPath_EnvOverride(t *testing.T) {
+	t.Setenv("LINEAR_CONFIG", "/tmp/custom.yaml")
+
+	path, err := Path()
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+	if path != "/tmp/custom.yaml" {
+		t.Errorf("Path() = %q", path)
+	}
+}
+
+func TestFormat_NilConfig(t *testing.T) {
+	var cfg *Config
+	if _, ok := cfg.Format("any"); ok {
+		t.Error("nil config should not return formats")
+	}
+}
+
+func TestLoadFile_Timeouts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `timeout: 5m
+request_timeout: 45s
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if cfg.Timeout != 5*time.Minute {
+		t.Errorf("Timeout = %v, want 5m", cfg.Timeout)
+	}
+	if cfg.RequestTimeout != 45*time.Second {
+		t.Errorf("RequestTimeout = %v, want 45s", cfg.RequestTimeout)
+	}
+}
+
+func TestLoadFile_InvalidTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("timeout: soon\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	if _, err := LoadFile(path); err == nil {
+		t.Error("expected an error for an invalid duration")
+	}
+}
@@ -0,0 +1,146 @@
+// Package git runs the git commands the CLI integrates with, in the
+// current directory
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ErrNotRepository is returned outside a git work tree
+var ErrNotRepository = errors.New("not a git repository")
+
+// run runs git with args and returns its output without the trailing
+// newline. Failures carry git's own message.
+func run(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if errors.Is(err, exec.ErrNotFound) {
+		return "", errors.New("git is not installed")
+	}
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "not a git repository") {
+			return "", ErrNotRepository
+		}
+		if msg == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], strings.TrimPrefix(msg, "fatal: "))
+	}
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
+
+// exitCode returns the exit status of a failed command, or -1
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// CurrentBranch returns the name of the checked-out branch. A detached
+// HEAD is an error.
+func CurrentBranch(ctx context.Context) (string, error) {
+	branch, err := run(ctx, "symbolic-ref", "--quiet", "--short", "HEAD")
+	// --quiet makes a detached HEAD fail with status 1 and no message
+	if exitCode(err) == 1 {
+		return "", errors.New("HEAD is detached, not on a branch")
+	}
+	return branch, err
+}
+
+// HasRef reports whether a ref such as refs/heads/main exists
+func HasRef(ctx context.Context, ref string) (bool, error) {
+	_, err := run(ctx, "rev-parse", "--verify", "--quiet", ref)
+	switch {
+	case err == nil:
+		return true, nil
+	case exitCode(err) == 1:
+		// --quiet makes a missing ref fail with status 1 and no message
+		return false, nil
+	}
+	return false, err
+}
+
+// Switch checks out an existing local branch
+func Switch(ctx context.Context, branch string) error {
+	_, err := run(ctx, "switch", branch)
+	return err
+}
+
+// SwitchNew creates a branch from the current commit and checks it out
+func SwitchNew(ctx context.Context, branch string) error {
+	_, err := run(ctx, "switch", "--create", branch)
+	return err
+}
+
+// SwitchTrack creates a local branch tracking a remote one, such as
+// origin/eng-1-fix-login, and checks it out
+func SwitchTrack(ctx context.Context, remoteBranch string) error {
+	_, err := run(ctx, "switch", "--track", remoteBranch)
+	return err
+}
+
+// HookPath returns the path of a hook such as commit-msg, honoring
+// core.hooksPath
+func HookPath(ctx context.Context, name string) (string, error) {
+	return run(ctx, "rev-parse", "--git-path", "hooks/"+name)
+}
+
+// AddTrailer adds a trailer such as "Refs: ENG-123" to the commit message
+// in file, above any comments and scissors line, unless it is already there
+func AddTrailer(ctx context.Context, file, trailer string) error {
+	_, err := run(ctx, "interpret-trailers", "--in-place", "--if-exists", "addIfDifferent", "--trailer", trailer, file)
+	return err
+}
+
+// checkRevision rejects revisions git would read as options, such as
+// --output=file
+func checkRevision(revision string) error {
+	if strings.HasPrefix(revision, "-") {
+		return fmt.Errorf("invalid revision %q", revision)
+	}
+	return nil
+}
+
+// CommitTime returns the committer date of a commit, tag or other ref
+func CommitTime(ctx context.Context, ref string) (time.Time, error) {
+	if err := checkRevision(ref); err != nil {
+		return time.Time{}, err
+	}
+	out, err := run(ctx, "log", "-1", "--format=%cI", ref, "--")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, out)
+}
+
+// Messages returns the messages of the commits in a range such as
+// v1.4..HEAD, newest first
+func Messages(ctx context.Context, revisionRange string) ([]string, error) {
+	if err := checkRevision(revisionRange); err != nil {
+		return nil, err
+	}
+	out, err := run(ctx, "log", "--format=%B%x00", revisionRange, "--")
+	if err != nil {
+		return nil, err
+	}
+	var messages []string
+	for _, message := range strings.Split(out, "\x00") {
+		if message = strings.TrimSpace(message); message != "" {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
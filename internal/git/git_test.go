@@ -0,0 +1,149 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newRepo creates a repository with one commit on main and makes it the
+// current directory
+func newRepo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	t.Chdir(t.TempDir())
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch", "main"},
+		{"-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "Initial commit"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+}
+
+func TestBranches(t *testing.T) {
+	newRepo(t)
+	ctx := context.Background()
+
+	if branch, err := CurrentBranch(ctx); err != nil || branch != "main" {
+		t.Fatalf("CurrentBranch() = %q, %v; want main", branch, err)
+	}
+
+	if err := SwitchNew(ctx, "ada/eng-1-fix-login"); err != nil {
+		t.Fatal(err)
+	}
+	if branch, _ := CurrentBranch(ctx); branch != "ada/eng-1-fix-login" {
+		t.Errorf("CurrentBranch() = %q after SwitchNew", branch)
+	}
+	if ok, err := HasRef(ctx, "refs/heads/ada/eng-1-fix-login"); !ok || err != nil {
+		t.Errorf("HasRef() = %v, %v for the new branch", ok, err)
+	}
+	if ok, err := HasRef(ctx, "refs/heads/missing"); ok || err != nil {
+		t.Errorf("HasRef() = %v, %v for a missing branch", ok, err)
+	}
+
+	if err := Switch(ctx, "main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Switch(ctx, "missing"); err == nil {
+		t.Error("Switch() to a missing branch succeeded")
+	}
+
+	if _, err := run(ctx, "switch", "--quiet", "--detach"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CurrentBranch(ctx); err == nil {
+		t.Error("CurrentBranch() succeeded on a detached HEAD")
+	}
+}
+
+func TestNotRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Chdir(t.TempDir())
+	t.Setenv("GIT_CEILING_DIRECTORIES", t.TempDir()+"/..")
+
+	if _, err := CurrentBranch(context.Background()); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("CurrentBranch() error = %v, want ErrNotRepository", err)
+	}
+}
+
+func TestAddTrailer(t *testing.T) {
+	newRepo(t)
+	ctx := context.Background()
+
+	file := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	message := "Fix login\n\nDetails\n# Please enter the commit message\n"
+	if err := os.WriteFile(file, []byte(message), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if err := AddTrailer(ctx, file, "Refs: ENG-1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, _ := os.ReadFile(file)
+	want := "Fix login\n\nDetails\n\nRefs: ENG-1\n# Please enter the commit message\n"
+	if string(got) != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+
+	hook, err := HookPath(ctx, "commit-msg")
+	if err != nil || hook != filepath.Join(".git", "hooks", "commit-msg") {
+		t.Errorf("HookPath() = %q, %v", hook, err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	newRepo(t)
+	ctx := context.Background()
+
+	for _, args := range [][]string{
+		{"tag", "v1.0"},
+		{"-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "Fix login\n\nRefs: ENG-1"},
+		{"-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "Add dark mode"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	messages, err := Messages(ctx, "v1.0..HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 || messages[0] != "Add dark mode" || messages[1] != "Fix login\n\nRefs: ENG-1" {
+		t.Errorf("Messages() = %q", messages)
+	}
+
+	tagged, err := CommitTime(ctx, "v1.0")
+	if err != nil || tagged.IsZero() || time.Since(tagged) > time.Hour {
+		t.Errorf("CommitTime() = %v, %v", tagged, err)
+	}
+	if _, err := CommitTime(ctx, "v9.9"); err == nil {
+		t.Error("CommitTime() of a missing tag succeeded")
+	}
+
+	// Revisions are never read as options
+	out := filepath.Join(t.TempDir(), "out")
+	if _, err := Messages(ctx, "--output="+out); err == nil {
+		t.Error("Messages() accepted an option")
+	}
+	if _, err := CommitTime(ctx, "--output="+out); err == nil {
+		t.Error("CommitTime() accepted an option")
+	}
+	if _, err := os.Stat(out); err == nil {
+		t.Error("git wrote the --output file")
+	}
+}
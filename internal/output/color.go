@@ -0,0 +1,143 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const ansiReset = "\x1b[0m"
+
+// ColorMode controls when ANSI colors are written
+type ColorMode string
+
+const (
+	ColorAuto   ColorMode = "auto"
+	ColorAlways ColorMode = "always"
+	ColorNever  ColorMode = "never"
+)
+
+// ParseColorMode converts a --color value into a ColorMode
+func ParseColorMode(s string) (ColorMode, error) {
+	switch mode := ColorMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case ColorAuto, ColorAlways, ColorNever:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid color mode %q (valid modes: auto, always, never)", s)
+	}
+}
+
+var colorEnabled = detectColor()
+
+// detectColor enables colors when stdout is a terminal and NO_COLOR is unset
+func detectColor() bool {
+	return os.Getenv("NO_COLOR") == "" && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// SetColorMode sets whether colors are written to stdout.
+// ColorAuto enables colors only for terminals when NO_COLOR is unset.
+func SetColorMode(mode ColorMode) {
+	switch mode {
+	case ColorAlways:
+		colorEnabled = true
+	case ColorNever:
+		colorEnabled = false
+	default:
+		colorEnabled = detectColor()
+	}
+}
+
+// ColorEnabled reports whether ANSI colors are written to stdout
+func ColorEnabled() bool {
+	return colorEnabled
+}
+
+var namedColors = map[string]string{
+	"black":   "30",
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"white":   "37",
+	"gray":    "90",
+	"grey":    "90",
+	"bold":    "1",
+	"dim":     "2",
+}
+
+// Colorize wraps s in the ANSI escape sequence for color, which is either a
+// name (red, green, bold, ...) or a hex value such as "#5e6ad2".
+// Unknown colors and disabled color output return s unchanged.
+func Colorize(color, s string) string {
+	if !colorEnabled || s == "" {
+		return s
+	}
+
+	code, ok := ansiCode(color)
+	if !ok {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + ansiReset
+}
+
+// ansiCode converts a color name or hex value into an SGR parameter string
+func ansiCode(color string) (string, bool) {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if code, ok := namedColors[color]; ok {
+		return code, true
+	}
+
+	r, g, b, ok := parseHexColor(color)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("38;2;%d;%d;%d", r, g, b), true
+}
+
+// parseHexColor parses "#rrggbb" or "#rgb" into its components
+func parseHexColor(color string) (uint8, uint8, uint8, bool) {
+	hex := strings.TrimPrefix(color, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return 0, 0, 0, false
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(value >> 16), uint8(value >> 8), uint8(value), true
+}
+
+// PriorityIcon returns a compact icon for a Linear priority
+// (0=None, 1=Urgent, 2=High, 3=Medium, 4=Low)
+func PriorityIcon(priority int) string {
+	switch priority {
+	case 1:
+		return Colorize("#f2994a", "!!!")
+	case 2:
+		return "▮▮▮"
+	case 3:
+		return "▮▮" + Colorize("dim", "▮")
+	case 4:
+		return "▮" + Colorize("dim", "▮▮")
+	default:
+		return Colorize("dim", "---")
+	}
+}
+
+// Chip renders a name with a colored bullet, as Linear shows labels and
+// states. Without colors the bullet carries no meaning, so only the name is returned.
+func Chip(color, name string) string {
+	if name == "" || !colorEnabled {
+		return name
+	}
+	return Colorize(color, "●") + " " + name
+}
@@ -0,0 +1,71 @@
+package output
+
+import "testing"
+
+func TestColorize(t *testing.T) {
+	original := colorEnabled
+	t.Cleanup(func() { colorEnabled = original })
+
+	colorEnabled = true
+	if got := Colorize("red", "x"); got != "\x1b[31mx\x1b[0m" {
+		t.Errorf("Colorize(red) = %q", got)
+	}
+	if got := Colorize("#5e6ad2", "x"); got != "\x1b[38;2;94;106;210mx\x1b[0m" {
+		t.Errorf("Colorize(hex) = %q", got)
+	}
+	if got := Colorize("not-a-color", "x"); got != "x" {
+		t.Errorf("Colorize(unknown) = %q", got)
+	}
+
+	colorEnabled = false
+	if got := Colorize("red", "x"); got != "x" {
+		t.Errorf("Colorize with colors disabled = %q", got)
+	}
+}
+
+func TestParseColorMode(t *testing.T) {
+	for _, input := range []string{"auto", "always", "NEVER"} {
+		if _, err := ParseColorMode(input); err != nil {
+			t.Errorf("ParseColorMode(%q) error = %v", input, err)
+		}
+	}
+	if _, err := ParseColorMode("sometimes"); err == nil {
+		t.Error("expected error for invalid color mode")
+	}
+}
+
+func TestSetColorMode(t *testing.T) {
+	original := colorEnabled
+	t.Cleanup(func() { colorEnabled = original })
+
+	SetColorMode(ColorAlways)
+	if !ColorEnabled() {
+		t.Error("ColorAlways should enable colors")
+	}
+
+	SetColorMode(ColorNever)
+	if ColorEnabled() {
+		t.Error("ColorNever should disable colors")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	SetColorMode(ColorAuto)
+	if ColorEnabled() {
+		t.Error("ColorAuto should respect NO_COLOR")
+	}
+}
+
+func TestChip(t *testing.T) {
+	original := colorEnabled
+	t.Cleanup(func() { colorEnabled = original })
+
+	colorEnabled = false
+	if got := Chip("#ff0000", "Bug"); got != "Bug" {
+		t.Errorf("Chip without color = %q, want plain name", got)
+	}
+
+	colorEnabled = true
+	if got := Chip("#ff0000", "Bug"); got != "\x1b[38;2;255;0;0m●\x1b[0m Bug" {
+		t.Errorf("Chip with color = %q", got)
+	}
+}
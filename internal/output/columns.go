@@ -0,0 +1,185 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Column describes a selectable table column for items of type T
+type Column[T any] struct {
+	// Name is the identifier used with --columns and --sort
+	Name string
+	// Aliases are alternative names accepted for the column
+	Aliases []string
+	// Header is the column heading shown in tabular output
+	Header string
+	// MaxWidth truncates the column in table output (0 means unlimited)
+	MaxWidth int
+	// Value renders the cell for an item
+	Value func(T) string
+	// Styled optionally renders a colored cell for table output
+	Styled func(T) string
+	// Compare orders two items for sorting; defaults to comparing Value
+	Compare func(a, b T) int
+}
+
+// ColumnSet is the collection of columns a command can display
+type ColumnSet[T any] struct {
+	columns  []Column[T]
+	defaults []string
+}
+
+// NewColumnSet creates a column set with the given default column names
+func NewColumnSet[T any](defaults []string, columns ...Column[T]) *ColumnSet[T] {
+	return &ColumnSet[T]{
+		columns:  columns,
+		defaults: defaults,
+	}
+}
+
+// Names returns the names of all available columns
+func (s *ColumnSet[T]) Names() []string {
+	names := make([]string, len(s.columns))
+	for i, col := range s.columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// Lookup finds a column by name or alias (case-insensitive)
+func (s *ColumnSet[T]) Lookup(name string) (Column[T], error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, col := range s.columns {
+		if col.Name == name || slices.Contains(col.Aliases, name) {
+			return col, nil
+		}
+	}
+	return Column[T]{}, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(s.Names(), ", "))
+}
+
+// Select resolves column names into columns, falling back to the defaults
+// when names is empty
+func (s *ColumnSet[T]) Select(names []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		names = s.defaults
+	}
+
+	selected := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		col, err := s.Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, col)
+	}
+	return selected, nil
+}
+
+// Table builds a table of items using the given columns
+func (s *ColumnSet[T]) Table(columns []Column[T], items []T) *Table {
+	headers := make([]string, len(columns))
+	maxWidths := make([]int, len(columns))
+	for i, col := range columns {
+		headers[i] = col.Header
+		maxWidths[i] = col.MaxWidth
+	}
+
+	hasStyles := false
+	for _, col := range columns {
+		if col.Styled != nil {
+			hasStyles = true
+		}
+	}
+
+	table := NewTable(headers)
+	table.maxWidths = maxWidths
+	for _, item := range items {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = col.Value(item)
+		}
+		if !hasStyles {
+			table.AddRow(row)
+			continue
+		}
+
+		styled := make([]string, len(columns))
+		for i, col := range columns {
+			styled[i] = row[i]
+			if col.Styled != nil {
+				styled[i] = col.Styled(item)
+			}
+		}
+		table.AddStyledRow(row, styled)
+	}
+	return table
+}
+
+// SortKey is a single column reference in a sort specification
+type SortKey struct {
+	Column     string
+	Descending bool
+}
+
+// ParseSortSpec parses column names for sorting, where a leading '-'
+// sorts that column in descending order (e.g. "updated", "-priority")
+func ParseSortSpec(spec []string) ([]SortKey, error) {
+	keys := make([]SortKey, 0, len(spec))
+	for _, raw := range spec {
+		raw = strings.TrimSpace(raw)
+		key := SortKey{Column: raw}
+		if strings.HasPrefix(raw, "-") {
+			key.Column = raw[1:]
+			key.Descending = true
+		} else if strings.HasPrefix(raw, "+") {
+			key.Column = raw[1:]
+		}
+		if key.Column == "" {
+			return nil, fmt.Errorf("invalid sort key %q", raw)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Sort orders items in place by the given sort keys.
+// Items that compare equal keep their original order.
+func (s *ColumnSet[T]) Sort(items []T, keys []SortKey) error {
+	type comparator struct {
+		compare    func(a, b T) int
+		descending bool
+	}
+
+	comparators := make([]comparator, 0, len(keys))
+	for _, key := range keys {
+		col, err := s.Lookup(key.Column)
+		if err != nil {
+			return err
+		}
+
+		compare := col.Compare
+		if compare == nil {
+			value := col.Value
+			compare = func(a, b T) int {
+				return cmp.Compare(strings.ToLower(value(a)), strings.ToLower(value(b)))
+			}
+		}
+		comparators = append(comparators, comparator{compare: compare, descending: key.Descending})
+	}
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		for _, c := range comparators {
+			result := c.compare(a, b)
+			if c.descending {
+				result = -result
+			}
+			if result != 0 {
+				return result
+			}
+		}
+		return 0
+	})
+	return nil
+}
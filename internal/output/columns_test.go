@@ -0,0 +1,156 @@
+package output
+
+import (
+	"bytes"
+	"cmp"
+	"strings"
+	"testing"
+)
+
+type testItem struct {
+	Name  string
+	Score int
+	Note  string
+}
+
+func testColumnSet() *ColumnSet[testItem] {
+	return NewColumnSet(
+		[]string{"name", "score"},
+		Column[testItem]{
+			Name:   "name",
+			Header: "NAME",
+			Value:  func(i testItem) string { return i.Name },
+		},
+		Column[testItem]{
+			Name:    "score",
+			Aliases: []string{"points"},
+			Header:  "SCORE",
+			Value:   func(i testItem) string { return string(rune('0' + i.Score)) },
+			Compare: func(a, b testItem) int { return cmp.Compare(a.Score, b.Score) },
+		},
+		Column[testItem]{
+			Name:     "note",
+			Header:   "NOTE",
+			MaxWidth: 8,
+			Value:    func(i testItem) string { return i.Note },
+		},
+	)
+}
+
+func TestColumnSet_SelectDefaults(t *testing.T) {
+	set := testColumnSet()
+
+	cols, err := set.Select(nil)
+	if err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+	if len(cols) != 2 || cols[0].Name != "name" || cols[1].Name != "score" {
+		t.Errorf("Select(nil) returned %+v, want default columns", cols)
+	}
+}
+
+func TestColumnSet_SelectByNameAndAlias(t *testing.T) {
+	set := testColumnSet()
+
+	cols, err := set.Select([]string{"NOTE", "points"})
+	if err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+	if len(cols) != 2 || cols[0].Name != "note" || cols[1].Name != "score" {
+		t.Errorf("Select() returned wrong columns: %+v", cols)
+	}
+}
+
+func TestColumnSet_SelectUnknown(t *testing.T) {
+	set := testColumnSet()
+
+	_, err := set.Select([]string{"bogus"})
+	if err == nil {
+		t.Fatal("expected error for unknown column")
+	}
+	if !strings.Contains(err.Error(), "name, score, note") {
+		t.Errorf("error should list available columns, got %q", err.Error())
+	}
+}
+
+func TestColumnSet_Table_TruncatesOnlyInTableFormat(t *testing.T) {
+	set := testColumnSet()
+	cols, err := set.Select([]string{"name", "note"})
+	if err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+
+	items := []testItem{{Name: "a", Note: "a rather long note"}}
+	table := set.Table(cols, items)
+
+	var tableBuf bytes.Buffer
+	table.PrintTo(&tableBuf)
+	if !strings.Contains(tableBuf.String(), "a rat...") {
+		t.Errorf("table output should truncate note, got %q", tableBuf.String())
+	}
+
+	var csvBuf bytes.Buffer
+	if err := table.PrintCSV(&csvBuf); err != nil {
+		t.Fatalf("PrintCSV() error = %v", err)
+	}
+	if !strings.Contains(csvBuf.String(), "a rather long note") {
+		t.Errorf("CSV output should keep full note, got %q", csvBuf.String())
+	}
+}
+
+func TestParseSortSpec(t *testing.T) {
+	keys, err := ParseSortSpec([]string{"updated", "-priority", "+name"})
+	if err != nil {
+		t.Fatalf("ParseSortSpec() error = %v", err)
+	}
+
+	want := []SortKey{
+		{Column: "updated"},
+		{Column: "priority", Descending: true},
+		{Column: "name"},
+	}
+	if len(keys) != len(want) {
+		t.Fatalf("ParseSortSpec() returned %d keys, want %d", len(keys), len(want))
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("key %d = %+v, want %+v", i, keys[i], want[i])
+		}
+	}
+
+	if _, err := ParseSortSpec([]string{"-"}); err == nil {
+		t.Error("expected error for empty sort column")
+	}
+}
+
+func TestColumnSet_Sort(t *testing.T) {
+	set := testColumnSet()
+	items := []testItem{
+		{Name: "b", Score: 1},
+		{Name: "a", Score: 2},
+		{Name: "c", Score: 2},
+		{Name: "A", Score: 1},
+	}
+
+	keys, _ := ParseSortSpec([]string{"-score", "name"})
+	if err := set.Sort(items, keys); err != nil {
+		t.Fatalf("Sort() error = %v", err)
+	}
+
+	var got []string
+	for _, item := range items {
+		got = append(got, item.Name)
+	}
+	// Names compare case-insensitively, so "A" sorts before "b"
+	if strings.Join(got, ",") != "a,c,A,b" {
+		t.Errorf("Sort() order = %v, want a,c,A,b", got)
+	}
+}
+
+func TestColumnSet_SortUnknownColumn(t *testing.T) {
+	set := testColumnSet()
+	keys, _ := ParseSortSpec([]string{"missing"})
+	if err := set.Sort([]testItem{{Name: "a"}}, keys); err == nil {
+		t.Error("expected error sorting by unknown column")
+	}
+}
@@ -0,0 +1,144 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SelectFields trims structured output down to the given JSON fields.
+// Fields use dots to reach nested values (e.g. "state.name") and are kept
+// in the order requested. Slices are projected element by element.
+func SelectFields(data interface{}, fields []string) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	paths := make([][]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		paths = append(paths, strings.Split(field, "."))
+	}
+
+	return projectValue(value, paths, "")
+}
+
+func projectValue(value interface{}, paths [][]string, prefix string) (interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		projected := make([]interface{}, len(v))
+		for i, elem := range v {
+			p, err := projectValue(elem, paths, prefix)
+			if err != nil {
+				return nil, err
+			}
+			projected[i] = p
+		}
+		return projected, nil
+	case map[string]interface{}:
+		return projectObject(v, paths, prefix)
+	default:
+		return value, nil
+	}
+}
+
+func projectObject(obj map[string]interface{}, paths [][]string, prefix string) (*orderedObject, error) {
+	result := &orderedObject{values: map[string]interface{}{}}
+
+	// Group nested paths under their first segment, preserving request order
+	var order []string
+	nested := map[string][][]string{}
+	for _, path := range paths {
+		head := path[0]
+		if _, ok := obj[head]; !ok {
+			return nil, fmt.Errorf("unknown field %q", prefix+strings.Join(path, "."))
+		}
+		if _, seen := nested[head]; !seen {
+			order = append(order, head)
+			nested[head] = nil
+		}
+		if len(path) > 1 {
+			nested[head] = append(nested[head], path[1:])
+		} else {
+			// Selecting the whole field overrides any nested selection
+			nested[head] = append(nested[head], nil)
+		}
+	}
+
+	for _, key := range order {
+		value := obj[key]
+		subpaths := nested[key]
+
+		wholeField := false
+		var trimmed [][]string
+		for _, sub := range subpaths {
+			if sub == nil {
+				wholeField = true
+				break
+			}
+			trimmed = append(trimmed, sub)
+		}
+
+		if !wholeField && value != nil {
+			projected, err := projectValue(value, trimmed, prefix+key+".")
+			if err != nil {
+				return nil, err
+			}
+			value = projected
+		}
+		result.set(key, value)
+	}
+
+	return result, nil
+}
+
+// orderedObject is a JSON object that keeps its keys in insertion order
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (o *orderedObject) set(key string, value interface{}) {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON implements json.Marshaler
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+
+		valueJSON, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(valueJSON)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
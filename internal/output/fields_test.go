@@ -0,0 +1,112 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type fieldsState struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type fieldsIssue struct {
+	ID         string       `json:"id"`
+	Identifier string       `json:"identifier"`
+	Title      string       `json:"title"`
+	Estimate   *float64     `json:"estimate"`
+	State      *fieldsState `json:"state"`
+}
+
+func marshalString(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return string(b)
+}
+
+func TestSelectFields_KeepsRequestedOrder(t *testing.T) {
+	issue := fieldsIssue{ID: "1", Identifier: "ENG-1", Title: "Bug", State: &fieldsState{Name: "Todo", Type: "unstarted"}}
+
+	got, err := SelectFields(issue, []string{"title", "identifier"})
+	if err != nil {
+		t.Fatalf("SelectFields() error = %v", err)
+	}
+
+	want := `{"title":"Bug","identifier":"ENG-1"}`
+	if marshalString(t, got) != want {
+		t.Errorf("SelectFields() = %s, want %s", marshalString(t, got), want)
+	}
+}
+
+func TestSelectFields_NestedAndSlices(t *testing.T) {
+	estimate := 3.0
+	issues := []fieldsIssue{
+		{Identifier: "ENG-1", Estimate: &estimate, State: &fieldsState{Name: "Todo", Type: "unstarted"}},
+		{Identifier: "ENG-2"},
+	}
+
+	got, err := SelectFields(issues, []string{"identifier", "state.name", "estimate"})
+	if err != nil {
+		t.Fatalf("SelectFields() error = %v", err)
+	}
+
+	want := `[{"identifier":"ENG-1","state":{"name":"Todo"},"estimate":3},{"identifier":"ENG-2","state":null,"estimate":null}]`
+	if marshalString(t, got) != want {
+		t.Errorf("SelectFields() = %s, want %s", marshalString(t, got), want)
+	}
+}
+
+func TestSelectFields_WholeFieldWinsOverNested(t *testing.T) {
+	issue := fieldsIssue{State: &fieldsState{Name: "Todo", Type: "unstarted"}}
+
+	got, err := SelectFields(issue, []string{"state.name", "state"})
+	if err != nil {
+		t.Fatalf("SelectFields() error = %v", err)
+	}
+
+	want := `{"state":{"name":"Todo","type":"unstarted"}}`
+	if marshalString(t, got) != want {
+		t.Errorf("SelectFields() = %s, want %s", marshalString(t, got), want)
+	}
+}
+
+func TestSelectFields_UnknownField(t *testing.T) {
+	issue := fieldsIssue{State: &fieldsState{Name: "Todo"}}
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: "nope", want: `unknown field "nope"`},
+		{field: "state.color", want: `unknown field "state.color"`},
+	}
+
+	for _, tt := range tests {
+		_, err := SelectFields(issue, []string{tt.field})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("SelectFields(%q) error = %v, want %q", tt.field, err, tt.want)
+		}
+	}
+}
+
+func TestSelectFields_YAMLKeepsOrder(t *testing.T) {
+	issue := fieldsIssue{Identifier: "ENG-1", Title: "Bug"}
+
+	got, err := SelectFields(issue, []string{"title", "identifier"})
+	if err != nil {
+		t.Fatalf("SelectFields() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, got); err != nil {
+		t.Fatalf("WriteYAML() error = %v", err)
+	}
+	if buf.String() != "title: Bug\nidentifier: ENG-1\n" {
+		t.Errorf("WriteYAML() = %q", buf.String())
+	}
+}
@@ -0,0 +1,231 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies how command output is rendered
+type Format string
+
+const (
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatNDJSON   Format = "ndjson"
+	FormatCSV      Format = "csv"
+	FormatTSV      Format = "tsv"
+	FormatYAML     Format = "yaml"
+	FormatMarkdown Format = "markdown"
+)
+
+// Formats lists every supported output format
+var Formats = []Format{
+	FormatTable,
+	FormatJSON,
+	FormatNDJSON,
+	FormatCSV,
+	FormatTSV,
+	FormatYAML,
+	FormatMarkdown,
+}
+
+// ParseFormat converts a user-supplied format name into a Format
+func ParseFormat(s string) (Format, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "md" {
+		return FormatMarkdown, nil
+	}
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("unknown output format %q (valid formats: %s)", s, strings.Join(names, ", "))
+}
+
+// IsStructured reports whether the format serializes the underlying data
+// rather than rendering table rows
+func (f Format) IsStructured() bool {
+	return f == FormatJSON || f == FormatNDJSON || f == FormatYAML
+}
+
+// Print renders output to stdout in the given format.
+// Structured formats serialize data; tabular formats render table, fitted
+// to the terminal width when stdout is a terminal.
+func Print(f Format, data interface{}, table *Table) error {
+	if table != nil {
+		table.SetWidth(TerminalWidth())
+	}
+	return Render(Stdout, f, data, table)
+}
+
+// Render writes output to w in the given format
+func Render(w io.Writer, f Format, data interface{}, table *Table) error {
+	switch f {
+	case FormatJSON:
+		return WriteJSON(w, data)
+	case FormatNDJSON:
+		return WriteNDJSON(w, data)
+	case FormatYAML:
+		return WriteYAML(w, data)
+	}
+
+	if table == nil {
+		return fmt.Errorf("output format %q is not supported for this command", f)
+	}
+
+	switch f {
+	case FormatTable, "":
+		table.PrintTo(w)
+		return nil
+	case FormatCSV:
+		return table.PrintCSV(w)
+	case FormatTSV:
+		return table.PrintTSV(w)
+	case FormatMarkdown:
+		return table.PrintMarkdown(w)
+	default:
+		return fmt.Errorf("unknown output format %q", f)
+	}
+}
+
+// WriteJSON writes data to w as indented JSON
+func WriteJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// WriteNDJSON writes data to w as newline-delimited JSON.
+// Slices and arrays produce one line per element; anything else is a single line.
+func WriteNDJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return encoder.Encode(data)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		if err := encoder.Encode(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteYAML writes data to w as YAML.
+// Keys follow the JSON field names and ordering so both formats stay consistent.
+func WriteYAML(w io.Writer, data interface{}) error {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	// JSON is valid YAML, so decoding it into a node preserves key order
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonBytes, &node); err != nil {
+		return err
+	}
+	resetYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// resetYAMLStyle clears the flow and quoting styles inherited from JSON
+// so the encoder emits block-style YAML
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+// PrintCSV writes the table as comma-separated values
+func (t *Table) PrintCSV(w io.Writer) error {
+	return t.printDelimited(w, ',')
+}
+
+// PrintTSV writes the table as tab-separated values
+func (t *Table) PrintTSV(w io.Writer) error {
+	return t.printDelimited(w, '\t')
+}
+
+func (t *Table) printDelimited(w io.Writer, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	if err := cw.Write(t.headers); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// PrintMarkdown writes the table as a GitHub-flavored Markdown pipe table
+func (t *Table) PrintMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	writeMarkdownRow(&b, t.headers)
+
+	separators := make([]string, len(t.headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeMarkdownRow(&b, separators)
+
+	for _, row := range t.rows {
+		writeMarkdownRow(&b, row)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		b.WriteString(" ")
+		b.WriteString(escapeMarkdownCell(cell))
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+}
+
+// escapeMarkdownCell makes a value safe to place inside a pipe table cell
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\n", "<br>")
+	return s
+}
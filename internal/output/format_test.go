@@ -0,0 +1,198 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{input: "table", want: FormatTable},
+		{input: "JSON", want: FormatJSON},
+		{input: "ndjson", want: FormatNDJSON},
+		{input: "csv", want: FormatCSV},
+		{input: "tsv", want: FormatTSV},
+		{input: "yaml", want: FormatYAML},
+		{input: "markdown", want: FormatMarkdown},
+		{input: "md", want: FormatMarkdown},
+		{input: " csv ", want: FormatCSV},
+		{input: "xml", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFormat(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_PrintCSV_QuotesSpecialCharacters(t *testing.T) {
+	table := NewTable([]string{"ID", "TITLE"})
+	table.AddRow([]string{"ENG-1", "Fix login, signup"})
+	table.AddRow([]string{"ENG-2", "Multi\nline"})
+	table.AddRow([]string{"ENG-3", `Say "hello"`})
+
+	var buf bytes.Buffer
+	if err := table.PrintCSV(&buf); err != nil {
+		t.Fatalf("PrintCSV() error = %v", err)
+	}
+
+	want := "ID,TITLE\nENG-1,\"Fix login, signup\"\nENG-2,\"Multi\nline\"\nENG-3,\"Say \"\"hello\"\"\"\n"
+	if buf.String() != want {
+		t.Errorf("PrintCSV() = %q, want %q", buf.String(), want)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 4 || records[2][1] != "Multi\nline" {
+		t.Errorf("CSV round trip mismatch: %q", records)
+	}
+}
+
+func TestTable_PrintTSV(t *testing.T) {
+	table := NewTable([]string{"ID", "TITLE"})
+	table.AddRow([]string{"ENG-1", "Plain, with comma"})
+	table.AddRow([]string{"ENG-2", "Has\ttab"})
+
+	var buf bytes.Buffer
+	if err := table.PrintTSV(&buf); err != nil {
+		t.Fatalf("PrintTSV() error = %v", err)
+	}
+
+	want := "ID\tTITLE\nENG-1\tPlain, with comma\nENG-2\t\"Has\ttab\"\n"
+	if buf.String() != want {
+		t.Errorf("PrintTSV() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestTable_PrintMarkdown(t *testing.T) {
+	table := NewTable([]string{"ID", "TITLE"})
+	table.AddRow([]string{"ENG-1", "Pipes | and\nnewlines"})
+
+	var buf bytes.Buffer
+	if err := table.PrintMarkdown(&buf); err != nil {
+		t.Fatalf("PrintMarkdown() error = %v", err)
+	}
+
+	want := "| ID | TITLE |\n| --- | --- |\n| ENG-1 | Pipes \\| and<br>newlines |\n"
+	if buf.String() != want {
+		t.Errorf("PrintMarkdown() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	type item struct {
+		ID string `json:"id"`
+	}
+
+	tests := []struct {
+		name string
+		data interface{}
+		want string
+	}{
+		{
+			name: "slice writes one line per element",
+			data: []item{{ID: "a"}, {ID: "b"}},
+			want: "{\"id\":\"a\"}\n{\"id\":\"b\"}\n",
+		},
+		{
+			name: "single value writes one line",
+			data: item{ID: "a"},
+			want: "{\"id\":\"a\"}\n",
+		},
+		{
+			name: "empty slice writes nothing",
+			data: []item{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteNDJSON(&buf, tt.data); err != nil {
+				t.Fatalf("WriteNDJSON() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("WriteNDJSON() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteYAML_UsesJSONFieldNames(t *testing.T) {
+	type state struct {
+		Name string `json:"name"`
+	}
+	type issue struct {
+		Identifier  string  `json:"identifier"`
+		Title       string  `json:"title"`
+		Description *string `json:"description"`
+		State       *state  `json:"state"`
+	}
+
+	data := []issue{{Identifier: "ENG-1", Title: "123", State: &state{Name: "Todo"}}}
+
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, data); err != nil {
+		t.Fatalf("WriteYAML() error = %v", err)
+	}
+
+	want := "- identifier: ENG-1\n  title: \"123\"\n  description: null\n  state:\n    name: Todo\n"
+	if buf.String() != want {
+		t.Errorf("WriteYAML() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRender(t *testing.T) {
+	table := NewTable([]string{"ID"})
+	table.AddRow([]string{"ENG-1"})
+	data := []map[string]string{{"id": "ENG-1"}}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{format: FormatJSON, want: "\"id\": \"ENG-1\""},
+		{format: FormatNDJSON, want: "{\"id\":\"ENG-1\"}\n"},
+		{format: FormatYAML, want: "- id: ENG-1\n"},
+		{format: FormatCSV, want: "ID\nENG-1\n"},
+		{format: FormatTSV, want: "ID\nENG-1\n"},
+		{format: FormatMarkdown, want: "| ID |\n| --- |\n| ENG-1 |\n"},
+		{format: FormatTable, want: "ENG-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.format, data, table); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("Render() = %q, want it to contain %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_TabularFormatWithoutTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatCSV, map[string]string{}, nil); err == nil {
+		t.Error("expected error when rendering CSV without a table")
+	}
+}
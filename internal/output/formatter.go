@@ -1,26 +1,25 @@
 package output
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 	"strings"
-	"text/tabwriter"
 )
 
 // PrintJSON prints data as JSON
 func PrintJSON(data interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(data)
+	return WriteJSON(Stdout, data)
 }
 
-// Table is a simple table formatter
+// Table is a simple table formatter.
+// Cells are aligned by display width, so wide characters such as CJK and
+// emoji line up, and styled cells may contain ANSI colors.
 type Table struct {
-	writer  *tabwriter.Writer
-	headers []string
-	rows    [][]string
+	headers   []string
+	rows      [][]string
+	styled    [][]string
+	maxWidths []int
+	width     int
 }
 
 // NewTable creates a new table
@@ -34,33 +33,163 @@ func NewTable(headers []string) *Table {
 // AddRow adds a row to the table
 func (t *Table) AddRow(row []string) {
 	t.rows = append(t.rows, row)
+	if t.styled != nil {
+		t.styled = append(t.styled, row)
+	}
+}
+
+// AddStyledRow adds a row with an alternative styled rendering, used in
+// table output when colors are enabled. Other formats use the plain row.
+func (t *Table) AddStyledRow(row, styled []string) {
+	if t.styled == nil {
+		t.styled = make([][]string, len(t.rows), len(t.rows)+1)
+		copy(t.styled, t.rows)
+	}
+	t.rows = append(t.rows, row)
+	t.styled = append(t.styled, styled)
 }
 
-// Print prints the table to stdout
+// SetWidth limits the total table width, shrinking truncatable columns to
+// fit. A width of 0 means unlimited.
+func (t *Table) SetWidth(width int) {
+	t.width = width
+}
+
+// Print prints the table to stdout, fitted to the terminal width
 func (t *Table) Print() {
-	t.PrintTo(os.Stdout)
+	t.SetWidth(TerminalWidth())
+	t.PrintTo(Stdout)
 }
 
 // PrintTo prints the table to the given writer
 func (t *Table) PrintTo(w io.Writer) {
-	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	rows := t.rows
+	if t.styled != nil && ColorEnabled() {
+		rows = t.styled
+	}
 
-	// Print headers
-	fmt.Fprintln(tw, strings.Join(t.headers, "\t"))
+	fitted := make([][]string, len(rows))
+	for i, row := range rows {
+		fitted[i] = t.fitRow(row)
+	}
 
-	// Print separator
 	separators := make([]string, len(t.headers))
 	for i, header := range t.headers {
-		separators[i] = strings.Repeat("-", len(header))
+		separators[i] = strings.Repeat("-", DisplayWidth(header))
+	}
+
+	widths := t.columnWidths(fitted)
+	for i, row := range fitted {
+		for j, cell := range row {
+			if j < len(widths) && DisplayWidth(cell) > widths[j] {
+				fitted[i][j] = TruncateWidth(cell, widths[j])
+			}
+		}
+	}
+
+	t.printLine(w, t.headers, widths)
+	t.printLine(w, separators, widths)
+	for _, row := range fitted {
+		t.printLine(w, row, widths)
+	}
+}
+
+// printLine writes one aligned line. Every cell but the last is padded to
+// its column width and followed by two spaces.
+func (t *Table) printLine(w io.Writer, cells []string, widths []int) {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i == len(cells)-1 {
+			b.WriteString(cell)
+			break
+		}
+		width := 0
+		if i < len(widths) {
+			width = widths[i]
+		}
+		b.WriteString(padRight(cell, width))
+		b.WriteString(columnGap)
+	}
+	fmt.Fprintln(w, b.String())
+}
+
+const columnGap = "  "
+
+// minFlexWidth is the narrowest a truncatable column shrinks to when fitting
+// the table into the terminal
+const minFlexWidth = 10
+
+// columnWidths measures each column and, when the table has a width limit,
+// shrinks the widest truncatable columns until the table fits
+func (t *Table) columnWidths(rows [][]string) []int {
+	widths := make([]int, len(t.headers))
+	for i, header := range t.headers {
+		widths[i] = DisplayWidth(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], DisplayWidth(cell))
+			}
+		}
+	}
+
+	if t.width <= 0 || len(widths) == 0 {
+		return widths
 	}
-	fmt.Fprintln(tw, strings.Join(separators, "\t"))
 
-	// Print rows
-	for _, row := range t.rows {
-		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	total := len(columnGap) * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
 	}
 
-	tw.Flush()
+	for total > t.width {
+		widest := -1
+		for i, w := range widths {
+			if !t.isTruncatable(i) || w <= minFlexWidth {
+				continue
+			}
+			if widest == -1 || w > widths[widest] {
+				widest = i
+			}
+		}
+		if widest == -1 {
+			break
+		}
+
+		shrink := min(total-t.width, widths[widest]-minFlexWidth)
+		widths[widest] -= shrink
+		total -= shrink
+	}
+	return widths
+}
+
+// isTruncatable reports whether column i may be shrunk to fit the terminal.
+// Columns with a maximum width are truncatable; if none have one, all are.
+func (t *Table) isTruncatable(i int) bool {
+	for _, w := range t.maxWidths {
+		if w > 0 {
+			return i < len(t.maxWidths) && t.maxWidths[i] > 0
+		}
+	}
+	return true
+}
+
+// fitRow flattens and truncates cells that have a maximum table width
+func (t *Table) fitRow(row []string) []string {
+	fitted := make([]string, len(row))
+	for i, cell := range row {
+		if i < len(t.maxWidths) && t.maxWidths[i] > 0 {
+			cell = TruncateWidth(flattenWhitespace(cell), t.maxWidths[i])
+		}
+		fitted[i] = cell
+	}
+	return fitted
+}
+
+// flattenWhitespace collapses newlines and runs of spaces into single spaces
+func flattenWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
 }
 
 // TruncateString truncates a string to the specified length
@@ -83,10 +212,5 @@ func TruncateString(s string, maxLen int) string {
 
 // FormatMultilineString formats a multiline string for table display
 func FormatMultilineString(s string, maxLen int) string {
-	// Replace newlines with spaces
-	s = strings.ReplaceAll(s, "\n", " ")
-	s = strings.ReplaceAll(s, "\r", " ")
-	// Collapse multiple spaces
-	s = strings.Join(strings.Fields(s), " ")
-	return TruncateString(s, maxLen)
+	return TruncateString(flattenWhitespace(s), maxLen)
 }
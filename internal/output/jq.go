@@ -0,0 +1,111 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/itchyny/gojq"
+)
+
+// Query is a compiled jq expression applied to JSON output
+type Query struct {
+	expr string
+	code *gojq.Code
+}
+
+// ParseQuery compiles a jq expression.
+// Syntax errors report the position of the offending token.
+func ParseQuery(expr string) (*Query, error) {
+	parsed, err := gojq.Parse(expr)
+	if err != nil {
+		var parseErr *gojq.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("invalid jq expression: %v\n%s", err, pointAt(expr, parseErr.Offset-len(parseErr.Token)))
+		}
+		return nil, fmt.Errorf("invalid jq expression: %w", err)
+	}
+
+	code, err := gojq.Compile(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression: %w", err)
+	}
+
+	return &Query{expr: expr, code: code}, nil
+}
+
+// pointAt renders the expression with a caret under the given byte offset
+func pointAt(expr string, offset int) string {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(expr) {
+		offset = len(expr)
+	}
+
+	// Only show the line containing the error for multi-line expressions
+	lineStart := strings.LastIndex(expr[:offset], "\n") + 1
+	lineEnd := len(expr)
+	if i := strings.Index(expr[offset:], "\n"); i >= 0 {
+		lineEnd = offset + i
+	}
+
+	column := len([]rune(expr[lineStart:offset]))
+	return "  " + expr[lineStart:lineEnd] + "\n  " + strings.Repeat(" ", column) + "^"
+}
+
+// Run applies the query to data as it would be serialized to JSON and
+// returns every result the expression produces
+func (q *Query) Run(data interface{}) ([]interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var input interface{}
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return nil, err
+	}
+
+	var results []interface{}
+	iter := q.code.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			var haltErr *gojq.HaltError
+			if errors.As(err, &haltErr) && haltErr.Value() == nil {
+				break
+			}
+			return nil, fmt.Errorf("jq: %w", err)
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+// WriteQuery writes the results of the query to w, one per line.
+// Strings are written without quotes, like `jq -r`; other values as indented JSON.
+func WriteQuery(w io.Writer, q *Query, data interface{}) error {
+	results, err := q.Run(data)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if s, ok := result.(string); ok {
+			if _, err := fmt.Fprintln(w, s); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := WriteJSON(w, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
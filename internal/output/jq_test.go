@@ -0,0 +1,131 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type jqState struct {
+	Name string `json:"name"`
+}
+
+type jqIssue struct {
+	Identifier string   `json:"identifier"`
+	Priority   int      `json:"priority"`
+	State      *jqState `json:"state"`
+}
+
+var jqIssues = []jqIssue{
+	{Identifier: "ENG-1", Priority: 1, State: &jqState{Name: "Todo"}},
+	{Identifier: "ENG-2", Priority: 3, State: &jqState{Name: "Done"}},
+	{Identifier: "ENG-3", Priority: 2},
+}
+
+func runQuery(t *testing.T, expr string, data interface{}) string {
+	t.Helper()
+
+	q, err := ParseQuery(expr)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q) error = %v", expr, err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteQuery(&buf, q, data); err != nil {
+		t.Fatalf("WriteQuery(%q) error = %v", expr, err)
+	}
+	return buf.String()
+}
+
+func TestWriteQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{
+			name: "path selection prints raw strings",
+			expr: ".[0].state.name",
+			want: "Todo\n",
+		},
+		{
+			name: "iterate",
+			expr: ".[].identifier",
+			want: "ENG-1\nENG-2\nENG-3\n",
+		},
+		{
+			name: "select",
+			expr: ".[] | select(.priority <= 2) | .identifier",
+			want: "ENG-1\nENG-3\n",
+		},
+		{
+			name: "map",
+			expr: "map(.priority)",
+			want: "[\n  1,\n  3,\n  2\n]\n",
+		},
+		{
+			name: "object construction",
+			expr: ".[1] | {id: .identifier, state: .state.name}",
+			want: "{\n  \"id\": \"ENG-2\",\n  \"state\": \"Done\"\n}\n",
+		},
+		{
+			name: "null values",
+			expr: ".[2].state",
+			want: "null\n",
+		},
+		{
+			name: "length",
+			expr: "length",
+			want: "3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runQuery(t, tt.expr, jqIssues); got != tt.want {
+				t.Errorf("WriteQuery(%q) = %q, want %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuery_SyntaxErrorShowsPosition(t *testing.T) {
+	_, err := ParseQuery(".[] | select(.priority <= ) | .id")
+	if err == nil {
+		t.Fatal("expected syntax error")
+	}
+
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "invalid jq expression: unexpected token \")\"") {
+		t.Errorf("unexpected error message: %q", msg)
+	}
+	want := "  .[] | select(.priority <= ) | .id\n" + strings.Repeat(" ", 28) + "^"
+	if !strings.HasSuffix(msg, want) {
+		t.Errorf("error should point at the offending token, got:\n%s", msg)
+	}
+}
+
+func TestParseQuery_UndefinedFunction(t *testing.T) {
+	_, err := ParseQuery("nosuchfunc")
+	if err == nil || !strings.Contains(err.Error(), "nosuchfunc") {
+		t.Errorf("expected undefined function error, got %v", err)
+	}
+}
+
+func TestQuery_RuntimeError(t *testing.T) {
+	q, err := ParseQuery(".[0].identifier[]")
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+
+	_, err = q.Run(jqIssues)
+	if err == nil || !strings.HasPrefix(err.Error(), "jq: ") {
+		t.Errorf("expected runtime error, got %v", err)
+	}
+}
+
+func TestQuery_Empty(t *testing.T) {
+	if got := runQuery(t, "empty", jqIssues); got != "" {
+		t.Errorf("expected no output, got %q", got)
+	}
+}
@@ -0,0 +1,695 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MarkdownOptions controls how Markdown is rendered for the terminal
+type MarkdownOptions struct {
+	// Width wraps paragraphs and lists to this many cells (0 disables wrapping)
+	Width int
+	// Color enables ANSI styling
+	Color bool
+	// Hyperlinks renders links as OSC 8 terminal hyperlinks instead of footnotes
+	Hyperlinks bool
+}
+
+// TerminalMarkdownOptions returns options suited to stdout: wrapped to the
+// terminal width, styled when colors are enabled, and using hyperlinks when
+// the terminal is known to support them
+func TerminalMarkdownOptions() MarkdownOptions {
+	return MarkdownOptions{
+		Width:      TerminalWidth(),
+		Color:      ColorEnabled(),
+		Hyperlinks: ColorEnabled() && HyperlinksSupported(),
+	}
+}
+
+// HyperlinksSupported reports whether the terminal is known to render OSC 8
+// hyperlinks. FORCE_HYPERLINK=1 or 0 overrides detection.
+func HyperlinksSupported() bool {
+	if force := os.Getenv("FORCE_HYPERLINK"); force != "" {
+		return force != "0"
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty":
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" {
+		return true
+	}
+	if vte, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && vte >= 5000 {
+		return true
+	}
+	return false
+}
+
+// SGR parameters for Markdown styles
+const (
+	styleBold      = "1"
+	styleItalic    = "3"
+	styleUnderline = "4"
+	styleStrike    = "9"
+	styleCode      = "36"
+	styleLink      = "4;34"
+	styleMention   = "1;35"
+	styleIssueRef  = "1;34"
+	styleDim       = "2"
+	styleDone      = "32"
+)
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	rulePattern     = regexp.MustCompile(`^([-*_])(\s*[-*_]){2,}$`)
+	listPattern     = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	fencePattern    = regexp.MustCompile("^\\s*(```+|~~~+)")
+	tableSepPattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	issueRefPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{0,9}-\d+`)
+	mentionPattern  = regexp.MustCompile(`^@[\w.\-]+`)
+	linearIssueURL  = regexp.MustCompile(`^https://linear\.app/[^/]+/issue/([A-Z][A-Z0-9]{0,9}-\d+)(/[^\s)]*)?`)
+)
+
+// RenderMarkdown renders Markdown source as styled, wrapped terminal text.
+// It understands headings, emphasis, lists and checklists, code blocks,
+// block quotes, pipe tables, links, and Linear @mentions and issue references.
+func RenderMarkdown(src string, opts MarkdownOptions) string {
+	links := []string{}
+	r := &markdownRenderer{opts: opts, links: &links}
+	r.renderBlocks(splitMarkdownLines(src))
+
+	out := strings.TrimRight(r.out.String(), "\n")
+	if len(links) > 0 {
+		out += "\n"
+		for i, link := range links {
+			out += "\n" + r.style(styleDim, fmt.Sprintf("[%d]: %s", i+1, link))
+		}
+	}
+	return out + "\n"
+}
+
+func splitMarkdownLines(src string) []string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	return strings.Split(src, "\n")
+}
+
+type markdownRenderer struct {
+	opts  MarkdownOptions
+	out   strings.Builder
+	links *[]string
+}
+
+func (r *markdownRenderer) style(sgr, s string) string {
+	if !r.opts.Color || s == "" || sgr == "" {
+		return s
+	}
+	return "\x1b[" + sgr + "m" + s + ansiReset
+}
+
+// blankLine ends the current block, avoiding runs of empty lines
+func (r *markdownRenderer) blankLine() {
+	s := r.out.String()
+	if s == "" || strings.HasSuffix(s, "\n\n") {
+		return
+	}
+	r.out.WriteString("\n")
+}
+
+func (r *markdownRenderer) writeLine(s string) {
+	r.out.WriteString(s)
+	r.out.WriteString("\n")
+}
+
+func (r *markdownRenderer) renderBlocks(lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			r.blankLine()
+			i++
+
+		case fencePattern.MatchString(line):
+			i = r.renderFencedCode(lines, i)
+
+		case headingPattern.MatchString(trimmed):
+			r.renderHeading(trimmed)
+			i++
+
+		case rulePattern.MatchString(trimmed):
+			r.blankLine()
+			r.writeLine(r.style(styleDim, strings.Repeat("─", r.ruleWidth())))
+			r.blankLine()
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			i = r.renderQuote(lines, i)
+
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && tableSepPattern.MatchString(lines[i+1]):
+			i = r.renderTable(lines, i)
+
+		case listPattern.MatchString(line):
+			i = r.renderListItem(lines, i)
+
+		case strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t"):
+			i = r.renderIndentedCode(lines, i)
+
+		default:
+			i = r.renderParagraph(lines, i)
+		}
+	}
+}
+
+func (r *markdownRenderer) ruleWidth() int {
+	if r.opts.Width > 0 {
+		return min(r.opts.Width, 80)
+	}
+	return 40
+}
+
+func (r *markdownRenderer) renderHeading(line string) {
+	m := headingPattern.FindStringSubmatch(line)
+	level := len(m[1])
+	text := r.renderInline(m[2])
+
+	r.blankLine()
+	if r.opts.Color {
+		sgr := styleBold
+		if level == 1 {
+			sgr = styleBold + ";" + styleUnderline
+		}
+		r.writeLine(r.style(sgr, StripANSI(text)))
+	} else {
+		r.writeLine(text)
+		switch level {
+		case 1:
+			r.writeLine(strings.Repeat("=", DisplayWidth(text)))
+		case 2:
+			r.writeLine(strings.Repeat("-", DisplayWidth(text)))
+		}
+	}
+	r.blankLine()
+}
+
+func (r *markdownRenderer) renderFencedCode(lines []string, start int) int {
+	fence := strings.TrimSpace(fencePattern.FindStringSubmatch(lines[start])[1])
+	indent := len(lines[start]) - len(strings.TrimLeft(lines[start], " "))
+
+	r.blankLine()
+	i := start + 1
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence[:3]) && strings.Trim(trimmed, fence[:1]) == "" && len(trimmed) >= len(fence) {
+			i++
+			break
+		}
+		code := lines[i]
+		// Remove the fence's own indentation from content lines
+		for j := 0; j < indent && strings.HasPrefix(code, " "); j++ {
+			code = code[1:]
+		}
+		r.writeLine("    " + r.style(styleCode, code))
+	}
+	r.blankLine()
+	return i
+}
+
+func (r *markdownRenderer) renderIndentedCode(lines []string, start int) int {
+	r.blankLine()
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			// Blank lines inside indented code continue the block
+			if i+1 < len(lines) && (strings.HasPrefix(lines[i+1], "    ") || strings.HasPrefix(lines[i+1], "\t")) {
+				r.writeLine("")
+				continue
+			}
+			break
+		}
+		if !strings.HasPrefix(line, "    ") && !strings.HasPrefix(line, "\t") {
+			break
+		}
+		code := strings.TrimPrefix(strings.TrimPrefix(line, "\t"), "    ")
+		r.writeLine("    " + r.style(styleCode, code))
+	}
+	r.blankLine()
+	return i
+}
+
+func (r *markdownRenderer) renderQuote(lines []string, start int) int {
+	var quoted []string
+	i := start
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, ">") {
+			break
+		}
+		trimmed = strings.TrimPrefix(trimmed, ">")
+		quoted = append(quoted, strings.TrimPrefix(trimmed, " "))
+	}
+
+	width := r.opts.Width
+	if width > 0 {
+		width = max(width-2, 10)
+	}
+	inner := &markdownRenderer{opts: r.opts, links: r.links}
+	inner.opts.Width = width
+	inner.renderBlocks(quoted)
+
+	r.blankLine()
+	bar := r.style(styleDim, "│") + " "
+	for _, line := range strings.Split(strings.TrimRight(inner.out.String(), "\n"), "\n") {
+		r.writeLine(strings.TrimRight(bar+line, " "))
+	}
+	r.blankLine()
+	return i
+}
+
+func (r *markdownRenderer) renderTable(lines []string, start int) int {
+	headers := splitTableRow(lines[start])
+	for i := range headers {
+		headers[i] = r.renderInline(headers[i])
+	}
+	table := NewTable(headers)
+
+	i := start + 2
+	for ; i < len(lines); i++ {
+		if !strings.HasPrefix(strings.TrimSpace(lines[i]), "|") {
+			break
+		}
+		cells := splitTableRow(lines[i])
+		row := make([]string, len(headers))
+		for j := range row {
+			if j < len(cells) {
+				row[j] = r.renderInline(cells[j])
+			}
+		}
+		table.AddRow(row)
+	}
+
+	var b strings.Builder
+	table.SetWidth(r.opts.Width)
+	table.PrintTo(&b)
+
+	r.blankLine()
+	r.out.WriteString(b.String())
+	r.blankLine()
+	return i
+}
+
+// splitTableRow splits a pipe table row into trimmed cells, honoring \| escapes
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && line[i+1] == '|' {
+			cell.WriteByte('|')
+			i++
+			continue
+		}
+		if line[i] == '|' {
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+			continue
+		}
+		cell.WriteByte(line[i])
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func (r *markdownRenderer) renderListItem(lines []string, start int) int {
+	m := listPattern.FindStringSubmatch(lines[start])
+	indent := strings.ReplaceAll(m[1], "\t", "    ")
+	level := len(indent) / 2
+	marker := m[2]
+	text := m[3]
+
+	// Lazy continuation lines belong to the item until a blank line or new block
+	i := start + 1
+	for ; i < len(lines); i++ {
+		next := lines[i]
+		trimmed := strings.TrimSpace(next)
+		if trimmed == "" || listPattern.MatchString(next) || startsBlock(trimmed) {
+			break
+		}
+		text += "\n" + trimmed
+	}
+
+	var bullet string
+	switch {
+	case strings.HasPrefix(text, "[ ] "):
+		bullet = "☐"
+		text = text[4:]
+	case strings.HasPrefix(text, "[x] "), strings.HasPrefix(text, "[X] "):
+		bullet = r.style(styleDone, "☑")
+		text = text[4:]
+	case marker[0] >= '0' && marker[0] <= '9':
+		bullet = marker
+	default:
+		bullet = []string{"•", "◦", "▪"}[min(level, 2)]
+	}
+
+	prefix := strings.Repeat("  ", level) + bullet + " "
+	hanging := strings.Repeat(" ", DisplayWidth(prefix))
+	r.writeWrapped(text, prefix, hanging)
+	return i
+}
+
+func (r *markdownRenderer) renderParagraph(lines []string, start int) int {
+	text := strings.TrimSpace(lines[start])
+	i := start + 1
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || startsBlock(trimmed) || listPattern.MatchString(lines[i]) {
+			break
+		}
+		// Two trailing spaces or a backslash mark a hard line break
+		if strings.HasSuffix(lines[i-1], "  ") || strings.HasSuffix(lines[i-1], "\\") {
+			text = strings.TrimSuffix(text, "\\") + "\n" + trimmed
+		} else {
+			text += " " + trimmed
+		}
+	}
+
+	r.writeWrapped(text, "", "")
+	return i
+}
+
+// startsBlock reports whether a trimmed line opens a non-paragraph block
+func startsBlock(trimmed string) bool {
+	return headingPattern.MatchString(trimmed) ||
+		rulePattern.MatchString(trimmed) ||
+		strings.HasPrefix(trimmed, ">") ||
+		strings.HasPrefix(trimmed, "```") ||
+		strings.HasPrefix(trimmed, "~~~")
+}
+
+// writeWrapped renders inline Markdown and wraps it to the configured width.
+// Explicit newlines in text become hard line breaks.
+func (r *markdownRenderer) writeWrapped(text, firstPrefix, prefix string) {
+	width := r.opts.Width
+	current := firstPrefix
+
+	for n, segment := range strings.Split(text, "\n") {
+		if n > 0 {
+			r.writeLine(strings.TrimRight(current, " "))
+			current = prefix
+		}
+
+		lineStart := true
+		for _, word := range r.renderWords(segment) {
+			if lineStart {
+				current += word
+				lineStart = false
+				continue
+			}
+			if width > 0 && DisplayWidth(current)+1+DisplayWidth(word) > width {
+				r.writeLine(current)
+				current = prefix + word
+				continue
+			}
+			current += " " + word
+		}
+	}
+	r.writeLine(strings.TrimRight(current, " "))
+}
+
+// span is a run of inline text sharing a style and link target
+type span struct {
+	text  string
+	style string
+	link  string
+}
+
+// renderInline renders inline Markdown on a single line without wrapping
+func (r *markdownRenderer) renderInline(text string) string {
+	return strings.Join(r.renderWords(text), " ")
+}
+
+// renderWords renders inline Markdown into styled words. Styles are applied
+// per word so wrapped lines never carry escape sequences across line breaks.
+func (r *markdownRenderer) renderWords(text string) []string {
+	spans := r.parseInline(text, "")
+
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, sp := range spans {
+		parts := strings.Split(sp.text, " ")
+		for i, part := range parts {
+			if i > 0 {
+				flush()
+			}
+			if part == "" {
+				continue
+			}
+			rendered := r.style(sp.style, part)
+			if sp.link != "" && r.opts.Hyperlinks {
+				rendered = "\x1b]8;;" + sp.link + "\x1b\\" + rendered + "\x1b]8;;\x1b\\"
+			}
+			word.WriteString(rendered)
+		}
+	}
+	flush()
+	return words
+}
+
+// parseInline splits text into styled spans, handling emphasis, code,
+// links, autolinks, @mentions and issue identifiers
+func (r *markdownRenderer) parseInline(text, style string) []span {
+	var spans []span
+	var plain strings.Builder
+
+	emit := func(s span) {
+		if plain.Len() > 0 {
+			spans = append(spans, span{text: plain.String(), style: style})
+			plain.Reset()
+		}
+		spans = append(spans, s)
+	}
+	emitAll := func(s []span) {
+		if plain.Len() > 0 {
+			spans = append(spans, span{text: plain.String(), style: style})
+			plain.Reset()
+		}
+		spans = append(spans, s...)
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		atWordStart := i == 0 || !isWordChar(rune(text[i-1]))
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\`*_{}[]()#+-.!|~<>@", rune(rest[1])):
+			plain.WriteByte(rest[1])
+			i += 2
+			continue
+
+		case rest[0] == '`':
+			ticks := len(rest) - len(strings.TrimLeft(rest, "`"))
+			closing := strings.Index(rest[ticks:], strings.Repeat("`", ticks))
+			if closing >= 0 {
+				code := strings.TrimSpace(rest[ticks : ticks+closing])
+				if !r.opts.Color {
+					code = "`" + code + "`"
+				}
+				emit(span{text: code, style: joinStyle(style, styleCode)})
+				i += ticks*2 + closing
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if inner, n, ok := delimited(rest, rest[:2], rest[0] == '_' && !atWordStart); ok {
+				emitAll(r.parseInline(inner, joinStyle(style, styleBold)))
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "~~"):
+			if inner, n, ok := delimited(rest, "~~", false); ok {
+				emitAll(r.parseInline(inner, joinStyle(style, styleStrike)))
+				i += n
+				continue
+			}
+
+		case rest[0] == '*' || rest[0] == '_':
+			if inner, n, ok := delimited(rest, rest[:1], rest[0] == '_' && !atWordStart); ok {
+				emitAll(r.parseInline(inner, joinStyle(style, styleItalic)))
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "!["):
+			if label, url, n, ok := parseLink(rest[1:]); ok {
+				emitAll(r.linkSpans("image: "+label, url, style))
+				i += n + 1
+				continue
+			}
+
+		case rest[0] == '[':
+			if label, url, n, ok := parseLink(rest); ok {
+				emitAll(r.linkSpans(label, url, style))
+				i += n
+				continue
+			}
+
+		case rest[0] == '<':
+			if end := strings.IndexByte(rest, '>'); end > 0 && isURL(rest[1:end]) {
+				emitAll(r.linkSpans(rest[1:end], rest[1:end], style))
+				i += end + 1
+				continue
+			}
+
+		case atWordStart && isURL(rest):
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			url := strings.TrimRight(rest[:end], ".,;:!?)")
+			emitAll(r.linkSpans(url, url, style))
+			i += len(url)
+			continue
+
+		case atWordStart && rest[0] == '@':
+			if m := mentionPattern.FindString(rest); m != "" {
+				emit(span{text: m, style: joinStyle(style, styleMention)})
+				i += len(m)
+				continue
+			}
+
+		case atWordStart && rest[0] >= 'A' && rest[0] <= 'Z':
+			if m := issueRefPattern.FindString(rest); m != "" && (len(m) == len(rest) || !isWordChar(rune(rest[len(m)]))) {
+				emit(span{text: m, style: joinStyle(style, styleIssueRef)})
+				i += len(m)
+				continue
+			}
+		}
+
+		plain.WriteByte(text[i])
+		i++
+	}
+
+	if plain.Len() > 0 {
+		spans = append(spans, span{text: plain.String(), style: style})
+	}
+	return spans
+}
+
+// linkSpans renders a link. Linear issue links and bare URLs are shown
+// inline; other links become footnotes unless hyperlinks are enabled.
+func (r *markdownRenderer) linkSpans(label, url, style string) []span {
+	if m := linearIssueURL.FindStringSubmatch(url); m != nil && (label == url || label == m[1]) {
+		return []span{{text: m[1], style: joinStyle(style, styleIssueRef), link: url}}
+	}
+	if strings.HasPrefix(label, "@") {
+		return []span{{text: label, style: joinStyle(style, styleMention), link: url}}
+	}
+
+	spans := r.parseInline(label, joinStyle(style, styleLink))
+	for i := range spans {
+		spans[i].link = url
+	}
+
+	if r.opts.Hyperlinks || label == url {
+		return spans
+	}
+
+	*r.links = append(*r.links, url)
+	return append(spans, span{text: fmt.Sprintf("[%d]", len(*r.links)), style: joinStyle(style, styleDim)})
+}
+
+// delimited finds the closing delimiter for emphasis starting at s.
+// It returns the inner text and the number of bytes consumed.
+func delimited(s, delim string, disallow bool) (string, int, bool) {
+	if disallow || len(s) <= len(delim) || s[len(delim)] == ' ' {
+		return "", 0, false
+	}
+
+	search := s[len(delim):]
+	for offset := 0; ; {
+		idx := strings.Index(search[offset:], delim)
+		if idx < 0 {
+			return "", 0, false
+		}
+		end := offset + idx
+		if end > 0 && search[end-1] != ' ' {
+			after := len(delim) + end + len(delim)
+			// Underscores inside words (snake_case) are not emphasis
+			if delim[0] != '_' || after >= len(s) || !isWordChar(rune(s[after])) {
+				return search[:end], after, true
+			}
+		}
+		offset = end + len(delim)
+	}
+}
+
+// parseLink parses "[label](url)" at the start of s
+func parseLink(s string) (string, string, int, bool) {
+	depth := 0
+	closeLabel := -1
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeLabel = i
+			}
+		}
+		if closeLabel >= 0 {
+			break
+		}
+	}
+	if closeLabel < 0 || closeLabel+1 >= len(s) || s[closeLabel+1] != '(' {
+		return "", "", 0, false
+	}
+
+	closeURL := strings.IndexByte(s[closeLabel+2:], ')')
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+
+	target := strings.TrimSpace(s[closeLabel+2 : closeLabel+2+closeURL])
+	// Drop an optional title: [label](url "title")
+	if space := strings.IndexByte(target, ' '); space >= 0 {
+		target = target[:space]
+	}
+	return s[1:closeLabel], strings.Trim(target, "<>"), closeLabel + 3 + closeURL, true
+}
+
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
+
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func joinStyle(base, add string) string {
+	if base == "" {
+		return add
+	}
+	return base + ";" + add
+}
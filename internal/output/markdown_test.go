@@ -0,0 +1,182 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func renderPlain(src string, width int) string {
+	return RenderMarkdown(src, MarkdownOptions{Width: width})
+}
+
+func TestRenderMarkdown_Blocks(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		width int
+		want  string
+	}{
+		{
+			name: "headings",
+			src:  "# Title\n## Section\n### Detail",
+			want: "Title\n=====\n\nSection\n-------\n\nDetail\n",
+		},
+		{
+			name: "emphasis markers are removed",
+			src:  "Some **bold**, _italic_, ~~gone~~ and *em* text",
+			want: "Some bold, italic, gone and em text\n",
+		},
+		{
+			name: "snake_case is not emphasis",
+			src:  "call some_function_name now",
+			want: "call some_function_name now\n",
+		},
+		{
+			name: "code spans keep backticks without color",
+			src:  "Run `make test` first",
+			want: "Run `make test` first\n",
+		},
+		{
+			name: "bullets and nesting",
+			src:  "- one\n- two\n  - nested\n    - deeper",
+			want: "• one\n• two\n  ◦ nested\n    ▪ deeper\n",
+		},
+		{
+			name: "ordered list",
+			src:  "1. first\n2. second",
+			want: "1. first\n2. second\n",
+		},
+		{
+			name: "checklist",
+			src:  "- [ ] todo\n- [x] done",
+			want: "☐ todo\n☑ done\n",
+		},
+		{
+			name:  "fenced code is indented and not wrapped",
+			src:   "```\nline one is long enough to exceed width\n  indented\n```",
+			width: 10,
+			want:  "    line one is long enough to exceed width\n      indented\n",
+		},
+		{
+			name: "block quote",
+			src:  "> quoted\n> text",
+			want: "│ quoted text\n",
+		},
+		{
+			name:  "horizontal rule",
+			src:   "above\n\n---\n\nbelow",
+			width: 10,
+			want:  "above\n\n──────────\n\nbelow\n",
+		},
+		{
+			name: "hard line break",
+			src:  "first line  \nsecond line",
+			want: "first line\nsecond line\n",
+		},
+		{
+			name: "paragraphs",
+			src:  "one\ntwo\n\n\n\nthree",
+			want: "one two\n\nthree\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderPlain(tt.src, tt.width)
+			if got != tt.want {
+				t.Errorf("RenderMarkdown() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdown_Wrapping(t *testing.T) {
+	src := "The quick brown fox jumps over the lazy dog and keeps running.\n\n- a list item that is long enough to need wrapping"
+	got := renderPlain(src, 20)
+
+	want := "The quick brown fox\njumps over the lazy\ndog and keeps\nrunning.\n\n• a list item that\n  is long enough to\n  need wrapping\n"
+	if got != want {
+		t.Errorf("RenderMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+
+	for _, line := range strings.Split(got, "\n") {
+		if DisplayWidth(line) > 20 {
+			t.Errorf("line %q exceeds wrap width", line)
+		}
+	}
+}
+
+func TestRenderMarkdown_LinksAsFootnotes(t *testing.T) {
+	src := "See [the runbook](https://example.com/runbook) and [docs](https://example.com/docs \"Docs\")."
+	got := renderPlain(src, 0)
+
+	want := "See the runbook[1] and docs[2].\n\n[1]: https://example.com/runbook\n[2]: https://example.com/docs\n"
+	if got != want {
+		t.Errorf("RenderMarkdown() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRenderMarkdown_Hyperlinks(t *testing.T) {
+	got := RenderMarkdown("[docs](https://example.com)", MarkdownOptions{Hyperlinks: true})
+
+	want := "\x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\\n"
+	if got != want {
+		t.Errorf("RenderMarkdown() = %q, want %q", got, want)
+	}
+	if StripANSI(got) != "docs\n" {
+		t.Errorf("hyperlink should be invisible apart from its text, got %q", StripANSI(got))
+	}
+}
+
+func TestRenderMarkdown_LinearReferences(t *testing.T) {
+	src := "Blocked by ENG-42 and https://linear.app/acme/issue/APP-7/fix-it, cc @jane.doe [@Sam](https://linear.app/acme/profiles/sam)"
+
+	plain := renderPlain(src, 0)
+	if plain != "Blocked by ENG-42 and APP-7, cc @jane.doe @Sam\n" {
+		t.Errorf("RenderMarkdown() = %q", plain)
+	}
+
+	styled := RenderMarkdown(src, MarkdownOptions{Color: true})
+	for _, want := range []string{
+		"\x1b[" + styleIssueRef + "mENG-42\x1b[0m",
+		"\x1b[" + styleIssueRef + "mAPP-7\x1b[0m",
+		"\x1b[" + styleMention + "m@jane.doe\x1b[0m",
+		"\x1b[" + styleMention + "m@Sam\x1b[0m",
+	} {
+		if !strings.Contains(styled, want) {
+			t.Errorf("expected %q in styled output %q", want, styled)
+		}
+	}
+}
+
+func TestRenderMarkdown_Table(t *testing.T) {
+	src := "| Env | Status |\n| --- | :---: |\n| prod | broken \\| bad |\n| staging | **ok** |"
+	got := renderPlain(src, 0)
+
+	want := "Env      Status\n---      ------\nprod     broken | bad\nstaging  ok\n"
+	if got != want {
+		t.Errorf("RenderMarkdown() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRenderMarkdown_ColorStylesEachWord(t *testing.T) {
+	got := RenderMarkdown("**two words**", MarkdownOptions{Color: true, Width: 5})
+
+	want := "\x1b[1mtwo\x1b[0m\n\x1b[1mwords\x1b[0m\n"
+	if got != want {
+		t.Errorf("RenderMarkdown() = %q, want %q", got, want)
+	}
+}
+
+func TestHyperlinksSupported_Override(t *testing.T) {
+	t.Setenv("FORCE_HYPERLINK", "1")
+	if !HyperlinksSupported() {
+		t.Error("FORCE_HYPERLINK=1 should enable hyperlinks")
+	}
+
+	t.Setenv("FORCE_HYPERLINK", "0")
+	t.Setenv("TERM_PROGRAM", "iTerm.app")
+	if HyperlinksSupported() {
+		t.Error("FORCE_HYPERLINK=0 should disable hyperlinks")
+	}
+}
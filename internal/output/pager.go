@@ -0,0 +1,116 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// defaultPager is used when neither LINEAR_PAGER nor PAGER is set.
+// -F exits immediately when output fits on screen, -R passes colors
+// through and -X leaves the output on screen after quitting.
+const defaultPager = "less -FRX"
+
+// Stdout is the writer commands print their output to.
+// It is replaced by a buffer while the pager is active.
+var Stdout io.Writer = os.Stdout
+
+var pagerBuffer *bytes.Buffer
+
+// PagerCommand returns the pager command line from LINEAR_PAGER or PAGER,
+// falling back to less. It returns nil when paging is disabled by setting
+// the variable to an empty string or "cat".
+func PagerCommand() []string {
+	pager, ok := os.LookupEnv("LINEAR_PAGER")
+	if !ok {
+		pager, ok = os.LookupEnv("PAGER")
+	}
+	if !ok {
+		pager = defaultPager
+	}
+
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		return nil
+	}
+	return args
+}
+
+// StartPager buffers everything written to Stdout until StopPager, which
+// sends it through the pager if it is taller than the terminal.
+// It does nothing when stdout is not a terminal or paging is disabled.
+func StartPager() {
+	if pagerBuffer != nil || PagerCommand() == nil || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return
+	}
+	pagerBuffer = &bytes.Buffer{}
+	Stdout = pagerBuffer
+}
+
+// StopPager flushes output buffered since StartPager, through the pager
+// when it does not fit on the screen
+func StopPager() error {
+	if pagerBuffer == nil {
+		return nil
+	}
+	buffered := pagerBuffer.Bytes()
+	pagerBuffer = nil
+	Stdout = os.Stdout
+
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || OutputHeight(string(buffered), width) < height {
+		_, err := os.Stdout.Write(buffered)
+		return err
+	}
+	return runPager(PagerCommand(), buffered)
+}
+
+// runPager pipes content through the pager command, falling back to
+// writing it directly when the pager cannot be started
+func runPager(args []string, content []byte) error {
+	pager := exec.Command(args[0], args[1:]...)
+	pager.Stdin = bytes.NewReader(content)
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+
+	if err := pager.Start(); err != nil {
+		_, writeErr := os.Stdout.Write(content)
+		return writeErr
+	}
+	// Ctrl-C belongs to the pager, which shares the terminal; dying on it
+	// would leave the pager running with the terminal in its mode. It is
+	// ignored only once the pager has started so the pager does not
+	// inherit that.
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+	if err := pager.Wait(); err != nil {
+		return fmt.Errorf("pager: %w", err)
+	}
+	return nil
+}
+
+// OutputHeight returns the number of terminal rows s occupies when long
+// lines wrap at width. A width of 0 disables wrapping.
+func OutputHeight(s string, width int) int {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return 0
+	}
+
+	height := 0
+	for _, line := range strings.Split(s, "\n") {
+		w := DisplayWidth(line)
+		if width <= 0 || w <= width {
+			height++
+			continue
+		}
+		height += (w + width - 1) / width
+	}
+	return height
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"os"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestPagerCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		linearPager *string
+		pager       *string
+		want        []string
+	}{
+		{name: "default", want: []string{"less", "-FRX"}},
+		{name: "PAGER", pager: ptr("more -s"), want: []string{"more", "-s"}},
+		{name: "LINEAR_PAGER wins", linearPager: ptr("bat --paging=always"), pager: ptr("more"), want: []string{"bat", "--paging=always"}},
+		{name: "empty disables", pager: ptr(""), want: nil},
+		{name: "cat disables", linearPager: ptr("cat"), pager: ptr("less"), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setOrUnsetEnv(t, "LINEAR_PAGER", tt.linearPager)
+			setOrUnsetEnv(t, "PAGER", tt.pager)
+
+			if got := PagerCommand(); !slices.Equal(got, tt.want) {
+				t.Errorf("PagerCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputHeight(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  int
+	}{
+		{name: "empty", s: "", width: 80, want: 0},
+		{name: "trailing newline", s: "a\nb\n", width: 80, want: 2},
+		{name: "blank lines count", s: "a\n\nb", width: 80, want: 3},
+		{name: "long lines wrap", s: "0123456789abcdef\nx", width: 10, want: 3},
+		{name: "exact width does not wrap", s: "0123456789", width: 10, want: 1},
+		{name: "ANSI escapes ignored", s: "\x1b[31m0123456789\x1b[0m", width: 10, want: 1},
+		{name: "wide characters", s: "日本語日本語", width: 10, want: 2},
+		{name: "no width", s: "0123456789abcdef", width: 0, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OutputHeight(tt.s, tt.width); got != tt.want {
+				t.Errorf("OutputHeight() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartPager_NotATerminal(t *testing.T) {
+	t.Setenv("PAGER", "less")
+
+	StartPager()
+	defer StopPager()
+
+	if Stdout != os.Stdout {
+		t.Error("output should not be buffered when stdout is not a terminal")
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+// setOrUnsetEnv sets key for the duration of the test, or unsets it when
+// value is nil
+func setOrUnsetEnv(t *testing.T, key string, value *string) {
+	t.Helper()
+	t.Setenv(key, "")
+	if value == nil {
+		os.Unsetenv(key)
+		return
+	}
+	os.Setenv(key, *value)
+}
+
+func TestRunPager_IgnoresInterrupt(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	// Ctrl-C in the terminal reaches both processes; the pager handles it
+	if err := runPager([]string{"sh", "-c", "sleep 0.2 && kill -INT $PPID && cat >/dev/null"}, []byte("page\n")); err != nil {
+		t.Fatalf("runPager() error = %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// templateFuncs are the helper functions available to output templates
+var templateFuncs = template.FuncMap{
+	"truncate": func(maxLen int, s string) string { return TruncateString(s, maxLen) },
+	"timeago":  timeAgo,
+	"join":     joinValues,
+	"color":    Colorize,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"pad":      padString,
+	"json":     toJSON,
+}
+
+// now is overridden in tests
+var now = time.Now
+
+// ParseTemplate compiles a Go template with the output helper functions
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// RenderTemplate executes tmpl against data and writes the result to w.
+// Slices are rendered one element at a time, each on its own line.
+func RenderTemplate(w io.Writer, tmpl *template.Template, data interface{}) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return executeTemplateLine(w, tmpl, data)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		if err := executeTemplateLine(w, tmpl, v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func executeTemplateLine(w io.Writer, tmpl *template.Template, data interface{}) error {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	out := b.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// timeAgo renders a timestamp relative to now, e.g. "3 hours ago".
+// It accepts time.Time values and RFC 3339 strings (or pointers to them).
+func timeAgo(value interface{}) (string, error) {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return "", nil
+		}
+		t = *v
+	case string:
+		if v == "" {
+			return "", nil
+		}
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return "", fmt.Errorf("timeago: %w", err)
+		}
+		t = parsed
+	case *string:
+		if v == nil {
+			return "", nil
+		}
+		return timeAgo(*v)
+	default:
+		return "", fmt.Errorf("timeago: unsupported value of type %T", value)
+	}
+
+	return FormatTimeAgo(now().Sub(t)), nil
+}
+
+// FormatTimeAgo describes how long ago something happened, given the elapsed duration
+func FormatTimeAgo(d time.Duration) string {
+	if d < 0 {
+		return "just now"
+	}
+
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{365 * 24 * time.Hour, "year"},
+		{30 * 24 * time.Hour, "month"},
+		{7 * 24 * time.Hour, "week"},
+		{24 * time.Hour, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+	}
+
+	for _, unit := range units {
+		if d >= unit.size {
+			n := int(d / unit.size)
+			if n == 1 {
+				return "1 " + unit.name + " ago"
+			}
+			return fmt.Sprintf("%d %ss ago", n, unit.name)
+		}
+	}
+	return "just now"
+}
+
+// joinValues joins the elements of any slice with sep
+func joinValues(sep string, values interface{}) (string, error) {
+	if strs, ok := values.([]string); ok {
+		return strings.Join(strs, sep), nil
+	}
+
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expected a list, got %T", values)
+	}
+
+	parts := make([]string, v.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// padString pads s with spaces to width characters.
+// A negative width pads on the left to right-align the value.
+func padString(width int, s string) string {
+	alignRight := width < 0
+	if alignRight {
+		width = -width
+	}
+
+	n := width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		return s
+	}
+	if alignRight {
+		return strings.Repeat(" ", n) + s
+	}
+	return s + strings.Repeat(" ", n)
+}
+
+// toJSON renders a value as compact JSON
+func toJSON(value interface{}) (string, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
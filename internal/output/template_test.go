@@ -0,0 +1,142 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type templateIssue struct {
+	Identifier string
+	Title      string
+	URL        string
+	UpdatedAt  string
+	Labels     []string
+}
+
+func TestRenderTemplate_SliceRendersEachElement(t *testing.T) {
+	tmpl, err := ParseTemplate("{{.Identifier}}\t{{.URL}}")
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+
+	issues := []templateIssue{
+		{Identifier: "ENG-1", URL: "https://linear.app/t/issue/ENG-1"},
+		{Identifier: "ENG-2", URL: "https://linear.app/t/issue/ENG-2"},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderTemplate(&buf, tmpl, issues); err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+
+	want := "ENG-1\thttps://linear.app/t/issue/ENG-1\nENG-2\thttps://linear.app/t/issue/ENG-2\n"
+	if buf.String() != want {
+		t.Errorf("RenderTemplate() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRenderTemplate_SingleValue(t *testing.T) {
+	tmpl, err := ParseTemplate("{{.Identifier}}: {{.Title}}\n")
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderTemplate(&buf, tmpl, &templateIssue{Identifier: "ENG-1", Title: "Bug"}); err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+	if buf.String() != "ENG-1: Bug\n" {
+		t.Errorf("RenderTemplate() = %q", buf.String())
+	}
+}
+
+func TestRenderTemplate_Helpers(t *testing.T) {
+	originalNow := now
+	t.Cleanup(func() { now = originalNow })
+	now = func() time.Time { return time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC) }
+
+	originalColor := colorEnabled
+	t.Cleanup(func() { colorEnabled = originalColor })
+	colorEnabled = false
+
+	issue := templateIssue{
+		Identifier: "eng-1",
+		Title:      "A very long issue title",
+		UpdatedAt:  "2025-01-02T09:00:00Z",
+		Labels:     []string{"bug", "ui"},
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "truncate", text: "{{truncate 10 .Title}}", want: "A very ..."},
+		{name: "truncate pipeline", text: "{{.Title | truncate 6}}", want: "A v..."},
+		{name: "timeago", text: "{{timeago .UpdatedAt}}", want: "3 hours ago"},
+		{name: "join", text: "{{join \", \" .Labels}}", want: "bug, ui"},
+		{name: "upper", text: "{{upper .Identifier}}", want: "ENG-1"},
+		{name: "pad", text: "[{{pad 7 .Identifier}}]", want: "[eng-1  ]"},
+		{name: "pad right aligned", text: "[{{pad -7 .Identifier}}]", want: "[  eng-1]"},
+		{name: "json", text: "{{json .Labels}}", want: `["bug","ui"]`},
+		{name: "color disabled", text: "{{color \"red\" .Identifier}}", want: "eng-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.text)
+			if err != nil {
+				t.Fatalf("ParseTemplate() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := RenderTemplate(&buf, tmpl, issue); err != nil {
+				t.Fatalf("RenderTemplate() error = %v", err)
+			}
+			if got := strings.TrimSuffix(buf.String(), "\n"); got != tt.want {
+				t.Errorf("RenderTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTemplate_Invalid(t *testing.T) {
+	if _, err := ParseTemplate("{{.Identifier"); err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestRenderTemplate_ExecutionError(t *testing.T) {
+	tmpl, err := ParseTemplate("{{.Missing}}")
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderTemplate(&buf, tmpl, templateIssue{}); err == nil {
+		t.Error("expected execution error for missing field")
+	}
+}
+
+func TestFormatTimeAgo(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: -time.Minute, want: "just now"},
+		{d: 30 * time.Second, want: "just now"},
+		{d: time.Minute, want: "1 minute ago"},
+		{d: 90 * time.Minute, want: "1 hour ago"},
+		{d: 50 * time.Hour, want: "2 days ago"},
+		{d: 8 * 24 * time.Hour, want: "1 week ago"},
+		{d: 400 * 24 * time.Hour, want: "1 year ago"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatTimeAgo(tt.d); got != tt.want {
+			t.Errorf("FormatTimeAgo(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
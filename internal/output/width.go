@@ -0,0 +1,130 @@
+package output
+
+import (
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// TerminalWidth returns the width of the terminal attached to stdout,
+// or 0 when stdout is not a terminal
+func TerminalWidth() int {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// DisplayWidth returns the number of terminal cells needed to show s,
+// accounting for wide characters (CJK, emoji) and ignoring ANSI escapes
+func DisplayWidth(s string) int {
+	return runewidth.StringWidth(StripANSI(s))
+}
+
+// StripANSI removes ANSI escape sequences from s
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if n := ansiSequenceLen(s[i:]); n > 0 {
+			i += n - 1
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// ansiSequenceLen returns the length of the ANSI escape sequence at the
+// start of s, or 0 if s does not start with one
+func ansiSequenceLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+
+	switch s[1] {
+	case '[':
+		// CSI: parameters end with a byte in the range 0x40–0x7e
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		// OSC: terminated by BEL or ST (ESC \)
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	}
+	return 0
+}
+
+// TruncateWidth shortens s to at most width terminal cells, ending with
+// "..." when truncated. ANSI escape sequences are preserved and reset at
+// the cut so colors do not bleed into following text.
+func TruncateWidth(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if DisplayWidth(s) <= width {
+		return s
+	}
+
+	ellipsis := "..."
+	if width <= len(ellipsis) {
+		ellipsis = ""
+	}
+	limit := width - len(ellipsis)
+
+	var b strings.Builder
+	used := 0
+	styled := false
+	for i := 0; i < len(s); {
+		if n := ansiSequenceLen(s[i:]); n > 0 {
+			b.WriteString(s[i : i+n])
+			styled = true
+			i += n
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := runewidth.RuneWidth(r)
+		if used+w > limit {
+			break
+		}
+		b.WriteString(s[i : i+size])
+		used += w
+		i += size
+	}
+
+	if styled {
+		b.WriteString(ansiReset)
+	}
+	b.WriteString(ellipsis)
+	return b.String()
+}
+
+// padRight pads s with spaces to the given display width
+func padRight(s string, width int) string {
+	n := width - DisplayWidth(s)
+	if n <= 0 {
+		return s
+	}
+	return s + strings.Repeat(" ", n)
+}
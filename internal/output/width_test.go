@@ -0,0 +1,170 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{input: "hello", want: 5},
+		{input: "多言語", want: 6},
+		{input: "fix 🐛", want: 6},
+		{input: "\x1b[31mred\x1b[0m", want: 3},
+		{input: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.input); got != tt.want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "plain", want: "plain"},
+		{input: "\x1b[38;2;1;2;3mcolor\x1b[0m", want: "color"},
+		{input: "\x1b]8;;https://linear.app\x1b\\link\x1b]8;;\x1b\\", want: "link"},
+	}
+
+	for _, tt := range tests {
+		if got := StripANSI(tt.input); got != tt.want {
+			t.Errorf("StripANSI(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  string
+	}{
+		{name: "fits", input: "hello", width: 5, want: "hello"},
+		{name: "ascii", input: "hello world", width: 8, want: "hello..."},
+		{name: "wide characters", input: "多言語サポート", width: 9, want: "多言語..."},
+		{name: "wide character does not split", input: "多言語サポート", width: 8, want: "多言..."},
+		{name: "tiny width", input: "hello", width: 2, want: "he"},
+		{name: "zero width", input: "hello", width: 0, want: ""},
+		{name: "styled", input: "\x1b[31mhello world\x1b[0m", width: 8, want: "\x1b[31mhello\x1b[0m..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateWidth(tt.input, tt.width)
+			if got != tt.want {
+				t.Errorf("TruncateWidth(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+			}
+			if DisplayWidth(got) > tt.width {
+				t.Errorf("TruncateWidth(%q, %d) is %d cells wide", tt.input, tt.width, DisplayWidth(got))
+			}
+		})
+	}
+}
+
+func TestTable_AlignsWideCharacters(t *testing.T) {
+	table := NewTable([]string{"ID", "TITLE", "STATE"})
+	table.AddRow([]string{"ENG-1", "多言語サポート", "Todo"})
+	table.AddRow([]string{"ENG-2", "Fix 🐛 in login", "Done"})
+	table.AddRow([]string{"ENG-3", "plain", "Todo"})
+
+	var buf bytes.Buffer
+	table.PrintTo(&buf)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	stateColumn := -1
+	for _, line := range lines {
+		var idx int
+		if strings.HasPrefix(line, "ID") {
+			idx = DisplayWidth(line[:strings.Index(line, "STATE")])
+		} else if strings.HasPrefix(line, "--") {
+			continue
+		} else {
+			pos := strings.LastIndex(line, "  ")
+			idx = DisplayWidth(line[:pos+2])
+		}
+		if stateColumn == -1 {
+			stateColumn = idx
+		} else if idx != stateColumn {
+			t.Errorf("STATE column misaligned in %q: starts at %d, want %d\n%s", line, idx, stateColumn, buf.String())
+		}
+	}
+}
+
+func TestTable_StyledRowsOnlyWithColor(t *testing.T) {
+	original := colorEnabled
+	t.Cleanup(func() { colorEnabled = original })
+
+	table := NewTable([]string{"STATE", "ID"})
+	table.AddStyledRow([]string{"Todo", "ENG-1"}, []string{"\x1b[31mTodo\x1b[0m", "ENG-1"})
+	table.AddRow([]string{"In Progress", "ENG-2"})
+
+	colorEnabled = false
+	var plain bytes.Buffer
+	table.PrintTo(&plain)
+	if strings.Contains(plain.String(), "\x1b") {
+		t.Errorf("expected no ANSI codes without color, got %q", plain.String())
+	}
+
+	colorEnabled = true
+	var styled bytes.Buffer
+	table.PrintTo(&styled)
+	if !strings.Contains(styled.String(), "\x1b[31mTodo\x1b[0m") {
+		t.Errorf("expected styled cell with color, got %q", styled.String())
+	}
+
+	// Styled and plain output align identically once escapes are removed
+	if StripANSI(styled.String()) != plain.String() {
+		t.Errorf("styled output misaligned:\n%q\n%q", StripANSI(styled.String()), plain.String())
+	}
+
+	var csvBuf bytes.Buffer
+	if err := table.PrintCSV(&csvBuf); err != nil {
+		t.Fatalf("PrintCSV() error = %v", err)
+	}
+	if strings.Contains(csvBuf.String(), "\x1b") {
+		t.Errorf("CSV output should never contain colors, got %q", csvBuf.String())
+	}
+}
+
+func TestTable_SetWidthShrinksTruncatableColumns(t *testing.T) {
+	table := NewTable([]string{"ID", "TITLE", "STATUS"})
+	table.maxWidths = []int{0, 50, 0}
+	table.AddRow([]string{"ENG-1", strings.Repeat("long title ", 4), "In Progress"})
+	table.SetWidth(40)
+
+	var buf bytes.Buffer
+	table.PrintTo(&buf)
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if w := DisplayWidth(line); w > 40 {
+			t.Errorf("line %q is %d cells wide, want at most 40", line, w)
+		}
+	}
+	if !strings.Contains(buf.String(), "In Progress") {
+		t.Errorf("non-truncatable column should be kept intact, got %q", buf.String())
+	}
+}
+
+func TestTable_SetWidthKeepsMinimumColumnWidth(t *testing.T) {
+	table := NewTable([]string{"ID", "TITLE"})
+	table.maxWidths = []int{0, 50}
+	table.AddRow([]string{"ENG-1", "A reasonably long title"})
+	table.SetWidth(5)
+
+	var buf bytes.Buffer
+	table.PrintTo(&buf)
+
+	if !strings.Contains(buf.String(), "A reaso...") {
+		t.Errorf("expected title truncated to the minimum width, got %q", buf.String())
+	}
+}
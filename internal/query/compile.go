@@ -0,0 +1,449 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukky/linear/linear"
+)
+
+// Compile parses a query and compiles it to an issue filter. Errors are
+// returned as *Error
+func Compile(src string) (linear.IssueFilter, error) {
+	n, err := parse(src)
+	if err != nil {
+		return linear.IssueFilter{}, err
+	}
+	c := &compiler{src: src}
+	return c.compile(n, false)
+}
+
+// Fields returns the names of the fields a query can filter on
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fieldCompiler compiles a term with a single value. op is ":", "=" or an
+// ordering operator; negate asks for the complement of the term
+type fieldCompiler func(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error)
+
+// fields maps query field names, including aliases, to their compilers
+var fields map[string]fieldCompiler
+
+func init() {
+	fields = map[string]fieldCompiler{
+		"title":       textField(linear.IssueFilter.Title, false),
+		"description": textField(linear.IssueFilter.Description, true),
+		"team":        teamField,
+		"state":       stateField,
+		"status":      stateField,
+		"type":        stateTypeField,
+		"label":       labelField,
+		"labels":      labelField,
+		"assignee":    userField(linear.IssueFilter.Assignee),
+		"creator":     userField(linear.IssueFilter.Creator),
+		"project":     projectField,
+		"priority":    priorityField,
+		"estimate":    numberField(linear.IssueFilter.Estimate, true),
+		"created":     dateField(linear.IssueFilter.CreatedAt, false),
+		"updated":     dateField(linear.IssueFilter.UpdatedAt, false),
+		"started":     dateField(linear.IssueFilter.StartedAt, true),
+		"completed":   dateField(linear.IssueFilter.CompletedAt, true),
+		"canceled":    dateField(linear.IssueFilter.CanceledAt, true),
+		"due":         dateField(linear.IssueFilter.DueDate, true),
+	}
+}
+
+type compiler struct {
+	src string
+}
+
+func (c *compiler) errorf(pos int, format string, args ...any) *Error {
+	return &Error{Query: c.src, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// compile converts n to a filter, pushing negations down to the terms
+// because Linear filters have no NOT
+func (c *compiler) compile(n node, negate bool) (linear.IssueFilter, error) {
+	switch n := n.(type) {
+	case *not:
+		return c.compile(n.node, !negate)
+	case *logical:
+		filters := make([]linear.IssueFilter, len(n.nodes))
+		for i, child := range n.nodes {
+			f, err := c.compile(child, negate)
+			if err != nil {
+				return linear.IssueFilter{}, err
+			}
+			filters[i] = f
+		}
+		// NOT (a OR b) is (NOT a) AND (NOT b), and vice versa
+		if n.or != negate {
+			return anyOf(filters...), nil
+		}
+		return allOf(filters...), nil
+	case *term:
+		return c.term(n, negate)
+	}
+	panic(fmt.Sprintf("query: unexpected node %T", n))
+}
+
+func (c *compiler) term(t *term, negate bool) (linear.IssueFilter, error) {
+	compile := textField(linear.IssueFilter.Title, false)
+	op := ":"
+	if t.field != "" {
+		var ok bool
+		if compile, ok = fields[t.field]; !ok {
+			return linear.IssueFilter{}, c.errorf(t.pos, "unknown field %q (fields: %s)", t.field, strings.Join(Fields(), ", "))
+		}
+		op = t.op
+	}
+	if op == "!=" {
+		op, negate = "=", !negate
+	}
+
+	filters := make([]linear.IssueFilter, len(t.values))
+	for i, value := range t.values {
+		f, err := compile(c, t, op, value, negate)
+		if err != nil {
+			return linear.IssueFilter{}, err
+		}
+		filters[i] = f
+	}
+	// label:bug,incident matches either label; its negation matches neither
+	if negate {
+		return allOf(filters...), nil
+	}
+	return anyOf(filters...), nil
+}
+
+// requireEquality rejects ordering operators on fields without an order
+func (c *compiler) requireEquality(t *term, op string) error {
+	if op != ":" && op != "=" {
+		return c.errorf(t.opPos, "%s does not support %q; use %s:value or %s!=value", t.field, op, t.field, t.field)
+	}
+	return nil
+}
+
+func anyOf(filters ...linear.IssueFilter) linear.IssueFilter {
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	return linear.IssueFilter{}.Or(filters...)
+}
+
+func allOf(filters ...linear.IssueFilter) linear.IssueFilter {
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	return linear.IssueFilter{}.And(filters...)
+}
+
+// orNull widens a negated condition on a nullable field to also match
+// issues where the field is empty: NOT assignee:ada includes unassigned
+// issues
+func orNull(negate bool, isNull, f linear.IssueFilter) linear.IssueFilter {
+	if !negate {
+		return f
+	}
+	return anyOf(isNull, f)
+}
+
+// isNone reports whether value asks for an empty field (e.g. assignee:none)
+func isNone(value string) bool {
+	return strings.EqualFold(value, "none")
+}
+
+type scalarSetter func(linear.IssueFilter, ...linear.Comparator) linear.IssueFilter
+
+// textField matches text containing the value with ':', or equal to it
+// with '=', ignoring case
+func textField(set scalarSetter, nullable bool) fieldCompiler {
+	return func(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error) {
+		if err := c.requireEquality(t, op); err != nil {
+			return linear.IssueFilter{}, err
+		}
+		var cmp linear.Comparator
+		switch {
+		case op == ":" && !negate:
+			cmp = linear.ContainsIgnoreCase(value)
+		case op == ":":
+			cmp = linear.NotContainsIgnoreCase(value)
+		case !negate:
+			cmp = linear.EqIgnoreCase(value)
+		default:
+			cmp = linear.NeqIgnoreCase(value)
+		}
+		f := set(linear.IssueFilter{}, cmp)
+		if nullable {
+			f = orNull(negate, set(linear.IssueFilter{}, linear.Null(true)), f)
+		}
+		return f, nil
+	}
+}
+
+// equalIgnoreCase returns a case-insensitive equality comparator, or its
+// negation
+func equalIgnoreCase(value string, negate bool) linear.Comparator {
+	if negate {
+		return linear.NeqIgnoreCase(value)
+	}
+	return linear.EqIgnoreCase(value)
+}
+
+func teamField(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error) {
+	if err := c.requireEquality(t, op); err != nil {
+		return linear.IssueFilter{}, err
+	}
+	return linear.IssueFilter{}.Team(linear.TeamFilter{}.Key(equalIgnoreCase(value, negate))), nil
+}
+
+func stateField(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error) {
+	if err := c.requireEquality(t, op); err != nil {
+		return linear.IssueFilter{}, err
+	}
+	return linear.IssueFilter{}.State(linear.StateFilter{}.Name(equalIgnoreCase(value, negate))), nil
+}
+
+// stateTypes are the workflow state types Linear accepts
+var stateTypes = []string{"triage", "backlog", "unstarted", "started", "completed", "canceled"}
+
+func stateTypeField(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error) {
+	if err := c.requireEquality(t, op); err != nil {
+		return linear.IssueFilter{}, err
+	}
+	value = strings.ToLower(value)
+	valid := false
+	for _, st := range stateTypes {
+		valid = valid || st == value
+	}
+	if !valid {
+		return linear.IssueFilter{}, c.errorf(t.valuePos, "unknown state type %q (types: %s)", value, strings.Join(stateTypes, ", "))
+	}
+	cmp := linear.Eq(value)
+	if negate {
+		cmp = linear.Neq(value)
+	}
+	return linear.IssueFilter{}.State(linear.StateFilter{}.Type(cmp)), nil
+}
+
+func labelField(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error) {
+	if err := c.requireEquality(t, op); err != nil {
+		return linear.IssueFilter{}, err
+	}
+	// An issue lacks a label when none of its labels has that name
+	if negate {
+		return linear.IssueFilter{}.Labels(linear.Every(linear.LabelFilter{}.Name(linear.NeqIgnoreCase(value)))), nil
+	}
+	return linear.IssueFilter{}.Labels(linear.Some(linear.LabelFilter{}.Name(linear.EqIgnoreCase(value)))), nil
+}
+
+// userField matches "me", "none", an email address, or a display name or
+// part of a full name
+func userField(set func(linear.IssueFilter, linear.UserFilter) linear.IssueFilter) fieldCompiler {
+	return func(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error) {
+		if err := c.requireEquality(t, op); err != nil {
+			return linear.IssueFilter{}, err
+		}
+		if isNone(value) {
+			return set(linear.IssueFilter{}, linear.UserFilter{}.Null(!negate)), nil
+		}
+
+		var user linear.UserFilter
+		switch {
+		case strings.EqualFold(value, "me"):
+			user = linear.UserFilter{}.IsMe(linear.Eq(!negate))
+		case strings.Contains(value, "@"):
+			user = linear.UserFilter{}.Email(equalIgnoreCase(value, negate))
+		case negate:
+			user = linear.UserFilter{}.DisplayName(linear.NeqIgnoreCase(value)).Name(linear.NotContainsIgnoreCase(value))
+		default:
+			user = linear.UserFilter{}.Or(
+				linear.UserFilter{}.DisplayName(linear.EqIgnoreCase(value)),
+				linear.UserFilter{}.Name(linear.ContainsIgnoreCase(value)),
+			)
+		}
+		return orNull(negate, set(linear.IssueFilter{}, linear.UserFilter{}.Null(true)), set(linear.IssueFilter{}, user)), nil
+	}
+}
+
+func projectField(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error) {
+	if err := c.requireEquality(t, op); err != nil {
+		return linear.IssueFilter{}, err
+	}
+	if isNone(value) {
+		return linear.IssueFilter{}.Project(linear.ProjectFilter{}.Null(!negate)), nil
+	}
+
+	var cmp linear.Comparator
+	switch {
+	case op == ":" && !negate:
+		cmp = linear.ContainsIgnoreCase(value)
+	case op == ":":
+		cmp = linear.NotContainsIgnoreCase(value)
+	default:
+		cmp = equalIgnoreCase(value, negate)
+	}
+	isNull := linear.IssueFilter{}.Project(linear.ProjectFilter{}.Null(true))
+	return orNull(negate, isNull, linear.IssueFilter{}.Project(linear.ProjectFilter{}.Name(cmp))), nil
+}
+
+// negatedOps maps each ordering operator to the one matching its
+// complement
+var negatedOps = map[string]string{"<": ">=", "<=": ">", ">": "<=", ">=": "<"}
+
+// ordered returns the comparator for an ordering operator
+func ordered(op string, v any) linear.Comparator {
+	switch op {
+	case "<":
+		return linear.Lt(v)
+	case "<=":
+		return linear.Lte(v)
+	case ">":
+		return linear.Gt(v)
+	default:
+		return linear.Gte(v)
+	}
+}
+
+// compare returns the comparator for op, or for its complement when negate
+// is set
+func compare(op string, negate bool, v any) linear.Comparator {
+	if op == ":" || op == "=" {
+		if negate {
+			return linear.Neq(v)
+		}
+		return linear.Eq(v)
+	}
+	if negate {
+		op = negatedOps[op]
+	}
+	return ordered(op, v)
+}
+
+// priorities maps priority names to Linear's priority numbers
+var priorities = map[string]int{"none": 0, "urgent": 1, "high": 2, "medium": 3, "low": 4}
+
+// priorityField compares priorities by number, where 1 is urgent and 4 is
+// low. Since "no priority" is 0, < and <= only match issues with a
+// priority: priority<=2 means urgent or high
+func priorityField(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error) {
+	n, ok := priorities[strings.ToLower(value)]
+	if !ok {
+		var err error
+		if n, err = strconv.Atoi(value); err != nil || n < 0 || n > 4 {
+			return linear.IssueFilter{}, c.errorf(t.valuePos, "invalid priority %q (use 0-4 or none, urgent, high, medium, low)", value)
+		}
+	}
+
+	if op != "<" && op != "<=" {
+		return linear.IssueFilter{}.Priority(compare(op, negate, n)), nil
+	}
+	if negate {
+		return anyOf(
+			linear.IssueFilter{}.Priority(linear.Eq(0)),
+			linear.IssueFilter{}.Priority(compare(op, true, n)),
+		), nil
+	}
+	return linear.IssueFilter{}.Priority(linear.Gt(0), ordered(op, n)), nil
+}
+
+// numberField compares numbers; nullable fields also accept "none"
+func numberField(set scalarSetter, nullable bool) fieldCompiler {
+	return func(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error) {
+		isNull := set(linear.IssueFilter{}, linear.Null(true))
+		if nullable && isNone(value) {
+			if err := c.requireEquality(t, op); err != nil {
+				return linear.IssueFilter{}, err
+			}
+			return set(linear.IssueFilter{}, linear.Null(!negate)), nil
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return linear.IssueFilter{}, c.errorf(t.valuePos, "invalid number %q for %s", value, t.field)
+		}
+		f := set(linear.IssueFilter{}, compare(op, negate, n))
+		if nullable {
+			f = orNull(negate, isNull, f)
+		}
+		return f, nil
+	}
+}
+
+// relativeDate matches durations relative to now such as -7d or +2w
+var relativeDate = regexp.MustCompile(`^([+-]?)(\d+)([hdwmy])$`)
+
+// parseDate converts a query date to a value Linear accepts: a relative
+// duration becomes an ISO 8601 duration (-7d is -P7D), and absolute dates
+// are passed through. It reports whether value is a whole day
+func parseDate(value string) (string, bool, bool) {
+	if m := relativeDate.FindStringSubmatch(strings.ToLower(value)); m != nil {
+		sign := ""
+		if m[1] == "-" {
+			sign = "-"
+		}
+		unit := strings.ToUpper(m[3])
+		if unit == "H" {
+			return sign + "PT" + m[2] + unit, false, true
+		}
+		return sign + "P" + m[2] + unit, false, true
+	}
+	if _, err := time.Parse(time.DateOnly, value); err == nil {
+		return value, true, true
+	}
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return value, false, true
+	}
+	return "", false, false
+}
+
+// dateField compares dates. Values are dates (2025-01-31), timestamps, or
+// durations relative to now (-7d, -2w, -3m, -1y, -12h); nullable fields
+// also accept "none". With ':' or '=', a date matches that whole day
+func dateField(set scalarSetter, nullable bool) fieldCompiler {
+	return func(c *compiler, t *term, op, value string, negate bool) (linear.IssueFilter, error) {
+		isNull := set(linear.IssueFilter{}, linear.Null(true))
+		if nullable && isNone(value) {
+			if err := c.requireEquality(t, op); err != nil {
+				return linear.IssueFilter{}, err
+			}
+			return set(linear.IssueFilter{}, linear.Null(!negate)), nil
+		}
+
+		date, wholeDay, ok := parseDate(value)
+		if !ok {
+			return linear.IssueFilter{}, c.errorf(t.valuePos, "invalid date %q (use 2025-01-31, a timestamp, or a relative date such as -7d)", value)
+		}
+
+		var f linear.IssueFilter
+		switch {
+		case op != ":" && op != "=":
+			f = set(linear.IssueFilter{}, compare(op, negate, date))
+		case wholeDay:
+			day, _ := time.Parse(time.DateOnly, date)
+			next := day.AddDate(0, 0, 1).Format(time.DateOnly)
+			if negate {
+				f = anyOf(set(linear.IssueFilter{}, linear.Lt(date)), set(linear.IssueFilter{}, linear.Gte(next)))
+			} else {
+				f = set(linear.IssueFilter{}, linear.Gte(date), linear.Lt(next))
+			}
+		case strings.HasPrefix(date, "-P") || strings.HasPrefix(date, "P"):
+			return linear.IssueFilter{}, c.errorf(t.opPos, "use <, <=, > or >= to compare %s with a relative date (e.g. %s>%s)", t.field, t.field, value)
+		default:
+			f = set(linear.IssueFilter{}, compare(op, negate, date))
+		}
+		if nullable {
+			f = orNull(negate, isNull, f)
+		}
+		return f, nil
+	}
+}
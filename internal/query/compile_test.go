@@ -0,0 +1,174 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukky/linear/linear"
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`team:eng`, `{"team":{"key":{"eqIgnoreCase":"eng"}}}`},
+		{`crash`, `{"title":{"containsIgnoreCase":"crash"}}`},
+		{`-crash`, `{"title":{"notContainsIgnoreCase":"crash"}}`},
+		{`title="Crash on launch"`, `{"title":{"eqIgnoreCase":"Crash on launch"}}`},
+		{`label:bug`, `{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}}`},
+		{`-label:bug`, `{"labels":{"every":{"name":{"neqIgnoreCase":"bug"}}}}`},
+		{`label:bug,incident`, `{"or":[{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}},{"labels":{"some":{"name":{"eqIgnoreCase":"incident"}}}}]}`},
+		{`state!=Done`, `{"state":{"name":{"neqIgnoreCase":"Done"}}}`},
+		{`type:started`, `{"state":{"type":{"eq":"started"}}}`},
+		{`priority:high`, `{"priority":{"eq":2}}`},
+		{`priority<=2`, `{"priority":{"gt":0,"lte":2}}`},
+		{`NOT priority<=2`, `{"or":[{"priority":{"eq":0}},{"priority":{"gt":2}}]}`},
+		{`priority>=3`, `{"priority":{"gte":3}}`},
+		{`estimate>3`, `{"estimate":{"gt":3}}`},
+		{`updated>-7d`, `{"updatedAt":{"gt":"-P7D"}}`},
+		{`created<-12h`, `{"createdAt":{"lt":"-PT12H"}}`},
+		{`created:2025-01-31`, `{"createdAt":{"gte":"2025-01-31","lt":"2025-02-01"}}`},
+		{`due:none`, `{"dueDate":{"null":true}}`},
+		{`-due:none`, `{"dueDate":{"null":false}}`},
+		{`NOT due<2025-02-01`, `{"or":[{"dueDate":{"null":true}},{"dueDate":{"gte":"2025-02-01"}}]}`},
+		{`assignee:me`, `{"assignee":{"isMe":{"eq":true}}}`},
+		{`assignee:none`, `{"assignee":{"null":true}}`},
+		{`-assignee:me`, `{"or":[{"assignee":{"null":true}},{"assignee":{"isMe":{"eq":false}}}]}`},
+		{`assignee:ada@example.com`, `{"assignee":{"email":{"eqIgnoreCase":"ada@example.com"}}}`},
+		{`creator:ada`, `{"creator":{"or":[{"displayName":{"eqIgnoreCase":"ada"}},{"name":{"containsIgnoreCase":"ada"}}]}}`},
+		{`project:mobile`, `{"project":{"name":{"containsIgnoreCase":"mobile"}}}`},
+		{`project:none`, `{"project":{"null":true}}`},
+		{
+			`(label:bug OR label:incident) AND priority<=2 AND updated>-7d AND NOT state:Done`,
+			`{"and":[{"or":[{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}},{"labels":{"some":{"name":{"eqIgnoreCase":"incident"}}}}]},{"priority":{"gt":0,"lte":2}},{"updatedAt":{"gt":"-P7D"}},{"state":{"name":{"neqIgnoreCase":"Done"}}}]}`,
+		},
+		{
+			`NOT (team:eng OR label:bug)`,
+			`{"and":[{"team":{"key":{"neqIgnoreCase":"eng"}}},{"labels":{"every":{"name":{"neqIgnoreCase":"bug"}}}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			f, err := Compile(tt.query)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			got, _ := json.Marshal(f)
+			if string(got) != tt.want {
+				t.Errorf("Compile() = %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		query  string
+		column int
+		msg    string
+	}{
+		{`lable:bug`, 1, `unknown field "lable"`},
+		{`label>bug`, 6, `label does not support ">"`},
+		{`priority:highest`, 10, `invalid priority "highest"`},
+		{`priority<9`, 10, `invalid priority "9"`},
+		{`estimate>big`, 10, `invalid number "big" for estimate`},
+		{`updated>yesterday`, 9, `invalid date "yesterday"`},
+		{`updated:-7d`, 8, `use <, <=, > or >= to compare updated with a relative date`},
+		{`created:none`, 9, `invalid date "none"`},
+		{`due<none`, 4, `due does not support "<"`},
+		{`type:doing`, 6, `unknown state type "doing"`},
+		{`team:eng (priority:1 OR state<x)`, 30, `state does not support "<"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Compile(tt.query)
+			var qerr *Error
+			if !errors.As(err, &qerr) {
+				t.Fatalf("Compile() error = %v, want *Error", err)
+			}
+			if qerr.Column() != tt.column || !strings.HasPrefix(qerr.Msg, tt.msg) {
+				t.Errorf("Compile() error at column %d: %s\nwant column %d: %s", qerr.Column(), qerr.Msg, tt.column, tt.msg)
+			}
+		})
+	}
+}
+
+// TestCompile_Semantics runs compiled queries against a fake Linear API to
+// check that negation and null handling select the intended issues
+func TestCompile_Semantics(t *testing.T) {
+	srv := lineartest.NewServer()
+	defer srv.Close()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	srv.SetClock(func() time.Time { return now })
+
+	ada := srv.AddUser(lineartest.User{Name: "Ada Lovelace", DisplayName: "ada", Email: "ada@example.com"})
+	bob := srv.AddUser(lineartest.User{Name: "Bob Smith", DisplayName: "bob", Email: "bob@example.com"})
+	eng := srv.AddTeam(lineartest.Team{Key: "ENG", Name: "Engineering"})
+	states := srv.States(eng.ID)
+	bug := srv.AddLabel(lineartest.Label{Name: "Bug"})
+	incident := srv.AddLabel(lineartest.Label{Name: "Incident"})
+	mobile := srv.AddProject(lineartest.Project{Name: "Mobile App", TeamIDs: []string{eng.ID}})
+
+	// ENG-1: urgent bug, updated recently, in progress
+	srv.AddIssue(lineartest.Issue{TeamID: eng.ID, Title: "Crash on launch", Priority: 1, LabelIDs: []string{bug.ID}, AssigneeID: ada.ID, StateID: states[2].ID, ProjectID: mobile.ID, UpdatedAt: now.AddDate(0, 0, -1)})
+	// ENG-2: high priority incident, done
+	srv.AddIssue(lineartest.Issue{TeamID: eng.ID, Title: "Outage", Priority: 2, LabelIDs: []string{incident.ID}, AssigneeID: bob.ID, StateID: states[3].ID, UpdatedAt: now.AddDate(0, 0, -2)})
+	// ENG-3: bug with no priority, stale
+	srv.AddIssue(lineartest.Issue{TeamID: eng.ID, Title: "Typo", LabelIDs: []string{bug.ID}, UpdatedAt: now.AddDate(0, 0, -30), DueDate: "2025-03-10"})
+	// ENG-4: low priority, unlabeled
+	srv.AddIssue(lineartest.Issue{TeamID: eng.ID, Title: "Refactor", Priority: 4, AssigneeID: ada.ID, UpdatedAt: now.AddDate(0, 0, -3)})
+
+	client := srv.Client()
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`(label:bug OR label:incident) AND priority<=2 AND updated>-7d AND NOT state:Done`, "ENG-1"},
+		{`label:bug,incident`, "ENG-1,ENG-2,ENG-3"},
+		{`-label:bug`, "ENG-2,ENG-4"},
+		{`priority<=2`, "ENG-1,ENG-2"},
+		{`NOT priority<=2`, "ENG-3,ENG-4"},
+		{`priority:none`, "ENG-3"},
+		{`assignee:ada`, "ENG-1,ENG-4"},
+		{`-assignee:ada`, "ENG-2,ENG-3"},
+		{`assignee:none`, "ENG-3"},
+		{`-assignee:none`, "ENG-1,ENG-2,ENG-4"},
+		{`-project:mobile`, "ENG-2,ENG-3,ENG-4"},
+		{`updated<-7d`, "ENG-3"},
+		{`due:2025-03-10`, "ENG-3"},
+		{`-due:2025-03-10`, "ENG-1,ENG-2,ENG-4"},
+		{`type:started,completed`, "ENG-1,ENG-2"},
+		{`crash OR outage`, "ENG-1,ENG-2"},
+		{`NOT (crash OR outage)`, "ENG-3,ENG-4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			filter, err := Compile(tt.query)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			issues, err := client.ListAllIssues(context.Background(), linear.ListIssuesOptions{Filter: filter})
+			if err != nil {
+				t.Fatalf("ListAllIssues() error = %v", err)
+			}
+			ids := make([]string, len(issues))
+			for i, issue := range issues {
+				ids[i] = issue.Identifier
+			}
+			sort.Strings(ids)
+			if got := strings.Join(ids, ","); got != tt.want {
+				t.Errorf("matched %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,376 @@
+// Package query implements the issue query language accepted by
+// `linear issue list --query`, e.g.
+//
+//	(label:bug OR label:incident) priority<=2 updated>-7d NOT state:Done
+//
+// Terms are combined with NOT (or a leading '-'), AND and OR, from tightest
+// to loosest binding; terms next to each other are ANDed. Queries compile
+// to a Linear IssueFilter
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Error is a syntax or semantic error in a query, with the position of the
+// offending token
+type Error struct {
+	// Query is the query that failed to parse
+	Query string
+	// Pos is the byte offset of the error in Query
+	Pos int
+	// Msg describes the problem
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid query at column %d: %s", e.Column(), e.Msg)
+}
+
+// Column returns the 1-based column of the error, counted in characters
+func (e *Error) Column() int {
+	return utf8.RuneCountInString(e.Query[:e.Pos]) + 1
+}
+
+// Caret returns the query with a caret underneath the error position
+func (e *Error) Caret() string {
+	return e.Query + "\n" + strings.Repeat(" ", e.Column()-1) + "^"
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// describe names a token for error messages
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// opChars start a comparison operator
+const opChars = ":=!<>"
+
+// lexer splits a query into tokens. After an operator it reads a value,
+// which may contain operator characters (e.g. a timestamp)
+type lexer struct {
+	src     string
+	pos     int
+	afterOp bool
+}
+
+func (l *lexer) errorf(pos int, format string, args ...any) *Error {
+	return &Error{Query: l.src, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.pos += size
+	}
+	start := l.pos
+	afterOp := l.afterOp
+	l.afterOp = false
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case c == '(':
+		l.pos++
+		return token{tokLParen, "(", start}, nil
+	case c == ')':
+		l.pos++
+		return token{tokRParen, ")", start}, nil
+	case c == '"':
+		return l.string()
+	case !afterOp && c == '-' && l.pos+1 < len(l.src) && !unicode.IsSpace(rune(l.src[l.pos+1])):
+		// A leading '-' negates the term that follows (e.g. -label:bug)
+		l.pos++
+		return token{tokNot, "-", start}, nil
+	case !afterOp && strings.IndexByte(opChars, c) >= 0:
+		return l.op()
+	}
+
+	for l.pos < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' {
+			break
+		}
+		if !afterOp && r < utf8.RuneSelf && strings.IndexByte(opChars, byte(r)) >= 0 {
+			break
+		}
+		l.pos += size
+	}
+	text := l.src[start:l.pos]
+	if !afterOp {
+		switch text {
+		case "AND":
+			return token{tokAnd, text, start}, nil
+		case "OR":
+			return token{tokOr, text, start}, nil
+		case "NOT":
+			return token{tokNot, text, start}, nil
+		}
+	}
+	return token{tokWord, text, start}, nil
+}
+
+func (l *lexer) op() (token, error) {
+	start := l.pos
+	for _, op := range []string{"!=", "<=", ">=", ":", "=", "<", ">"} {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			l.afterOp = true
+			return token{tokOp, op, start}, nil
+		}
+	}
+	return token{}, l.errorf(start, "unexpected %q", l.src[start:start+1])
+}
+
+func (l *lexer) string() (token, error) {
+	start := l.pos
+	l.pos++
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return token{tokString, b.String(), start}, nil
+		case '\\':
+			if l.pos+1 < len(l.src) {
+				b.WriteByte(l.src[l.pos+1])
+				l.pos += 2
+				continue
+			}
+		}
+		b.WriteByte(c)
+		l.pos++
+	}
+	return token{}, l.errorf(start, "unterminated string")
+}
+
+// node is an expression in a parsed query
+type node interface{}
+
+// logical is an AND or OR of two or more expressions
+type logical struct {
+	or    bool
+	nodes []node
+}
+
+// not negates an expression
+type not struct {
+	node node
+}
+
+// term compares a field with one or more values; a term without a field
+// searches issue titles
+type term struct {
+	field    string
+	op       string
+	values   []string
+	pos      int
+	opPos    int
+	valuePos int
+}
+
+// parser is a recursive descent parser over the tokens of a query:
+//
+//	query := or
+//	or    := and ("OR" and)*
+//	and   := unary ("AND"? unary)*
+//	unary := ("NOT" | "-") unary | "(" or ")" | term
+//	term  := WORD (OP value)? | STRING
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+// parse parses src into an expression tree
+func parse(src string) (node, error) {
+	p := &parser{lex: &lexer{src: src}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokEOF {
+		return nil, p.errorf(p.tok.pos, "empty query")
+	}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.unexpected()
+	}
+	return n, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) errorf(pos int, format string, args ...any) *Error {
+	return p.lex.errorf(pos, format, args...)
+}
+
+func (p *parser) unexpected() *Error {
+	if p.tok.kind == tokRParen {
+		return p.errorf(p.tok.pos, "unmatched \")\"")
+	}
+	return p.errorf(p.tok.pos, "unexpected %s", p.tok.describe())
+}
+
+func (p *parser) or() (node, error) {
+	first, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []node{first}
+	for p.tok.kind == tokOr {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		n, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return &logical{or: true, nodes: nodes}, nil
+}
+
+func (p *parser) and() (node, error) {
+	first, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []node{first}
+	for {
+		switch p.tok.kind {
+		case tokAnd:
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		case tokWord, tokString, tokNot, tokLParen:
+		default:
+			if len(nodes) == 1 {
+				return first, nil
+			}
+			return &logical{nodes: nodes}, nil
+		}
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	switch p.tok.kind {
+	case tokNot:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &not{n}, nil
+	case tokLParen:
+		open := p.tok.pos
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokRParen {
+			return nil, p.errorf(p.tok.pos, "empty parentheses")
+		}
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			if p.tok.kind == tokEOF {
+				return nil, p.errorf(open, "unclosed \"(\"")
+			}
+			return nil, p.unexpected()
+		}
+		return n, p.advance()
+	case tokString:
+		t := &term{values: []string{p.tok.text}, pos: p.tok.pos, valuePos: p.tok.pos}
+		return t, p.advance()
+	case tokWord:
+		return p.term()
+	case tokEOF:
+		return nil, p.errorf(p.tok.pos, "expected a term, got end of query")
+	default:
+		return nil, p.unexpected()
+	}
+}
+
+func (p *parser) term() (node, error) {
+	word := p.tok
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokOp {
+		return &term{values: []string{word.text}, pos: word.pos, valuePos: word.pos}, nil
+	}
+
+	t := &term{field: strings.ToLower(word.text), op: p.tok.text, pos: word.pos, opPos: p.tok.pos}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	t.valuePos = p.tok.pos
+	switch p.tok.kind {
+	case tokString:
+		t.values = []string{p.tok.text}
+	case tokWord:
+		// label:bug,incident matches any of the values
+		for _, v := range strings.Split(p.tok.text, ",") {
+			if v == "" {
+				return nil, p.errorf(p.tok.pos, "empty value in %q", p.tok.text)
+			}
+			t.values = append(t.values, v)
+		}
+	default:
+		return nil, p.errorf(p.tok.pos, "expected a value after %q, got %s", word.text+t.op, p.tok.describe())
+	}
+	return t, p.advance()
+}
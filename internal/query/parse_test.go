@@ -0,0 +1,119 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// show renders a parsed query as an s-expression
+func show(n node) string {
+	switch n := n.(type) {
+	case *logical:
+		parts := make([]string, len(n.nodes))
+		for i, child := range n.nodes {
+			parts[i] = show(child)
+		}
+		op := "and"
+		if n.or {
+			op = "or"
+		}
+		return "(" + op + " " + strings.Join(parts, " ") + ")"
+	case *not:
+		return "(not " + show(n.node) + ")"
+	case *term:
+		return n.field + n.op + strings.Join(n.values, "|")
+	}
+	return "?"
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`label:bug`, `label:bug`},
+		{`crash`, `crash`},
+		{`"login page"`, `login page`},
+		{`a:1 b:2`, `(and a:1 b:2)`},
+		{`a:1 AND b:2`, `(and a:1 b:2)`},
+		{`a:1 OR b:2 c:3`, `(or a:1 (and b:2 c:3))`},
+		{`(a:1 OR b:2) c:3`, `(and (or a:1 b:2) c:3)`},
+		{`NOT a:1 b:2`, `(and (not a:1) b:2)`},
+		{`NOT (a:1 OR b:2)`, `(not (or a:1 b:2))`},
+		{`-label:bug`, `(not label:bug)`},
+		{`NOT NOT a:1`, `(not (not a:1))`},
+		{`priority<=2`, `priority<=2`},
+		{`priority <= 2`, `priority<=2`},
+		{`updated>-7d`, `updated>-7d`},
+		{`label!=bug`, `label!=bug`},
+		{`label:bug,incident`, `label:bug|incident`},
+		{`created>=2025-01-01T10:00:00Z`, `created>=2025-01-01T10:00:00Z`},
+		{`assignee:ada@example.com`, `assignee:ada@example.com`},
+		{`title:"a \"quoted\" (word)"`, `title:a "quoted" (word)`},
+		{`state:"In Progress"`, `state:In Progress`},
+		{`and or`, `(and and or)`},
+		{
+			`(label:bug OR label:incident) AND priority<=2 AND updated>-7d AND NOT state:Done`,
+			`(and (or label:bug label:incident) priority<=2 updated>-7d (not state:Done))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			n, err := parse(tt.query)
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if got := show(n); got != tt.want {
+				t.Errorf("parse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		query  string
+		column int
+		msg    string
+	}{
+		{``, 1, "empty query"},
+		{`   `, 4, "empty query"},
+		{`(label:bug`, 1, `unclosed "("`},
+		{`label:bug)`, 10, `unmatched ")"`},
+		{`()`, 2, "empty parentheses"},
+		{`label:`, 7, `expected a value after "label:", got end of query`},
+		{`label: )`, 8, `expected a value after "label:", got ")"`},
+		{`label:bug OR`, 13, "expected a term, got end of query"},
+		{`label:bug AND OR x`, 15, `unexpected "OR"`},
+		{`title:"open`, 7, "unterminated string"},
+		{`label:a,,b`, 7, `empty value in "a,,b"`},
+		{`priority!2`, 9, `unexpected "!"`},
+		{`état:x (`, 9, `expected a term, got end of query`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := parse(tt.query)
+			var qerr *Error
+			if !errors.As(err, &qerr) {
+				t.Fatalf("parse() error = %v, want *Error", err)
+			}
+			if qerr.Column() != tt.column || qerr.Msg != tt.msg {
+				t.Errorf("parse() error at column %d: %s\nwant column %d: %s", qerr.Column(), qerr.Msg, tt.column, tt.msg)
+			}
+		})
+	}
+}
+
+func TestError_Caret(t *testing.T) {
+	err := &Error{Query: "label:bug OR", Pos: 12, Msg: "expected a term"}
+	want := "label:bug OR\n            ^"
+	if got := err.Caret(); got != want {
+		t.Errorf("Caret() =\n%s\nwant\n%s", got, want)
+	}
+	if got := err.Error(); got != "invalid query at column 13: expected a term" {
+		t.Errorf("Error() = %q", got)
+	}
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"unicode/utf8"
+)
+
+// KeyType identifies a key that isn't a printable character
+type KeyType int
+
+const (
+	// KeyRune is a printable character, held in Key.Rune
+	KeyRune KeyType = iota
+	KeyEnter
+	KeyTab
+	KeyBackspace
+	KeyEscape
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPageUp
+	KeyPageDown
+	KeyDelete
+	// KeyCtrl is a control character, with the letter in Key.Rune
+	// (e.g. 'c' for Ctrl-C)
+	KeyCtrl
+)
+
+// Key is a key press read from the terminal
+type Key struct {
+	Type KeyType
+	Rune rune
+}
+
+var keyNames = map[KeyType]string{
+	KeyEnter:     "enter",
+	KeyTab:       "tab",
+	KeyBackspace: "backspace",
+	KeyEscape:    "esc",
+	KeyUp:        "up",
+	KeyDown:      "down",
+	KeyLeft:      "left",
+	KeyRight:     "right",
+	KeyHome:      "home",
+	KeyEnd:       "end",
+	KeyPageUp:    "pgup",
+	KeyPageDown:  "pgdown",
+	KeyDelete:    "delete",
+}
+
+// String names the key for matching key bindings, e.g. "q", "enter",
+// "ctrl+c" or "pgdown"
+func (k Key) String() string {
+	switch k.Type {
+	case KeyRune:
+		return string(k.Rune)
+	case KeyCtrl:
+		return "ctrl+" + string(k.Rune)
+	}
+	return keyNames[k.Type]
+}
+
+// escapeSequences maps the escape sequences terminals send for special
+// keys, in both their CSI and SS3 forms
+var escapeSequences = map[string]KeyType{
+	"[A": KeyUp, "[B": KeyDown, "[C": KeyRight, "[D": KeyLeft,
+	"OA": KeyUp, "OB": KeyDown, "OC": KeyRight, "OD": KeyLeft,
+	"[H": KeyHome, "[F": KeyEnd, "OH": KeyHome, "OF": KeyEnd,
+	"[1~": KeyHome, "[4~": KeyEnd, "[7~": KeyHome, "[8~": KeyEnd,
+	"[3~": KeyDelete, "[5~": KeyPageUp, "[6~": KeyPageDown,
+	"[Z": KeyTab,
+}
+
+// ParseKeys decodes the bytes of one read from a terminal in raw mode
+func ParseKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		key, n := parseKey(b)
+		keys = append(keys, key)
+		b = b[n:]
+	}
+	return keys
+}
+
+// parseKey decodes the key at the start of b and returns its length
+func parseKey(b []byte) (Key, int) {
+	switch c := b[0]; {
+	case c == 0x1b:
+		if len(b) == 1 {
+			return Key{Type: KeyEscape}, 1
+		}
+		if b[1] == '[' || b[1] == 'O' {
+			// A sequence ends with its first byte in 0x40-0x7e
+			for i := 2; i < len(b); i++ {
+				if b[i] >= 0x40 && b[i] <= 0x7e {
+					if t, ok := escapeSequences[string(b[1:i+1])]; ok {
+						return Key{Type: t}, i + 1
+					}
+					// An unknown sequence is dropped rather than typed
+					return Key{Type: KeyEscape}, i + 1
+				}
+			}
+		}
+		// Alt+key arrives as ESC followed by the key
+		return Key{Type: KeyEscape}, 1
+	case c == '\r' || c == '\n':
+		return Key{Type: KeyEnter}, 1
+	case c == '\t':
+		return Key{Type: KeyTab}, 1
+	case c == 0x7f || c == 0x08:
+		return Key{Type: KeyBackspace}, 1
+	case c < 0x20:
+		return Key{Type: KeyCtrl, Rune: rune('a' + c - 1)}, 1
+	}
+
+	r, size := utf8.DecodeRune(b)
+	return Key{Type: KeyRune, Rune: r}, size
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"letters", "jk", []string{"j", "k"}},
+		{"unicode", "é", []string{"é"}},
+		{"enter", "\r", []string{"enter"}},
+		{"control", "\x03\x04", []string{"ctrl+c", "ctrl+d"}},
+		{"backspace", "\x7f", []string{"backspace"}},
+		{"arrows", "\x1b[A\x1b[B\x1bOC", []string{"up", "down", "right"}},
+		{"paging", "\x1b[5~\x1b[6~", []string{"pgup", "pgdown"}},
+		{"escape", "\x1b", []string{"esc"}},
+		{"alt key", "\x1bx", []string{"esc", "x"}},
+		{"unknown sequence", "\x1b[99zq", []string{"esc", "q"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, key := range ParseKeys([]byte(tt.input)) {
+				got = append(got, key.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseKeys(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/dukky/linear/internal/output"
+)
+
+// Fit truncates or pads s to exactly width cells
+func Fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	s = output.TruncateWidth(s, width)
+	if n := width - output.DisplayWidth(s); n > 0 {
+		s += strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// Reverse shows s in reverse video, for the selected row. Unlike colors it
+// is used even when colors are disabled, since it is the only cue.
+func Reverse(s string) string {
+	// Styles inside s end with a reset, which would also end the reverse
+	return "\x1b[7m" + strings.ReplaceAll(s, "\x1b[0m", "\x1b[0;7m") + "\x1b[0m"
+}
+
+// Bold shows s in bold
+func Bold(s string) string {
+	return "\x1b[1m" + s + "\x1b[0m"
+}
+
+// Dim shows s faintly when colors are enabled
+func Dim(s string) string {
+	if !output.ColorEnabled() {
+		return s
+	}
+	return "\x1b[2m" + s + "\x1b[0m"
+}
+
+// Columns joins panes side by side, each fitted to its width, separated by
+// sep; lines missing from shorter panes are left blank
+func Columns(sep string, widths []int, panes ...[]string) []string {
+	height := 0
+	for _, pane := range panes {
+		height = max(height, len(pane))
+	}
+
+	lines := make([]string, height)
+	for i := range lines {
+		parts := make([]string, len(panes))
+		for j, pane := range panes {
+			line := ""
+			if i < len(pane) {
+				line = pane[i]
+			}
+			if j == len(panes)-1 {
+				// The last pane needs no padding, only truncation
+				parts[j] = output.TruncateWidth(line, widths[j])
+			} else {
+				parts[j] = Fit(line, widths[j])
+			}
+		}
+		lines[i] = strings.Join(parts, sep)
+	}
+	return lines
+}
+
+// Wrap splits text into lines of at most width cells, breaking at spaces
+// where possible
+func Wrap(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = word
+			case output.DisplayWidth(line)+1+output.DisplayWidth(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+			for output.DisplayWidth(line) > width && width > 0 {
+				cut := output.TruncateWidth(line, width)
+				cut = strings.TrimSuffix(cut, "...")
+				if cut == "" {
+					break
+				}
+				lines = append(lines, cut)
+				line = strings.TrimPrefix(line, cut)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	if got := Fit("ab", 4); got != "ab  " {
+		t.Errorf("Fit pads to %q", got)
+	}
+	if got := Fit("abcdefgh", 6); got != "abc..." {
+		t.Errorf("Fit truncates to %q", got)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	got := Columns(" | ", []int{3, 4}, []string{"a", "b", "c"}, []string{"right pane"})
+	want := []string{"a   | r...", "b   | ", "c   | "}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Columns() = %q, want %q", got, want)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	got := Wrap("the quick brown fox\n\njumps", 10)
+	want := []string{"the quick", "brown fox", "", "jumps"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,270 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dukky/linear/internal/output"
+)
+
+// Item is a choice in a Menu
+type Item struct {
+	// Label is shown and matched against the filter
+	Label string
+	// Detail is shown dimmed after the label and also matched
+	Detail string
+	// Value identifies the item to the caller
+	Value string
+	// Checked marks the item as selected in a multi-select menu
+	Checked bool
+}
+
+// MenuAction is the outcome of a key press in a menu
+type MenuAction int
+
+const (
+	// MenuPending means the menu is still open
+	MenuPending MenuAction = iota
+	// MenuChosen means the user accepted the current item, or the checked
+	// items of a multi-select menu
+	MenuChosen
+	// MenuCanceled means the user closed the menu without choosing
+	MenuCanceled
+)
+
+// Menu is a list of items narrowed by typing, which matches items fuzzily
+// (letters in order, not necessarily adjacent). Up and down move, enter
+// chooses and esc cancels; in a multi-select menu tab toggles an item.
+type Menu struct {
+	Title string
+	Items []Item
+	Multi bool
+
+	filter  []rune
+	matches []int
+	cursor  int
+	offset  int
+}
+
+// NewMenu returns a menu over items with the first item selected
+func NewMenu(title string, items []Item) *Menu {
+	m := &Menu{Title: title, Items: items}
+	m.refilter()
+	return m
+}
+
+// Select moves the cursor to the item with value, if it is shown
+func (m *Menu) Select(value string) {
+	for i, index := range m.matches {
+		if m.Items[index].Value == value {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// Current returns the item under the cursor
+func (m *Menu) Current() (Item, bool) {
+	if len(m.matches) == 0 {
+		return Item{}, false
+	}
+	return m.Items[m.matches[m.cursor]], true
+}
+
+// Checked returns the checked items of a multi-select menu
+func (m *Menu) Checked() []Item {
+	var checked []Item
+	for _, item := range m.Items {
+		if item.Checked {
+			checked = append(checked, item)
+		}
+	}
+	return checked
+}
+
+// Filter returns the text typed to narrow the menu
+func (m *Menu) Filter() string {
+	return string(m.filter)
+}
+
+// SetFilter narrows the menu as if filter had been typed
+func (m *Menu) SetFilter(filter string) {
+	m.filter = []rune(filter)
+	m.refilter()
+}
+
+// HandleKey applies a key press
+func (m *Menu) HandleKey(k Key) MenuAction {
+	switch k.String() {
+	case "esc", "ctrl+c":
+		return MenuCanceled
+	case "enter":
+		if !m.Multi {
+			if _, ok := m.Current(); !ok {
+				return MenuPending
+			}
+		}
+		return MenuChosen
+	case "up", "ctrl+p":
+		m.move(-1)
+	case "down", "ctrl+n":
+		m.move(1)
+	case "pgup":
+		m.move(-10)
+	case "pgdown":
+		m.move(10)
+	case "tab":
+		if m.Multi && len(m.matches) > 0 {
+			item := &m.Items[m.matches[m.cursor]]
+			item.Checked = !item.Checked
+			m.move(1)
+		}
+	case "backspace":
+		if len(m.filter) > 0 {
+			m.filter = m.filter[:len(m.filter)-1]
+			m.refilter()
+		}
+	case "ctrl+u":
+		m.filter = nil
+		m.refilter()
+	default:
+		if k.Type == KeyRune && unicode.IsPrint(k.Rune) {
+			m.filter = append(m.filter, k.Rune)
+			m.refilter()
+		}
+	}
+	return MenuPending
+}
+
+func (m *Menu) move(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.matches)-1)
+}
+
+// refilter shows the items fuzzy-matching every word of the filter, best
+// matches first. The cursor moves to the best match, or stays on its item
+// when there is no filter.
+func (m *Menu) refilter() {
+	current := -1
+	if len(m.matches) > 0 {
+		current = m.matches[m.cursor]
+	}
+
+	type match struct{ index, score int }
+	var found []match
+	words := strings.Fields(string(m.filter))
+	for i, item := range m.Items {
+		text := output.StripANSI(item.Label + " " + item.Detail)
+		total, matched := 0, true
+		for _, word := range words {
+			score, ok := fuzzyMatch(word, text)
+			if !ok {
+				matched = false
+				break
+			}
+			total += score
+		}
+		if matched {
+			found = append(found, match{i, total})
+		}
+	}
+	sort.SliceStable(found, func(a, b int) bool { return found[a].score > found[b].score })
+
+	m.matches = m.matches[:0]
+	for _, f := range found {
+		m.matches = append(m.matches, f.index)
+	}
+
+	m.cursor = 0
+	if len(words) == 0 {
+		for i, index := range m.matches {
+			if index == current {
+				m.cursor = i
+			}
+		}
+	}
+}
+
+// fuzzyMatch reports whether the runes of pattern appear in order in text,
+// ignoring case, and scores the match: runes that start words or follow
+// the previous match score higher, and gaps cost a little
+func fuzzyMatch(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+
+	score, j, last := 0, 0, -1
+	for i := 0; i < len(t) && j < len(p); i++ {
+		if t[i] != p[j] {
+			continue
+		}
+		score++
+		switch {
+		case i == 0 || !unicode.IsLetter(t[i-1]) && !unicode.IsDigit(t[i-1]):
+			score += 8
+		case i == last+1:
+			score += 4
+		}
+		score -= min(i-last-1, 3)
+		last = i
+		j++
+	}
+	return score, j == len(p)
+}
+
+// View renders the menu as at most height lines of width cells
+func (m *Menu) View(width, height int) []string {
+	lines := []string{
+		Bold(m.Title),
+		"> " + string(m.filter) + "▏",
+	}
+
+	rows := max(height-len(lines)-1, 1)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+	m.offset = min(m.offset, max(len(m.matches)-rows, 0))
+
+	for i := m.offset; i < len(m.matches) && i < m.offset+rows; i++ {
+		item := m.Items[m.matches[i]]
+		line := item.Label
+		if m.Multi {
+			box := "[ ] "
+			if item.Checked {
+				box = "[x] "
+			}
+			line = box + line
+		}
+		if i == m.cursor {
+			line = Reverse("› " + line)
+		} else {
+			line = "  " + line
+		}
+		if item.Detail != "" {
+			line += " " + Dim(item.Detail)
+		}
+		lines = append(lines, line)
+	}
+	if len(m.matches) == 0 {
+		lines = append(lines, Dim("  No matches"))
+	}
+
+	footer := fmt.Sprintf("%d/%d", len(m.matches), len(m.Items))
+	if m.Multi {
+		footer += " · tab toggle · enter apply · esc cancel"
+	} else {
+		footer += " · enter choose · esc cancel"
+	}
+	lines = append(lines, Dim(footer))
+
+	for i, line := range lines {
+		lines[i] = output.TruncateWidth(line, width)
+	}
+	return lines
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dukky/linear/internal/output"
+)
+
+func typeKeys(m *Menu, s string) MenuAction {
+	action := MenuPending
+	for _, key := range ParseKeys([]byte(s)) {
+		action = m.HandleKey(key)
+	}
+	return action
+}
+
+func newStateMenu() *Menu {
+	return NewMenu("State", []Item{
+		{Label: "Backlog", Detail: "backlog", Value: "1"},
+		{Label: "Todo", Detail: "unstarted", Value: "2"},
+		{Label: "In Progress", Detail: "started", Value: "3"},
+		{Label: "Done", Detail: "completed", Value: "4"},
+	})
+}
+
+func TestMenu_Filter(t *testing.T) {
+	m := newStateMenu()
+
+	if action := typeKeys(m, "prog\r"); action != MenuChosen {
+		t.Fatalf("action = %v, want MenuChosen", action)
+	}
+	if item, _ := m.Current(); item.Value != "3" {
+		t.Errorf("chose %q, want In Progress", item.Label)
+	}
+
+	// Details are matched too, and every word must match
+	m = newStateMenu()
+	typeKeys(m, "in start")
+	if item, _ := m.Current(); item.Value != "3" {
+		t.Errorf("chose %q, want In Progress", item.Label)
+	}
+
+	// Enter does nothing when nothing matches
+	m = newStateMenu()
+	if action := typeKeys(m, "zzz\r"); action != MenuPending {
+		t.Errorf("action = %v, want MenuPending", action)
+	}
+	typeKeys(m, "\x7f\x7f\x7f")
+	if m.Filter() != "" {
+		t.Errorf("filter = %q after backspaces", m.Filter())
+	}
+}
+
+func TestMenu_Fuzzy(t *testing.T) {
+	m := NewMenu("Project", []Item{
+		{Label: "Mobile Platform"},
+		{Label: "Web App"},
+		{Label: "Mobile App"},
+		{Label: "Payments"},
+	})
+
+	m.SetFilter("mp")
+	var got []string
+	for range len(m.matches) {
+		item, _ := m.Current()
+		got = append(got, item.Label)
+		m.move(1)
+	}
+	// Letters may be spread out, but runs and word starts rank first
+	if want := "Mobile Platform,Mobile App"; strings.Join(got, ",") != want {
+		t.Errorf("matches = %q, want %q", got, want)
+	}
+
+	for _, tt := range []struct {
+		pattern, text string
+		want          bool
+	}{
+		{"mapp", "Mobile App", true},
+		{"MA", "mobile app", true},
+		{"pam", "Mobile App", false},
+		{"", "anything", true},
+	} {
+		if _, ok := fuzzyMatch(tt.pattern, tt.text); ok != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.pattern, tt.text, ok, tt.want)
+		}
+	}
+}
+
+func TestMenu_Navigation(t *testing.T) {
+	m := newStateMenu()
+	m.Select("2")
+	typeKeys(m, "\x1b[B\x1b[B\x1b[B")
+	if item, _ := m.Current(); item.Value != "4" {
+		t.Errorf("cursor on %q, want Done", item.Label)
+	}
+
+	// Typing moves the cursor to the best match
+	typeKeys(m, "dn")
+	if item, _ := m.Current(); item.Value != "4" {
+		t.Errorf("cursor on %q after filtering, want Done", item.Label)
+	}
+
+	if action := typeKeys(m, "\x1b"); action != MenuCanceled {
+		t.Errorf("action = %v, want MenuCanceled", action)
+	}
+}
+
+func TestMenu_Multi(t *testing.T) {
+	m := NewMenu("Labels", []Item{
+		{Label: "Bug", Value: "bug", Checked: true},
+		{Label: "Feature", Value: "feature"},
+		{Label: "Docs", Value: "docs"},
+	})
+	m.Multi = true
+
+	// Tab toggles and moves on
+	typeKeys(m, "\t\t")
+	if action := typeKeys(m, "\r"); action != MenuChosen {
+		t.Fatalf("action = %v, want MenuChosen", action)
+	}
+
+	var checked []string
+	for _, item := range m.Checked() {
+		checked = append(checked, item.Value)
+	}
+	if got := strings.Join(checked, ","); got != "feature" {
+		t.Errorf("checked = %q, want feature", got)
+	}
+}
+
+func TestMenu_View(t *testing.T) {
+	output.SetColorMode(output.ColorNever)
+
+	m := newStateMenu()
+	typeKeys(m, "n")
+	got := output.StripANSI(strings.Join(m.View(40, 10), "\n"))
+
+	want := `State
+> n▏
+› In Progress started
+  Done completed
+  Todo unstarted
+3/4 · enter choose · esc cancel`
+	if got != want {
+		t.Errorf("View() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Only the rows that fit are shown, scrolled to the cursor
+	m = newStateMenu()
+	typeKeys(m, "\x1b[B\x1b[B\x1b[B")
+	lines := m.View(30, 5)
+	if len(lines) != 5 || !strings.Contains(lines[3], "Done") {
+		t.Errorf("View() = %q", lines)
+	}
+}
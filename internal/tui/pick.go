@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// pickModel shows a menu on its own until the user chooses or cancels
+type pickModel struct {
+	menu   *Menu
+	chosen bool
+}
+
+func (p *pickModel) Update(msg Msg) Cmd {
+	key, ok := msg.(Key)
+	if !ok {
+		return nil
+	}
+	switch p.menu.HandleKey(key) {
+	case MenuChosen:
+		p.chosen = true
+		return Quit
+	case MenuCanceled:
+		return Quit
+	}
+	return nil
+}
+
+func (p *pickModel) View(width, height int) string {
+	return strings.Join(p.menu.View(width, height), "\n")
+}
+
+// Pick shows menu full-screen until the user chooses from it, reporting
+// false if they cancel instead. The choice is read from the menu.
+func Pick(ctx context.Context, in, out *os.File, menu *Menu) (bool, error) {
+	p := &pickModel{menu: menu}
+	if err := Run(ctx, in, out, p, nil); err != nil {
+		return false, err
+	}
+	return p.chosen, nil
+}
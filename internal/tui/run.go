@@ -0,0 +1,238 @@
+// Package tui runs full-screen terminal interfaces. A Model handles
+// messages (key presses, resizes and the results of background work) and
+// renders itself as text; Run owns the terminal, feeds the model messages
+// and redraws it after each one.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Msg is anything delivered to a model: a Key, a Resize, or the result of
+// a Cmd
+type Msg any
+
+// Cmd is background work, such as an API request, whose result is
+// delivered to the model as a message
+type Cmd func() Msg
+
+// Resize reports the terminal size, first when Run starts and then
+// whenever it changes
+type Resize struct {
+	Width, Height int
+}
+
+// quit is returned by a model to stop Run
+type quit struct{}
+
+// Quit is a Cmd that stops Run
+func Quit() Msg {
+	return quit{}
+}
+
+// Batch combines commands to run concurrently
+func Batch(cmds ...Cmd) Cmd {
+	var nonNil []Cmd
+	for _, cmd := range cmds {
+		if cmd != nil {
+			nonNil = append(nonNil, cmd)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return func() Msg { return BatchMsg(nonNil) }
+}
+
+// BatchMsg is the message of a Batch command; Run starts each of its
+// commands instead of delivering it
+type BatchMsg []Cmd
+
+// Output is a message that Run writes to the terminal between frames, such
+// as an OSC 52 clipboard request, before delivering Then to the model.
+// Commands run alongside the redraws and must not write to it themselves.
+type Output struct {
+	Sequence string
+	Then     Msg
+}
+
+// Model is the state of an interface
+type Model interface {
+	// Update handles a message, returning any work to start
+	Update(msg Msg) Cmd
+	// View renders the whole screen, as at most height lines that are
+	// each at most width cells wide
+	View(width, height int) string
+}
+
+// sizePollInterval is how often Run checks whether the terminal was
+// resized, which works the same on every platform
+const sizePollInterval = 250 * time.Millisecond
+
+// Run takes over the terminal on in and out until the model quits or ctx
+// is canceled, starting with the init command. The screen is restored
+// afterwards.
+func Run(ctx context.Context, in, out *os.File, m Model, init Cmd) error {
+	inFd, outFd := int(in.Fd()), int(out.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		return fmt.Errorf("not a terminal")
+	}
+
+	state, err := term.MakeRaw(inFd)
+	if err != nil {
+		return fmt.Errorf("failed to set up the terminal: %w", err)
+	}
+	defer term.Restore(inFd, state)
+
+	// Switch to the alternate screen and hide the cursor
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	keys := keysFrom(in)
+	size := func() Resize {
+		w, h, err := term.GetSize(outFd)
+		if err != nil {
+			return Resize{80, 24}
+		}
+		return Resize{w, h}
+	}
+	return loop(ctx, out, m, init, keys, size)
+}
+
+// loop delivers messages to the model and redraws it until it quits
+func loop(ctx context.Context, out io.Writer, m Model, init Cmd, keys <-chan Key, size func() Resize) error {
+	msgs := make(chan Msg, 16)
+	var start func(cmd Cmd)
+	start = func(cmd Cmd) {
+		if cmd == nil {
+			return
+		}
+		go func() {
+			msg := cmd()
+			if b, ok := msg.(BatchMsg); ok {
+				for _, cmd := range b {
+					start(cmd)
+				}
+				return
+			}
+			select {
+			case msgs <- msg:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	screen := &screen{out: out}
+	current := size()
+	start(m.Update(current))
+	start(init)
+	screen.draw(m.View(current.Width, current.Height))
+
+	ticker := time.NewTicker(sizePollInterval)
+	defer ticker.Stop()
+
+	for {
+		var msg Msg
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			msg = key
+		case msg = <-msgs:
+		case <-ticker.C:
+			if s := size(); s != current {
+				current = s
+				msg = s
+			} else {
+				continue
+			}
+		}
+
+		if o, ok := msg.(Output); ok {
+			if _, err := io.WriteString(out, o.Sequence); err != nil {
+				return err
+			}
+			if msg = o.Then; msg == nil {
+				continue
+			}
+		}
+		if _, ok := msg.(quit); ok {
+			return nil
+		}
+		cmd := m.Update(msg)
+		screen.draw(m.View(current.Width, current.Height))
+		start(cmd)
+	}
+}
+
+// readers holds the keys read from each input file. A read cannot be
+// interrupted, so a reader outlives its Run and is shared by later ones
+// rather than racing them for keys.
+var (
+	readersMu sync.Mutex
+	readers   = map[*os.File]chan Key{}
+)
+
+func keysFrom(in *os.File) <-chan Key {
+	readersMu.Lock()
+	defer readersMu.Unlock()
+	keys, ok := readers[in]
+	if !ok {
+		keys = make(chan Key)
+		readers[in] = keys
+		go readKeys(in, keys)
+	}
+	return keys
+}
+
+// readKeys sends the keys read from in until it fails
+func readKeys(in io.Reader, keys chan<- Key) {
+	defer close(keys)
+	buf := make([]byte, 256)
+	for {
+		n, err := in.Read(buf)
+		for _, key := range ParseKeys(buf[:n]) {
+			keys <- key
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// screen redraws frames, skipping unchanged ones
+type screen struct {
+	out  io.Writer
+	last string
+}
+
+func (s *screen) draw(frame string) {
+	if frame == s.last {
+		return
+	}
+	s.last = frame
+
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range strings.Split(frame, "\n") {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		// Reset styles, then clear whatever the previous frame left
+		b.WriteString(line)
+		b.WriteString("\x1b[0m\x1b[K")
+	}
+	b.WriteString("\x1b[J")
+	io.WriteString(s.out, b.String())
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// counter counts keys until q, and loads a greeting in the background
+type counter struct {
+	keys     int
+	greeting string
+	size     Resize
+}
+
+type greetingMsg string
+
+func (c *counter) Update(msg Msg) Cmd {
+	switch msg := msg.(type) {
+	case Resize:
+		c.size = msg
+	case greetingMsg:
+		c.greeting = string(msg)
+	case Key:
+		if msg.String() == "q" {
+			return Quit
+		}
+		c.keys++
+	}
+	return nil
+}
+
+func (c *counter) View(width, height int) string {
+	return fmt.Sprintf("%dx%d keys=%d %s", width, height, c.keys, c.greeting)
+}
+
+func TestLoop(t *testing.T) {
+	keys := make(chan Key)
+	var out bytes.Buffer
+	m := &counter{}
+	size := func() Resize { return Resize{100, 30} }
+	greet := func() Msg { return greetingMsg("hello") }
+
+	done := make(chan error)
+	go func() { done <- loop(context.Background(), &out, m, Batch(greet, nil), keys, size) }()
+
+	for _, key := range ParseKeys([]byte("jjk")) {
+		keys <- key
+	}
+	keys <- Key{Type: KeyRune, Rune: 'q'}
+	if err := <-done; err != nil {
+		t.Fatalf("loop() error = %v", err)
+	}
+
+	if m.keys != 3 {
+		t.Errorf("model saw %d keys, want 3", m.keys)
+	}
+	if m.size != (Resize{100, 30}) {
+		t.Errorf("model size = %v, want the initial resize", m.size)
+	}
+	if !strings.Contains(out.String(), "100x30 keys=3") {
+		t.Errorf("output %q does not show the last frame", out.String())
+	}
+}
+
+func TestLoop_Output(t *testing.T) {
+	var out bytes.Buffer
+	copied := func() Msg { return Output{Sequence: "\x1b]52;c;aGk=\a", Then: Quit()} }
+
+	// The loop stops only once Then is delivered
+	err := loop(context.Background(), &out, &counter{}, copied, make(chan Key), func() Resize { return Resize{80, 24} })
+	if err != nil {
+		t.Fatalf("loop() error = %v", err)
+	}
+	if !strings.HasSuffix(out.String(), "\x1b]52;c;aGk=\a") {
+		t.Errorf("output %q does not end with the sequence", out.String())
+	}
+}
+
+func TestLoop_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := loop(ctx, &bytes.Buffer{}, &counter{}, nil, make(chan Key), func() Resize { return Resize{80, 24} })
+	if err != context.Canceled {
+		t.Errorf("loop() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestScreen_SkipsUnchangedFrames(t *testing.T) {
+	var out bytes.Buffer
+	s := &screen{out: &out}
+	s.draw("a\nb")
+	first := out.Len()
+	s.draw("a\nb")
+	if out.Len() != first {
+		t.Error("an unchanged frame was redrawn")
+	}
+	s.draw("c")
+	if !strings.Contains(out.String()[first:], "c\x1b[0m\x1b[K\x1b[J") {
+		t.Errorf("frame = %q", out.String()[first:])
+	}
+}
+
+func TestPickModel(t *testing.T) {
+	keys := make(chan Key)
+	p := &pickModel{menu: NewMenu("Team", []Item{{Label: "Engineering", Value: "ENG"}, {Label: "Operations", Value: "OPS"}})}
+
+	done := make(chan error)
+	go func() {
+		done <- loop(context.Background(), &bytes.Buffer{}, p, nil, keys, func() Resize { return Resize{80, 24} })
+	}()
+	for _, key := range ParseKeys([]byte("ops\r")) {
+		keys <- key
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	if item, _ := p.menu.Current(); !p.chosen || item.Value != "OPS" {
+		t.Errorf("picked %+v (chosen %v), want OPS", item, p.chosen)
+	}
+}
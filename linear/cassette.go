@@ -0,0 +1,278 @@
+package linear
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Cassette is a set of recorded GraphQL interactions, saved as JSON by a
+// RecordingTransport and served by a ReplayTransport
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded GraphQL request and its response.
+// Requests are identified by operation and variables only, so a cassette
+// keeps replaying when query documents gain or lose fields
+type Interaction struct {
+	Operation string           `json:"operation"`
+	Variables json.RawMessage  `json:"variables,omitempty"`
+	Response  CassetteResponse `json:"response"`
+}
+
+// CassetteResponse is a recorded HTTP response
+type CassetteResponse struct {
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    json.RawMessage     `json:"body,omitempty"`
+}
+
+// cassetteHeaders are the response headers worth replaying; the rest
+// (dates, request IDs, cookies) only make cassettes noisy
+var cassetteHeaders = []string{"Content-Type", "Retry-After", "X-Complexity"}
+
+// LoadCassette reads a cassette file written by a RecordingTransport
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, replacing it atomically
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".cassette-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// RecordingTransport is an http.RoundTripper that sends requests through
+// to the API and appends each interaction to a cassette file, with
+// credentials redacted
+type RecordingTransport struct {
+	base     http.RoundTripper
+	path     string
+	mu       sync.Mutex
+	cassette *Cassette
+}
+
+// NewRecordingTransport wraps base, or http.DefaultTransport when base is
+// nil. Interactions are appended to the cassette at path if it exists, and
+// the file is rewritten after every request so nothing is lost when the
+// process exits early
+func NewRecordingTransport(base http.RoundTripper, path string) (*RecordingTransport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c, err := LoadCassette(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		c, err = &Cassette{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RecordingTransport{base: base, path: path, cassette: c}, nil
+}
+
+// RoundTrip sends the request through the wrapped transport and records it.
+// Requests that fail without a response are not recorded
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if err != nil {
+		return nil, err
+	}
+
+	operation, variables := cassetteKey(reqBody)
+	interaction := Interaction{
+		Operation: operation,
+		Response: CassetteResponse{
+			Status:  resp.StatusCode,
+			Headers: cassetteResponseHeaders(resp.Header),
+			Body:    traceBody(respBody),
+		},
+	}
+	if variables != "" {
+		interaction.Variables = json.RawMessage(variables)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	if err := t.cassette.Save(t.path); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReplayTransport is an http.RoundTripper that answers GraphQL requests
+// from a cassette instead of the network. Requests are matched by
+// operation and variables; repeated requests are served the recorded
+// responses in order, and the last one again once they run out
+type ReplayTransport struct {
+	cassette *Cassette
+	mu       sync.Mutex
+	used     []bool
+}
+
+// NewReplayTransport serves the interactions recorded in c
+func NewReplayTransport(c *Cassette) *ReplayTransport {
+	return &ReplayTransport{cassette: c, used: make([]bool, len(c.Interactions))}
+}
+
+// RoundTrip returns the recorded response for the request, or an error if
+// the cassette has none
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	operation, variables := cassetteKey(reqBody)
+
+	t.mu.Lock()
+	match := -1
+	for i, interaction := range t.cassette.Interactions {
+		if interaction.Operation != operation || canonicalJSON(interaction.Variables) != variables {
+			continue
+		}
+		match = i
+		if !t.used[i] {
+			break
+		}
+	}
+	if match >= 0 {
+		t.used[match] = true
+	}
+	t.mu.Unlock()
+
+	if match < 0 {
+		if variables == "" {
+			variables = "{}"
+		}
+		return nil, fmt.Errorf("cassette has no interaction for %s with variables %s", operation, variables)
+	}
+
+	recorded := t.cassette.Interactions[match].Response
+	body := []byte(recorded.Body)
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		body = []byte(text)
+	}
+	header := make(http.Header, len(recorded.Headers))
+	for name, values := range recorded.Headers {
+		header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// cassetteKey returns the operation name and canonical, redacted
+// variables that identify a GraphQL request body
+func cassetteKey(body []byte) (string, string) {
+	var payload struct {
+		Query         string          `json:"query"`
+		OperationName string          `json:"operationName"`
+		Variables     json.RawMessage `json:"variables"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", ""
+	}
+	operation := payload.OperationName
+	if operation == "" {
+		operation = operationName(payload.Query)
+	}
+	return operation, canonicalJSON(payload.Variables)
+}
+
+// canonicalJSON re-encodes raw with sorted keys and API keys redacted, or
+// returns "" for empty or null values
+func canonicalJSON(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil || v == nil {
+		return ""
+	}
+	if m, ok := v.(map[string]any); ok && len(m) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return redact(string(data))
+}
+
+// cassetteResponseHeaders keeps the replayable headers of a response
+func cassetteResponseHeaders(header http.Header) map[string][]string {
+	kept := http.Header{}
+	for name, values := range header {
+		canonical := http.CanonicalHeaderKey(name)
+		keep := strings.HasPrefix(canonical, "X-Ratelimit-")
+		for _, h := range cassetteHeaders {
+			keep = keep || canonical == h
+		}
+		if keep {
+			kept[canonical] = values
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return redactHeaders(kept)
+}
@@ -0,0 +1,135 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCassette_RecordThenReplay(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Requests-Remaining", "1499")
+		w.Header().Set("Set-Cookie", "session=abc")
+		if n == 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"message":"Entity not found: Issue","extensions":{"code":"ENTITY_NOT_FOUND"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"issue":{"title":"call ` + string('0'+rune(n)) + `","echo":"lin_api_secret123"}}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "issue.json")
+	recorder, err := NewRecordingTransport(nil, path)
+	if err != nil {
+		t.Fatalf("NewRecordingTransport() error = %v", err)
+	}
+	live, err := NewClient(WithAPIKey("lin_api_secret123"), WithEndpoint(server.URL), WithTransport(recorder), WithRetryPolicy(NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := "query Issue($id: String!) { issue(id: $id) { title } }"
+	run := func(c *Client, id string) (string, error) {
+		var result struct {
+			Issue struct{ Title string } `json:"issue"`
+		}
+		err := c.Do(context.Background(), query, map[string]any{"id": id}, &result)
+		return result.Issue.Title, err
+	}
+	for _, id := range []string{"ENG-1", "ENG-1", "ENG-404"} {
+		run(live, id)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leak := range []string{"secret123", "session=abc", server.URL} {
+		if strings.Contains(string(data), leak) {
+			t.Errorf("cassette contains %q:\n%s", leak, data)
+		}
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("LoadCassette() error = %v", err)
+	}
+	if len(cassette.Interactions) != 3 {
+		t.Fatalf("recorded %d interactions, want 3", len(cassette.Interactions))
+	}
+	if got := cassette.Interactions[0].Operation; got != "Issue" {
+		t.Errorf("Operation = %q", got)
+	}
+
+	replay, err := NewClient(WithAPIKey("lin_api_other"), WithTransport(NewReplayTransport(cassette)), WithRetryPolicy(NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"call 1", "call 2", "call 2"} {
+		if got, err := run(replay, "ENG-1"); err != nil || got != want {
+			t.Errorf("replayed title = %q, %v; want %q", got, err, want)
+		}
+	}
+	var apiErr *APIError
+	if _, err := run(replay, "ENG-404"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("replayed error = %v, want the recorded 400", err)
+	}
+	if _, err := run(replay, "ENG-2"); err == nil || !strings.Contains(err.Error(), `no interaction for Issue with variables {"id":"ENG-2"}`) {
+		t.Errorf("unrecorded request error = %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("replay reached the server: %d calls", calls.Load())
+	}
+}
+
+func TestRecordingTransport_AppendsToExistingCassette(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"viewer":{"id":"user-1"}}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "viewer.json")
+	for i := 0; i < 2; i++ {
+		recorder, err := NewRecordingTransport(nil, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, _ := NewClient(WithAPIKey("lin_api_key"), WithEndpoint(server.URL), WithTransport(recorder))
+		if err := c.Do(context.Background(), "{ viewer { id } }", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cassette.Interactions) != 2 || cassette.Interactions[1].Operation != "query viewer" {
+		t.Errorf("interactions = %+v", cassette.Interactions)
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := map[string]string{
+		``:                            "",
+		`null`:                        "",
+		`{}`:                          "",
+		`{"b": 1, "a": [true, null]}`: `{"a":[true,null],"b":1}`,
+		`{"key":"lin_api_abc"}`:       `{"key":"lin_api_REDACTED"}`,
+	}
+	for in, want := range tests {
+		if got := canonicalJSON([]byte(in)); got != want {
+			t.Errorf("canonicalJSON(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
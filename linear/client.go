@@ -0,0 +1,149 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultEndpoint is the Linear GraphQL API endpoint
+	DefaultEndpoint = "https://api.linear.app/graphql"
+
+	// DefaultRequestTimeout bounds each HTTP request to the API
+	DefaultRequestTimeout = 30 * time.Second
+
+	// DefaultUserAgent identifies the client when no user agent is set
+	DefaultUserAgent = "linear-go"
+)
+
+// ErrNoCredentials is returned by NewClient when neither an API key nor a
+// token source is configured
+var ErrNoCredentials = errors.New("no API key or token source configured")
+
+// Client is a client for the Linear GraphQL API.
+// A Client is safe for concurrent use by multiple goroutines.
+type Client struct {
+	httpClient     *http.Client
+	apiKey         string
+	tokenSource    TokenSource
+	endpoint       string
+	userAgent      string
+	requestTimeout time.Duration
+	retry          RetryPolicy
+}
+
+// TokenSource supplies OAuth access tokens, for example from a store that
+// refreshes them. Tokens are sent as "Authorization: Bearer <token>".
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticTokenSource is a TokenSource that always returns the same token
+type StaticTokenSource string
+
+// Token returns the token
+func (s StaticTokenSource) Token(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithAPIKey authenticates with a personal API key (lin_api_...)
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithTokenSource authenticates with OAuth access tokens from source.
+// It takes precedence over WithAPIKey.
+func WithTokenSource(source TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = source
+	}
+}
+
+// WithEndpoint sends requests to a different GraphQL endpoint, such as a
+// proxy or a fake server in tests
+func WithEndpoint(endpoint string) Option {
+	return func(c *Client) {
+		c.endpoint = endpoint
+	}
+}
+
+// WithHTTPClient sends requests with httpClient instead of a default
+// http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTransport sends requests through transport, for example a
+// TracingTransport. The HTTP client is copied, so a client passed to
+// WithHTTPClient is not modified.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRequestTimeout limits how long each request may take.
+// A timeout of 0 leaves requests bounded only by their context.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.requestTimeout = timeout
+	}
+}
+
+// WithRetryPolicy controls how failed requests are retried.
+// Use NoRetry to disable retries.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// NewClient creates a Linear API client.
+// An API key or token source is required.
+func NewClient(opts ...Option) (*Client, error) {
+	c := &Client{
+		httpClient:     &http.Client{},
+		endpoint:       DefaultEndpoint,
+		userAgent:      DefaultUserAgent,
+		requestTimeout: DefaultRequestTimeout,
+		retry:          DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.apiKey == "" && c.tokenSource == nil {
+		return nil, ErrNoCredentials
+	}
+	return c, nil
+}
+
+// authorization returns the Authorization header value for a request
+func (c *Client) authorization(ctx context.Context) (string, error) {
+	if c.tokenSource == nil {
+		return c.apiKey, nil
+	}
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	return "Bearer " + token, nil
+}
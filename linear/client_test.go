@@ -0,0 +1,103 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewClient_RequiresCredentials(t *testing.T) {
+	_, err := NewClient()
+	if !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Expected ErrNoCredentials, got %v", err)
+	}
+}
+
+func TestNewClient_Options(t *testing.T) {
+	var gotAuth, gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		opts          []Option
+		wantAuth      string
+		wantUserAgent string
+	}{
+		{
+			name:          "API key",
+			opts:          []Option{WithAPIKey("lin_api_key")},
+			wantAuth:      "lin_api_key",
+			wantUserAgent: DefaultUserAgent,
+		},
+		{
+			name:          "token source takes precedence",
+			opts:          []Option{WithAPIKey("lin_api_key"), WithTokenSource(StaticTokenSource("oauth-token"))},
+			wantAuth:      "Bearer oauth-token",
+			wantUserAgent: DefaultUserAgent,
+		},
+		{
+			name:          "user agent",
+			opts:          []Option{WithAPIKey("lin_api_key"), WithUserAgent("my-service/1.0")},
+			wantAuth:      "lin_api_key",
+			wantUserAgent: "my-service/1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(append(tt.opts, WithEndpoint(server.URL))...)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if err := client.Do(context.Background(), "query { viewer { id } }", nil, nil); err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			if gotAuth != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", gotAuth, tt.wantAuth)
+			}
+			if gotUserAgent != tt.wantUserAgent {
+				t.Errorf("User-Agent = %q, want %q", gotUserAgent, tt.wantUserAgent)
+			}
+		})
+	}
+}
+
+func TestWithTransport_DoesNotModifyHTTPClient(t *testing.T) {
+	httpClient := &http.Client{}
+	client, err := NewClient(WithAPIKey("key"), WithHTTPClient(httpClient), WithTransport(http.DefaultTransport))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if httpClient.Transport != nil {
+		t.Error("WithTransport should not modify the client passed to WithHTTPClient")
+	}
+	if client.httpClient.Transport != http.DefaultTransport {
+		t.Error("Expected the transport to be set on the client's copy")
+	}
+}
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token(ctx context.Context) (string, error) {
+	return "", errors.New("token expired")
+}
+
+func TestClient_Do_TokenSourceError(t *testing.T) {
+	client, err := NewClient(WithTokenSource(failingTokenSource{}), WithEndpoint("http://127.0.0.1:0"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	err = client.Do(context.Background(), "query { viewer { id } }", nil, nil)
+	if err == nil || err.Error() != "failed to get access token: token expired" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
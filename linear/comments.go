@@ -0,0 +1,126 @@
+package linear
+
+import "context"
+
+// Comment is a comment on an issue
+type Comment struct {
+	ID        string `json:"id"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"createdAt"`
+	User      *User  `json:"user"`
+	// Issue is only set by ListAllComments
+	Issue *Issue `json:"issue,omitempty"`
+}
+
+// CommentsResponse is the response for listing an issue's comments.
+// Issue is nil when the issue does not exist.
+type CommentsResponse struct {
+	Issue *struct {
+		Comments struct {
+			Nodes []Comment `json:"nodes"`
+		} `json:"comments"`
+	} `json:"issue"`
+}
+
+// ListComments retrieves the comments on an issue, given its ID or
+// identifier
+func (c *Client) ListComments(ctx context.Context, issueID string) (*CommentsResponse, error) {
+	query := `
+		query($id: String!) {
+			issue(id: $id) {
+				comments(first: 100) {
+					nodes {
+						id
+						body
+						createdAt
+						user {
+							id
+							name
+							email
+						}
+					}
+				}
+			}
+		}
+	`
+
+	vars := map[string]interface{}{
+		"id": issueID,
+	}
+
+	var resp CommentsResponse
+	if err := c.Do(ctx, query, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListAllComments retrieves every comment matching filter across issues,
+// with the issue each is on, using cursor-based pagination. If a page
+// fails, the comments fetched so far are returned with the error.
+func (c *Client) ListAllComments(ctx context.Context, filter CommentFilter) ([]Comment, error) {
+	query := `
+		query($filter: CommentFilter, $first: Int!, $after: String) {
+			comments(filter: $filter, first: $first, after: $after) {
+				nodes {
+					id
+					body
+					createdAt
+					user {
+						id
+						name
+						email
+					}
+					issue {
+						id
+						identifier
+						title
+						url
+						state {
+							name
+							color
+							type
+						}
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	vars := map[string]interface{}{
+		"first": 100,
+	}
+	if !filter.Empty() {
+		vars["filter"] = filter
+	}
+
+	var all []Comment
+	after := ""
+	for {
+		var resp struct {
+			Comments struct {
+				Nodes    []Comment `json:"nodes"`
+				PageInfo PageInfo  `json:"pageInfo"`
+			} `json:"comments"`
+		}
+		if err := c.Do(ctx, query, vars, &resp); err != nil {
+			return all, err
+		}
+		all = append(all, resp.Comments.Nodes...)
+
+		next, hasNextPage, err := nextPageCursor(after, resp.Comments.PageInfo)
+		if err != nil {
+			return all, err
+		}
+		if !hasNextPage {
+			return all, nil
+		}
+		after = next
+		vars["after"] = after
+	}
+}
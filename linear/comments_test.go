@@ -0,0 +1,94 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_ListComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Variables["id"] != "ENG-1" {
+			t.Errorf("Expected id variable 'ENG-1', got %v", req.Variables["id"])
+		}
+
+		response := graphQLResponse{
+			Data: json.RawMessage(`{
+				"issue": {
+					"comments": {
+						"nodes": [
+							{"id": "comment-1", "body": "Looking into it", "createdAt": "2024-01-02T00:00:00Z", "user": {"id": "user-1", "name": "Bob"}}
+						]
+					}
+				}
+			}`),
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiKey:     "test-key",
+		endpoint:   server.URL,
+	}
+
+	resp, err := client.ListComments(context.Background(), "ENG-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Issue == nil {
+		t.Fatal("Expected the issue to be returned")
+	}
+	comments := resp.Issue.Comments.Nodes
+	if len(comments) != 1 {
+		t.Fatalf("Expected 1 comment, got %d", len(comments))
+	}
+	if comments[0].Body != "Looking into it" || comments[0].User.Name != "Bob" {
+		t.Errorf("Unexpected comment %+v", comments[0])
+	}
+}
+
+func TestClient_ListAllComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		filter, _ := json.Marshal(req.Variables["filter"])
+		if string(filter) != `{"createdAt":{"gte":"-P1D"},"user":{"id":{"eq":"user-1"}}}` {
+			t.Errorf("Unexpected filter %s", filter)
+		}
+
+		json.NewEncoder(w).Encode(graphQLResponse{
+			Data: json.RawMessage(`{
+				"comments": {
+					"nodes": [
+						{"id": "comment-1", "body": "Done", "user": {"id": "user-1"}, "issue": {"identifier": "ENG-1", "title": "Fix login"}}
+					],
+					"pageInfo": {"hasNextPage": false}
+				}
+			}`),
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiKey:     "test-key",
+		endpoint:   server.URL,
+	}
+
+	filter := CommentFilter{}.User(UserFilter{}.ID(Eq("user-1"))).CreatedAt(Gte("-P1D"))
+	comments, err := client.ListAllComments(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(comments) != 1 || comments[0].Issue == nil || comments[0].Issue.Identifier != "ENG-1" {
+		t.Errorf("Unexpected comments %+v", comments)
+	}
+}
@@ -0,0 +1,27 @@
+// Package linear is a Go client for the Linear GraphQL API
+// (https://developers.linear.app).
+//
+// Create a client with a personal API key, or with WithTokenSource for
+// OAuth applications, then call its methods:
+//
+//	client, err := linear.NewClient(linear.WithAPIKey(os.Getenv("LINEAR_API_KEY")))
+//	if err != nil {
+//		return err
+//	}
+//	issues, err := client.ListIssues(ctx, linear.ListIssuesOptions{TeamKey: "ENG", Limit: 20})
+//
+// Queries not covered by a method can be sent with Client.Do.
+//
+// Errors for missing resources match ErrNotFound, and identifiers that match
+// several resources match ErrAmbiguous, with errors.Is; ambiguous project
+// names return an *AmbiguousProjectError listing the candidates. Rejected
+// requests return an *APIError describing the HTTP status and GraphQL error
+// code.
+//
+// # Compatibility
+//
+// This package follows semantic versioning. Within a major version, exported
+// identifiers are not removed or changed incompatibly. New methods, options
+// and struct fields may be added in minor releases, so construct structs with
+// field names and do not rely on comparing error strings.
+package linear
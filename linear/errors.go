@@ -0,0 +1,103 @@
+package linear
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound is matched by errors for issues, teams, projects or users
+	// that do not exist
+	ErrNotFound = errors.New("not found")
+	// ErrAmbiguous is matched by errors for identifiers that match more
+	// than one resource
+	ErrAmbiguous = errors.New("ambiguous identifier")
+)
+
+// APIError is returned when the Linear API rejects a request, either with
+// a non-200 HTTP status or with GraphQL errors
+type APIError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Code is the GraphQL error code from extensions.code (e.g. RATELIMITED)
+	Code string
+	// Message is the first GraphQL error message, if any
+	Message string
+	// Body is the raw response body for non-200 responses without
+	// GraphQL errors
+	Body string
+	// RetryAfter is the delay requested by a Retry-After header, if any
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// IsRateLimited reports whether the request was rejected by rate limiting
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.Code == "RATELIMITED"
+}
+
+// IsAuthError reports whether the request was rejected because the API key
+// is missing, invalid or lacks permission
+func (e *APIError) IsAuthError() bool {
+	return e.StatusCode == http.StatusUnauthorized ||
+		e.StatusCode == http.StatusForbidden ||
+		e.Code == "AUTHENTICATION_ERROR" ||
+		e.Code == "FORBIDDEN"
+}
+
+// IsNotFound reports whether the API could not find the requested entity
+func (e *APIError) IsNotFound() bool {
+	return e.Code == "ENTITY_NOT_FOUND" || strings.HasPrefix(e.Message, "Entity not found")
+}
+
+// newAPIError builds an APIError from a response, picking up the first
+// GraphQL error message and code when the body contains them
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+
+	var resp struct {
+		Errors []struct {
+			Message    string `json:"message"`
+			Extensions struct {
+				Code string `json:"code"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err == nil && len(resp.Errors) > 0 {
+		apiErr.Message = resp.Errors[0].Message
+		apiErr.Code = resp.Errors[0].Extensions.Code
+		return apiErr
+	}
+
+	apiErr.Body = string(body)
+	return apiErr
+}
+
+// kindError is an error with its own message that also matches one of the
+// sentinel errors above with errors.Is
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+func notFoundf(format string, args ...any) error {
+	return &kindError{kind: ErrNotFound, msg: fmt.Sprintf(format, args...)}
+}
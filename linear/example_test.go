@@ -0,0 +1,277 @@
+package linear_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/dukky/linear/linear"
+)
+
+// exampleResponses maps a substring of the GraphQL document to the data the
+// example server returns for it, checked in order
+var exampleResponses = []struct {
+	match string
+	data  string
+}{
+	{"issueCreate", `{"issueCreate":{"success":true,"issue":{"id":"issue-2","identifier":"ENG-2","title":"Fix login redirect","url":"https://linear.app/acme/issue/ENG-2"}}}`},
+	{"issueUpdate", `{"issueUpdate":{"success":true,"issue":{"id":"issue-1","identifier":"ENG-1","title":"Ship the SDK","url":"https://linear.app/acme/issue/ENG-1"}}}`},
+	{"issue(id", `{"issue":{"id":"issue-1","identifier":"ENG-1","title":"Ship the SDK","priorityLabel":"High","state":{"name":"In Progress","type":"started"}}}`},
+	{"issues(", `{"issues":{"nodes":[{"id":"issue-1","identifier":"ENG-1","title":"Ship the SDK"},{"id":"issue-2","identifier":"ENG-2","title":"Fix login redirect"}],"pageInfo":{"hasNextPage":false}}}`},
+	{"teams", `{"teams":{"nodes":[{"id":"team-1","key":"ENG","name":"Engineering"}]}}`},
+	{"projects", `{"projects":{"nodes":[{"id":"project-1","name":"Mobile App"}]}}`},
+	{"users", `{"users":{"nodes":[{"id":"user-1","name":"Ada Lovelace","email":"ada@example.com"}]}}`},
+	{"viewer", `{"viewer":{"id":"user-1","name":"Ada Lovelace"}}`},
+}
+
+// newExampleClient returns a client talking to a fake Linear API
+func newExampleClient() (*linear.Client, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, resp := range exampleResponses {
+			if strings.Contains(req.Query, resp.match) {
+				fmt.Fprintf(w, `{"data":%s}`, resp.data)
+				return
+			}
+		}
+		fmt.Fprint(w, `{"errors":[{"message":"Unknown query"}]}`)
+	}))
+
+	client, err := linear.NewClient(
+		linear.WithAPIKey("lin_api_example"),
+		linear.WithEndpoint(server.URL),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return client, server.Close
+}
+
+func ExampleNewClient() {
+	client, err := linear.NewClient(
+		linear.WithAPIKey("lin_api_example"),
+		linear.WithUserAgent("my-service/1.0"),
+		linear.WithRetryPolicy(linear.RetryPolicy{MaxAttempts: 5}),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(client != nil)
+	// Output: true
+}
+
+func ExampleNewClient_tokenSource() {
+	// OAuth applications pass a TokenSource; its tokens are sent as Bearer
+	// credentials and it is consulted before every request
+	_, err := linear.NewClient(linear.WithTokenSource(linear.StaticTokenSource("oauth-access-token")))
+	fmt.Println(err)
+
+	_, err = linear.NewClient()
+	fmt.Println(errors.Is(err, linear.ErrNoCredentials))
+	// Output:
+	// <nil>
+	// true
+}
+
+func ExampleClient_Do() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	var result struct {
+		Viewer struct {
+			Name string `json:"name"`
+		} `json:"viewer"`
+	}
+	if err := client.Do(context.Background(), `query { viewer { id name } }`, nil, &result); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(result.Viewer.Name)
+	// Output: Ada Lovelace
+}
+
+func ExampleClient_ListIssues() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	resp, err := client.ListIssues(context.Background(), linear.ListIssuesOptions{TeamKey: "ENG", Limit: 20})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, issue := range resp.Issues.Nodes {
+		fmt.Println(issue.Identifier, issue.Title)
+	}
+	// Output:
+	// ENG-1 Ship the SDK
+	// ENG-2 Fix login redirect
+}
+
+func ExampleIssueFilter() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	// Urgent or high priority bugs in ENG that are not done
+	filter := linear.IssueFilter{}.
+		Team(linear.TeamFilter{}.Key(linear.Eq("ENG"))).
+		Priority(linear.In(1, 2)).
+		Labels(linear.Some(linear.LabelFilter{}.Name(linear.EqIgnoreCase("bug")))).
+		State(linear.StateFilter{}.Type(linear.Nin("completed", "canceled")))
+
+	resp, err := client.ListIssues(context.Background(), linear.ListIssuesOptions{Filter: filter})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(len(resp.Issues.Nodes), "issues")
+	// Output:
+	// 2 issues
+}
+
+func ExampleClient_ListAllIssues() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	issues, err := client.ListAllIssues(context.Background(), linear.ListIssuesOptions{TeamKey: "ENG"})
+	if err != nil {
+		// issues holds the pages fetched before the error
+		log.Fatal(err)
+	}
+	fmt.Println(len(issues), "issues")
+	// Output: 2 issues
+}
+
+func ExampleClient_GetIssue() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	resp, err := client.GetIssue(context.Background(), "ENG-1")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s: %s [%s]\n", resp.Issue.Identifier, resp.Issue.Title, resp.Issue.State.Name)
+	// Output: ENG-1: Ship the SDK [In Progress]
+}
+
+func ExampleClient_CreateIssue() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	resp, err := client.CreateIssue(context.Background(), linear.CreateIssueInput{
+		Title:  "Fix login redirect",
+		TeamID: "team-1",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(resp.IssueCreate.Issue.Identifier, resp.IssueCreate.Issue.URL)
+	// Output: ENG-2 https://linear.app/acme/issue/ENG-2
+}
+
+func ExampleClient_UpdateIssue() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	priority := 2
+	resp, err := client.UpdateIssue(context.Background(), "ENG-1", linear.UpdateIssueInput{Priority: &priority})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(resp.IssueUpdate.Success)
+	// Output: true
+}
+
+func ExampleClient_ListTeams() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	resp, err := client.ListTeams(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, team := range resp.Teams.Nodes {
+		fmt.Println(team.Key, team.Name)
+	}
+	// Output: ENG Engineering
+}
+
+func ExampleClient_GetTeamByKey() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	resp, err := client.GetTeamByKey(context.Background(), "ENG")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(resp.Teams.Nodes[0].ID)
+	// Output: team-1
+}
+
+func ExampleClient_ListProjects() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	resp, err := client.ListProjects(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, project := range resp.Projects.Nodes {
+		fmt.Println(project.Name)
+	}
+	// Output: Mobile App
+}
+
+func ExampleClient_GetProjectByIdentifier() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	project, err := client.GetProjectByIdentifier(context.Background(), "mobile app", "team-1")
+	switch {
+	case errors.Is(err, linear.ErrNotFound):
+		fmt.Println("no such project")
+	case errors.Is(err, linear.ErrAmbiguous):
+		fmt.Println("several projects match")
+	case err != nil:
+		log.Fatal(err)
+	default:
+		fmt.Println(project.ID)
+	}
+	// Output: project-1
+}
+
+func ExampleClient_GetUserByEmail() {
+	client, stop := newExampleClient()
+	defer stop()
+
+	user, err := client.GetUserByEmail(context.Background(), "ada@example.com")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(user.Name)
+	// Output: Ada Lovelace
+}
+
+func ExampleAPIError() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors":[{"message":"Authentication required","extensions":{"code":"AUTHENTICATION_ERROR"}}]}`)
+	}))
+	defer server.Close()
+
+	client, _ := linear.NewClient(linear.WithAPIKey("lin_api_revoked"), linear.WithEndpoint(server.URL))
+
+	_, err := client.ListTeams(context.Background())
+	var apiErr *linear.APIError
+	if errors.As(err, &apiErr) {
+		fmt.Println(apiErr.Code, apiErr.IsAuthError())
+	}
+	// Output: AUTHENTICATION_ERROR true
+}
@@ -0,0 +1,511 @@
+package linear
+
+import (
+	"encoding/json"
+	"maps"
+)
+
+// Comparator is a condition on a scalar field, such as Eq("ENG") or
+// Gt("-P2W"). Field methods on filters accept several comparators, which
+// must all hold
+//
+// Dates may be given as a time.Time, an ISO 8601 date or timestamp, or a
+// duration relative to now such as "-P2W" (two weeks ago)
+type Comparator struct {
+	op    string
+	value any
+}
+
+// Eq matches values equal to v
+func Eq(v any) Comparator { return Comparator{"eq", v} }
+
+// Neq matches values not equal to v
+func Neq(v any) Comparator { return Comparator{"neq", v} }
+
+// In matches values equal to one of values
+func In[T any](values ...T) Comparator { return Comparator{"in", values} }
+
+// Nin matches values equal to none of values
+func Nin[T any](values ...T) Comparator { return Comparator{"nin", values} }
+
+// Null matches missing values when isNull is true, and present ones otherwise
+func Null(isNull bool) Comparator { return Comparator{"null", isNull} }
+
+// Gt matches values greater than, or dates after, v
+func Gt(v any) Comparator { return Comparator{"gt", v} }
+
+// Gte matches values greater than or equal to v
+func Gte(v any) Comparator { return Comparator{"gte", v} }
+
+// Lt matches values less than, or dates before, v
+func Lt(v any) Comparator { return Comparator{"lt", v} }
+
+// Lte matches values less than or equal to v
+func Lte(v any) Comparator { return Comparator{"lte", v} }
+
+// EqIgnoreCase matches strings equal to s, ignoring case
+func EqIgnoreCase(s string) Comparator { return Comparator{"eqIgnoreCase", s} }
+
+// NeqIgnoreCase matches strings not equal to s, ignoring case
+func NeqIgnoreCase(s string) Comparator { return Comparator{"neqIgnoreCase", s} }
+
+// Contains matches strings containing s
+func Contains(s string) Comparator { return Comparator{"contains", s} }
+
+// ContainsIgnoreCase matches strings containing s, ignoring case
+func ContainsIgnoreCase(s string) Comparator { return Comparator{"containsIgnoreCase", s} }
+
+// NotContains matches strings that do not contain s
+func NotContains(s string) Comparator { return Comparator{"notContains", s} }
+
+// NotContainsIgnoreCase matches strings that do not contain s, ignoring
+// case
+func NotContainsIgnoreCase(s string) Comparator { return Comparator{"notContainsIgnoreCase", s} }
+
+// StartsWith matches strings starting with s
+func StartsWith(s string) Comparator { return Comparator{"startsWith", s} }
+
+// EndsWith matches strings ending with s
+func EndsWith(s string) Comparator { return Comparator{"endsWith", s} }
+
+// conditions holds the fields of a filter as they are sent to the API.
+// Filters are immutable: every builder method returns a modified copy
+type conditions struct {
+	fields map[string]any
+}
+
+// MarshalJSON encodes the filter as a Linear filter object
+func (c conditions) MarshalJSON() ([]byte, error) {
+	if c.fields == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(c.fields)
+}
+
+// Empty reports whether the filter has no conditions and so matches
+// everything
+func (c conditions) Empty() bool {
+	return len(c.fields) == 0
+}
+
+func (c conditions) filterConditions() conditions {
+	return c
+}
+
+// with returns a copy of c with key set to value
+func (c conditions) with(key string, value any) conditions {
+	fields := maps.Clone(c.fields)
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	fields[key] = value
+	return conditions{fields}
+}
+
+// compare returns a copy of c with the comparators set on field
+func (c conditions) compare(field string, comparators []Comparator) conditions {
+	value := make(map[string]any, len(comparators))
+	for _, comparator := range comparators {
+		value[comparator.op] = comparator.value
+	}
+	return c.with(field, value)
+}
+
+// combine returns a copy of c with filters appended to its "and" or "or"
+// list
+func combine[F filter](c conditions, op string, filters []F) conditions {
+	list, _ := c.fields[op].([]conditions)
+	list = append([]conditions(nil), list...)
+	for _, f := range filters {
+		list = append(list, f.filterConditions())
+	}
+	return c.with(op, list)
+}
+
+// filter is implemented by every typed filter
+type filter interface {
+	filterConditions() conditions
+}
+
+// Collection is a condition on a to-many relation, such as an issue's
+// labels, built with Some or Every
+type Collection[F filter] struct {
+	conditions
+}
+
+// Some matches collections with at least one item matching f
+func Some[F filter](f F) Collection[F] {
+	return Collection[F]{conditions{}.with("some", f.filterConditions())}
+}
+
+// Every matches collections whose items all match f
+func Every[F filter](f F) Collection[F] {
+	return Collection[F]{conditions{}.with("every", f.filterConditions())}
+}
+
+// IssueFilter selects issues. The zero value matches every issue
+//
+//	linear.IssueFilter{}.
+//		Team(linear.TeamFilter{}.Key(linear.Eq("ENG"))).
+//		Priority(linear.Gte(1), linear.Lte(2)).
+//		Labels(linear.Some(linear.LabelFilter{}.Name(linear.EqIgnoreCase("bug"))))
+type IssueFilter struct {
+	conditions
+}
+
+// ID filters on the issue ID
+func (f IssueFilter) ID(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("id", c)}
+}
+
+// Number filters on the issue number within its team (the 123 in ENG-123)
+func (f IssueFilter) Number(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("number", c)}
+}
+
+// Title filters on the issue title
+func (f IssueFilter) Title(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("title", c)}
+}
+
+// Description filters on the issue description
+func (f IssueFilter) Description(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("description", c)}
+}
+
+// Priority filters on the priority, from 0 (none) and 1 (urgent) to 4 (low)
+func (f IssueFilter) Priority(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("priority", c)}
+}
+
+// Estimate filters on the estimate
+func (f IssueFilter) Estimate(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("estimate", c)}
+}
+
+// DueDate filters on the due date
+func (f IssueFilter) DueDate(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("dueDate", c)}
+}
+
+// CreatedAt filters on the creation time
+func (f IssueFilter) CreatedAt(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("createdAt", c)}
+}
+
+// UpdatedAt filters on the last update time
+func (f IssueFilter) UpdatedAt(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("updatedAt", c)}
+}
+
+// StartedAt filters on when the issue moved to a started state
+func (f IssueFilter) StartedAt(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("startedAt", c)}
+}
+
+// CompletedAt filters on when the issue was completed
+func (f IssueFilter) CompletedAt(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("completedAt", c)}
+}
+
+// CanceledAt filters on when the issue was canceled
+func (f IssueFilter) CanceledAt(c ...Comparator) IssueFilter {
+	return IssueFilter{f.compare("canceledAt", c)}
+}
+
+// Team filters on the issue's team
+func (f IssueFilter) Team(team TeamFilter) IssueFilter {
+	return IssueFilter{f.with("team", team)}
+}
+
+// State filters on the issue's workflow state
+func (f IssueFilter) State(state StateFilter) IssueFilter {
+	return IssueFilter{f.with("state", state)}
+}
+
+// Assignee filters on the assignee; UserFilter{}.Null(true) matches
+// unassigned issues
+func (f IssueFilter) Assignee(user UserFilter) IssueFilter {
+	return IssueFilter{f.with("assignee", user)}
+}
+
+// Creator filters on the user who created the issue
+func (f IssueFilter) Creator(user UserFilter) IssueFilter {
+	return IssueFilter{f.with("creator", user)}
+}
+
+// Project filters on the issue's project; ProjectFilter{}.Null(true)
+// matches issues without one
+func (f IssueFilter) Project(project ProjectFilter) IssueFilter {
+	return IssueFilter{f.with("project", project)}
+}
+
+// Parent filters on the parent issue; IssueFilter{}.Null(true) matches
+// top-level issues
+func (f IssueFilter) Parent(parent IssueFilter) IssueFilter {
+	return IssueFilter{f.with("parent", parent)}
+}
+
+// Labels filters on the issue's labels
+func (f IssueFilter) Labels(labels Collection[LabelFilter]) IssueFilter {
+	return IssueFilter{f.with("labels", labels)}
+}
+
+// Children filters on the issue's sub-issues
+func (f IssueFilter) Children(children Collection[IssueFilter]) IssueFilter {
+	return IssueFilter{f.with("children", children)}
+}
+
+// Null matches a missing relation when isNull is true, e.g. issues without
+// a parent when passed to Parent
+func (f IssueFilter) Null(isNull bool) IssueFilter {
+	return IssueFilter{f.with("null", isNull)}
+}
+
+// And returns a filter matching f and all of filters
+func (f IssueFilter) And(filters ...IssueFilter) IssueFilter {
+	return IssueFilter{combine(f.conditions, "and", filters)}
+}
+
+// Or returns a filter matching f and at least one of filters
+func (f IssueFilter) Or(filters ...IssueFilter) IssueFilter {
+	return IssueFilter{combine(f.conditions, "or", filters)}
+}
+
+// ProjectFilter selects projects. The zero value matches every project
+type ProjectFilter struct {
+	conditions
+}
+
+// ID filters on the project ID
+func (f ProjectFilter) ID(c ...Comparator) ProjectFilter {
+	return ProjectFilter{f.compare("id", c)}
+}
+
+// Name filters on the project name
+func (f ProjectFilter) Name(c ...Comparator) ProjectFilter {
+	return ProjectFilter{f.compare("name", c)}
+}
+
+// State filters on the project state (e.g. "planned", "started")
+func (f ProjectFilter) State(c ...Comparator) ProjectFilter {
+	return ProjectFilter{f.compare("state", c)}
+}
+
+// StartDate filters on the planned start date
+func (f ProjectFilter) StartDate(c ...Comparator) ProjectFilter {
+	return ProjectFilter{f.compare("startDate", c)}
+}
+
+// TargetDate filters on the target date
+func (f ProjectFilter) TargetDate(c ...Comparator) ProjectFilter {
+	return ProjectFilter{f.compare("targetDate", c)}
+}
+
+// CreatedAt filters on the creation time
+func (f ProjectFilter) CreatedAt(c ...Comparator) ProjectFilter {
+	return ProjectFilter{f.compare("createdAt", c)}
+}
+
+// UpdatedAt filters on the last update time
+func (f ProjectFilter) UpdatedAt(c ...Comparator) ProjectFilter {
+	return ProjectFilter{f.compare("updatedAt", c)}
+}
+
+// Lead filters on the project lead
+func (f ProjectFilter) Lead(user UserFilter) ProjectFilter {
+	return ProjectFilter{f.with("lead", user)}
+}
+
+// AccessibleTeams filters on the teams that can see the project
+func (f ProjectFilter) AccessibleTeams(teams Collection[TeamFilter]) ProjectFilter {
+	return ProjectFilter{f.with("accessibleTeams", teams)}
+}
+
+// Null matches a missing relation when isNull is true, e.g. issues without
+// a project when passed to IssueFilter.Project
+func (f ProjectFilter) Null(isNull bool) ProjectFilter {
+	return ProjectFilter{f.with("null", isNull)}
+}
+
+// And returns a filter matching f and all of filters
+func (f ProjectFilter) And(filters ...ProjectFilter) ProjectFilter {
+	return ProjectFilter{combine(f.conditions, "and", filters)}
+}
+
+// Or returns a filter matching f and at least one of filters
+func (f ProjectFilter) Or(filters ...ProjectFilter) ProjectFilter {
+	return ProjectFilter{combine(f.conditions, "or", filters)}
+}
+
+// TeamFilter selects teams
+type TeamFilter struct {
+	conditions
+}
+
+// ID filters on the team ID
+func (f TeamFilter) ID(c ...Comparator) TeamFilter {
+	return TeamFilter{f.compare("id", c)}
+}
+
+// Key filters on the team key (e.g. "ENG")
+func (f TeamFilter) Key(c ...Comparator) TeamFilter {
+	return TeamFilter{f.compare("key", c)}
+}
+
+// Name filters on the team name
+func (f TeamFilter) Name(c ...Comparator) TeamFilter {
+	return TeamFilter{f.compare("name", c)}
+}
+
+// And returns a filter matching f and all of filters
+func (f TeamFilter) And(filters ...TeamFilter) TeamFilter {
+	return TeamFilter{combine(f.conditions, "and", filters)}
+}
+
+// Or returns a filter matching f and at least one of filters
+func (f TeamFilter) Or(filters ...TeamFilter) TeamFilter {
+	return TeamFilter{combine(f.conditions, "or", filters)}
+}
+
+// UserFilter selects users
+type UserFilter struct {
+	conditions
+}
+
+// ID filters on the user ID
+func (f UserFilter) ID(c ...Comparator) UserFilter {
+	return UserFilter{f.compare("id", c)}
+}
+
+// Name filters on the full name
+func (f UserFilter) Name(c ...Comparator) UserFilter {
+	return UserFilter{f.compare("name", c)}
+}
+
+// DisplayName filters on the display name
+func (f UserFilter) DisplayName(c ...Comparator) UserFilter {
+	return UserFilter{f.compare("displayName", c)}
+}
+
+// Email filters on the email address
+func (f UserFilter) Email(c ...Comparator) UserFilter {
+	return UserFilter{f.compare("email", c)}
+}
+
+// IsMe matches the authenticated user with Eq(true)
+func (f UserFilter) IsMe(c ...Comparator) UserFilter {
+	return UserFilter{f.compare("isMe", c)}
+}
+
+// Null matches a missing user when isNull is true, e.g. unassigned issues
+// when passed to IssueFilter.Assignee
+func (f UserFilter) Null(isNull bool) UserFilter {
+	return UserFilter{f.with("null", isNull)}
+}
+
+// And returns a filter matching f and all of filters
+func (f UserFilter) And(filters ...UserFilter) UserFilter {
+	return UserFilter{combine(f.conditions, "and", filters)}
+}
+
+// Or returns a filter matching f and at least one of filters
+func (f UserFilter) Or(filters ...UserFilter) UserFilter {
+	return UserFilter{combine(f.conditions, "or", filters)}
+}
+
+// StateFilter selects workflow states
+type StateFilter struct {
+	conditions
+}
+
+// ID filters on the state ID
+func (f StateFilter) ID(c ...Comparator) StateFilter {
+	return StateFilter{f.compare("id", c)}
+}
+
+// Name filters on the state name (e.g. "In Progress")
+func (f StateFilter) Name(c ...Comparator) StateFilter {
+	return StateFilter{f.compare("name", c)}
+}
+
+// Type filters on the state type: "triage", "backlog", "unstarted",
+// "started", "completed" or "canceled"
+func (f StateFilter) Type(c ...Comparator) StateFilter {
+	return StateFilter{f.compare("type", c)}
+}
+
+// And returns a filter matching f and all of filters
+func (f StateFilter) And(filters ...StateFilter) StateFilter {
+	return StateFilter{combine(f.conditions, "and", filters)}
+}
+
+// Or returns a filter matching f and at least one of filters
+func (f StateFilter) Or(filters ...StateFilter) StateFilter {
+	return StateFilter{combine(f.conditions, "or", filters)}
+}
+
+// LabelFilter selects issue labels
+type LabelFilter struct {
+	conditions
+}
+
+// ID filters on the label ID
+func (f LabelFilter) ID(c ...Comparator) LabelFilter {
+	return LabelFilter{f.compare("id", c)}
+}
+
+// Name filters on the label name
+func (f LabelFilter) Name(c ...Comparator) LabelFilter {
+	return LabelFilter{f.compare("name", c)}
+}
+
+// And returns a filter matching f and all of filters
+func (f LabelFilter) And(filters ...LabelFilter) LabelFilter {
+	return LabelFilter{combine(f.conditions, "and", filters)}
+}
+
+// Or returns a filter matching f and at least one of filters
+func (f LabelFilter) Or(filters ...LabelFilter) LabelFilter {
+	return LabelFilter{combine(f.conditions, "or", filters)}
+}
+
+// CommentFilter selects comments. The zero value matches every comment
+type CommentFilter struct {
+	conditions
+}
+
+// ID filters on the comment ID
+func (f CommentFilter) ID(c ...Comparator) CommentFilter {
+	return CommentFilter{f.compare("id", c)}
+}
+
+// Body filters on the comment text
+func (f CommentFilter) Body(c ...Comparator) CommentFilter {
+	return CommentFilter{f.compare("body", c)}
+}
+
+// CreatedAt filters on the creation time
+func (f CommentFilter) CreatedAt(c ...Comparator) CommentFilter {
+	return CommentFilter{f.compare("createdAt", c)}
+}
+
+// User filters on the comment's author
+func (f CommentFilter) User(user UserFilter) CommentFilter {
+	return CommentFilter{f.with("user", user)}
+}
+
+// Issue filters on the issue commented on
+func (f CommentFilter) Issue(issue IssueFilter) CommentFilter {
+	return CommentFilter{f.with("issue", issue)}
+}
+
+// And returns a filter matching f and all of filters
+func (f CommentFilter) And(filters ...CommentFilter) CommentFilter {
+	return CommentFilter{combine(f.conditions, "and", filters)}
+}
+
+// Or returns a filter matching f and at least one of filters
+func (f CommentFilter) Or(filters ...CommentFilter) CommentFilter {
+	return CommentFilter{combine(f.conditions, "or", filters)}
+}
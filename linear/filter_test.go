@@ -0,0 +1,141 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFilter_MarshalJSON(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter any
+		want   string
+	}{
+		{
+			name:   "zero value",
+			filter: IssueFilter{},
+			want:   `{}`,
+		},
+		{
+			name:   "comparator",
+			filter: TeamFilter{}.Key(Eq("ENG")),
+			want:   `{"key":{"eq":"ENG"}}`,
+		},
+		{
+			name:   "several comparators on one field",
+			filter: IssueFilter{}.Priority(Gte(1), Lte(2)),
+			want:   `{"priority":{"gte":1,"lte":2}}`,
+		},
+		{
+			name:   "string comparators",
+			filter: ProjectFilter{}.Name(ContainsIgnoreCase("app"), NotContains("old"), StartsWith("M"), EndsWith("p")),
+			want:   `{"name":{"containsIgnoreCase":"app","endsWith":"p","notContains":"old","startsWith":"M"}}`,
+		},
+		{
+			name:   "in and nin",
+			filter: StateFilter{}.Type(In("started", "unstarted")).Name(Nin("Blocked")),
+			want:   `{"name":{"nin":["Blocked"]},"type":{"in":["started","unstarted"]}}`,
+		},
+		{
+			name:   "dates",
+			filter: IssueFilter{}.CreatedAt(Gt(since)).UpdatedAt(Lt("-P2W")).DueDate(Null(false)),
+			want:   `{"createdAt":{"gt":"2025-01-02T03:04:05Z"},"dueDate":{"null":false},"updatedAt":{"lt":"-P2W"}}`,
+		},
+		{
+			name:   "relations",
+			filter: IssueFilter{}.Team(TeamFilter{}.Key(Eq("ENG"))).Assignee(UserFilter{}.Null(true)).Project(ProjectFilter{}.ID(Eq("p1"))),
+			want:   `{"assignee":{"null":true},"project":{"id":{"eq":"p1"}},"team":{"key":{"eq":"ENG"}}}`,
+		},
+		{
+			name:   "collections",
+			filter: IssueFilter{}.Labels(Some(LabelFilter{}.Name(EqIgnoreCase("bug")))).Children(Every(IssueFilter{}.State(StateFilter{}.Type(Eq("completed"))))),
+			want:   `{"children":{"every":{"state":{"type":{"eq":"completed"}}}},"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}}`,
+		},
+		{
+			name:   "accessible teams",
+			filter: ProjectFilter{}.AccessibleTeams(Some(TeamFilter{}.ID(Eq("t1")))),
+			want:   `{"accessibleTeams":{"some":{"id":{"eq":"t1"}}}}`,
+		},
+		{
+			name: "and or",
+			filter: IssueFilter{}.Team(TeamFilter{}.Key(Eq("ENG"))).Or(
+				IssueFilter{}.Priority(Eq(1)),
+				IssueFilter{}.Labels(Some(LabelFilter{}.Name(Eq("bug")))),
+			).And(IssueFilter{}.Title(Neq("x"))),
+			want: `{"and":[{"title":{"neq":"x"}}],"or":[{"priority":{"eq":1}},{"labels":{"some":{"name":{"eq":"bug"}}}}],"team":{"key":{"eq":"ENG"}}}`,
+		},
+		{
+			name:   "later field replaces earlier",
+			filter: UserFilter{}.Email(Eq("a@example.com")).Email(Eq("b@example.com")),
+			want:   `{"email":{"eq":"b@example.com"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.filter)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter_Immutable(t *testing.T) {
+	base := IssueFilter{}.Team(TeamFilter{}.Key(Eq("ENG")))
+	or := base.Or(IssueFilter{}.Priority(Eq(1)))
+	_ = base.Title(Eq("changed"))
+	_ = or.Or(IssueFilter{}.Priority(Eq(2)))
+
+	if got, _ := json.Marshal(base); string(got) != `{"team":{"key":{"eq":"ENG"}}}` {
+		t.Errorf("base filter was modified: %s", got)
+	}
+	if got, _ := json.Marshal(or); string(got) != `{"or":[{"priority":{"eq":1}}],"team":{"key":{"eq":"ENG"}}}` {
+		t.Errorf("or filter was modified: %s", got)
+	}
+}
+
+func TestFilter_Empty(t *testing.T) {
+	if !(IssueFilter{}).Empty() {
+		t.Error("zero IssueFilter should be empty")
+	}
+	if (IssueFilter{}).Title(Eq("x")).Empty() {
+		t.Error("filter with a condition should not be empty")
+	}
+}
+
+func TestListIssues_CombinesFilterWithTeamAndProject(t *testing.T) {
+	opts := ListIssuesOptions{
+		Filter:    IssueFilter{}.Priority(Lte(2)).Team(TeamFilter{}.Key(Eq("OPS"))),
+		TeamKey:   "ENG",
+		ProjectID: "p1",
+	}
+	var req struct {
+		Variables map[string]json.RawMessage `json:"variables"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Write([]byte(`{"data":{"issues":{"nodes":[],"pageInfo":{"hasNextPage":false}}}}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("lin_api_test"), WithEndpoint(server.URL))
+	if _, err := client.ListIssues(context.Background(), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	got := req.Variables["filter"]
+	want := `{"priority":{"lte":2},"project":{"id":{"eq":"p1"}},"team":{"key":{"eq":"ENG"}}}`
+	if string(got) != want {
+		t.Errorf("filter = %s\nwant %s", got, want)
+	}
+}
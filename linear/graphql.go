@@ -0,0 +1,180 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// graphQLRequest represents a GraphQL request
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse represents a GraphQL response
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+		Path    []any  `json:"path,omitempty"`
+	} `json:"errors,omitempty"`
+}
+
+// Do executes a GraphQL query and unmarshals the response data into result.
+// Failed requests are retried according to the client's RetryPolicy.
+func (c *Client) Do(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	reqBody := graphQLRequest{
+		Query:     query,
+		Variables: variables,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	mutation := IsMutation(query)
+	attempts := c.retry.attempts()
+	for attempt := 1; ; attempt++ {
+		err = c.do(ctx, jsonBody, result)
+		if err == nil || attempt >= attempts || !retryable(err, mutation) {
+			return err
+		}
+		if sleepErr := sleep(ctx, c.retry.backoff(attempt, err)); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+// do sends a single request
+func (c *Client) do(ctx context.Context, jsonBody []byte, result interface{}) error {
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+
+	authorization, err := c.authorization(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := newAPIError(resp.StatusCode, body)
+		apiErr.RetryAfter = parseRetryAfter(resp.Header)
+		return apiErr
+	}
+
+	var gqlResp graphQLResponse
+	if err := json.Unmarshal(body, &gqlResp); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(gqlResp.Errors) > 0 {
+		return newAPIError(resp.StatusCode, body)
+	}
+
+	if result != nil && len(gqlResp.Data) > 0 {
+		if err := json.Unmarshal(gqlResp.Data, result); err != nil {
+			return fmt.Errorf("failed to unmarshal data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// IsMutation reports whether a GraphQL document defines a mutation. It
+// looks past comments, strings and fragment definitions at the operation
+// keyword of each definition, which is case-sensitive like GraphQL.
+func IsMutation(document string) bool {
+	depth := 0
+	// atDefinition is set where a new definition may start
+	atDefinition := true
+	for i := 0; i < len(document); i++ {
+		switch c := document[i]; {
+		case c == '#':
+			for i < len(document) && document[i] != '\n' && document[i] != '\r' {
+				i++
+			}
+		case c == '"':
+			i = skipString(document, i)
+		case c == '{' || c == '(' || c == '[':
+			// A definition starting with { is a query
+			atDefinition = false
+			depth++
+		case c == '}' || c == ')' || c == ']':
+			depth--
+			if depth == 0 && c == '}' {
+				atDefinition = true
+			}
+		case isNameStart(c):
+			start := i
+			for i+1 < len(document) && isNameChar(document[i+1]) {
+				i++
+			}
+			if depth == 0 && atDefinition {
+				if document[start:i+1] == "mutation" {
+					return true
+				}
+				atDefinition = false
+			}
+		}
+	}
+	return false
+}
+
+// skipString returns the index of the closing quote of the string or block
+// string starting at document[i]
+func skipString(document string, i int) int {
+	if strings.HasPrefix(document[i:], `"""`) {
+		end := strings.Index(document[i+3:], `"""`)
+		if end < 0 {
+			return len(document)
+		}
+		return i + 3 + end + 2
+	}
+	for i++; i < len(document); i++ {
+		switch document[i] {
+		case '\\':
+			i++
+		case '"', '\n':
+			return i
+		}
+	}
+	return i
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -1,8 +1,10 @@
-package client
+package linear
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -246,3 +248,105 @@ func TestClient_Do_NilResult(t *testing.T) {
 		t.Errorf("Expected no error with nil result, got %v", err)
 	}
 }
+
+func TestClient_Do_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors":[{"message":"Rate limit exceeded","extensions":{"code":"RATELIMITED"}}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiKey:     "test-api-key",
+		endpoint:   server.URL,
+	}
+
+	err := client.Do(context.Background(), "query { test }", nil, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != "RATELIMITED" {
+		t.Errorf("Unexpected APIError: %+v", apiErr)
+	}
+	if !apiErr.IsRateLimited() {
+		t.Error("Expected IsRateLimited to be true")
+	}
+	if err.Error() != "Rate limit exceeded" {
+		t.Errorf("Expected GraphQL message as error, got '%s'", err.Error())
+	}
+}
+
+func TestClient_Do_HTTPErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("Unauthorized"))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiKey:     "test-api-key",
+		endpoint:   server.URL,
+	}
+
+	err := client.Do(context.Background(), "query { test }", nil, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an APIError, got %v", err)
+	}
+	if !apiErr.IsAuthError() {
+		t.Error("Expected IsAuthError to be true")
+	}
+	if err.Error() != "unexpected status 401: Unauthorized" {
+		t.Errorf("Unexpected error message: '%s'", err.Error())
+	}
+}
+
+func TestClient_Do_RequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Drain the body so the server notices when the client gives up
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{},
+		apiKey:     "test-api-key",
+		endpoint:   server.URL,
+	}
+	WithRequestTimeout(20 * time.Millisecond)(client)
+
+	err := client.Do(context.Background(), "query { test }", nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestIsMutation(t *testing.T) {
+	tests := []struct {
+		document string
+		want     bool
+	}{
+		{"mutation { issueArchive(id: \"x\") { success } }", true},
+		{"  mutation Archive($id: String!) { issueArchive(id: $id) { success } }", true},
+		{"# mutation in a comment\nquery { viewer { id } }", false},
+		{"# Archive it\nmutation { issueArchive(id: \"x\") { success } }", true},
+		{"fragment F on Issue { id }\nmutation { issueUpdate(id: \"x\", input: {}) { issue { ...F } } }", true},
+		{"fragment mutation on Issue { id }\nquery { issue(id: \"x\") { ...mutation } }", false},
+		{"query($f: IssueFilter = {title: {eq: \"}\"}}) { issues(filter: $f) { nodes { id } } }\nmutation { issueArchive(id: \"x\") { success } }", true},
+		{"{ mutation: viewer { id } }", false},
+		{"query { viewer { id } }", false},
+		{"Mutation { issueArchive(id: \"x\") { success } }", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsMutation(tt.document); got != tt.want {
+			t.Errorf("IsMutation(%q) = %v, want %v", tt.document, got, tt.want)
+		}
+	}
+}
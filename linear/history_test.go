@@ -0,0 +1,57 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_ListAllIssuesWithHistory(t *testing.T) {
+	var afters []any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		afters = append(afters, req.Variables["after"])
+		if req.Variables["first"] != float64(50) {
+			t.Errorf("Expected pages of 50, got %v", req.Variables["first"])
+		}
+		if !strings.Contains(req.Query, "history(first: 100, orderBy: createdAt)") {
+			t.Error("Expected the newest changes to be fetched")
+		}
+		if req.Variables["filter"] == nil {
+			t.Error("Expected the team filter to be sent")
+		}
+
+		data := `{"issues": {"nodes": [{"identifier": "ENG-1", "startedAt": "2025-09-01T10:00:00Z", "history": {"nodes": [
+			{"id": "h-1", "createdAt": "2025-09-01T10:00:00Z", "actor": {"name": "Ada"}, "fromState": {"name": "Todo", "type": "unstarted"}, "toState": {"name": "In Progress", "type": "started"}}
+		]}}], "pageInfo": {"hasNextPage": true, "endCursor": "cursor-1"}}}`
+		if len(afters) > 1 {
+			data = `{"issues": {"nodes": [{"identifier": "ENG-2", "history": {"nodes": []}}], "pageInfo": {"hasNextPage": false}}}`
+		}
+		json.NewEncoder(w).Encode(graphQLResponse{Data: json.RawMessage(data)})
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: &http.Client{}, apiKey: "test-key", endpoint: server.URL}
+
+	issues, err := client.ListAllIssuesWithHistory(context.Background(), ListIssuesOptions{TeamKey: "ENG", Limit: 5})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(afters) != 2 || afters[0] != nil || afters[1] != "cursor-1" {
+		t.Errorf("Expected two pages, got cursors %v", afters)
+	}
+	if len(issues) != 2 || issues[0].History == nil || len(issues[0].History.Nodes) != 1 {
+		t.Fatalf("Unexpected issues %+v", issues)
+	}
+	if issues[0].StartedAt == nil || *issues[0].StartedAt != "2025-09-01T10:00:00Z" {
+		t.Errorf("Expected the start time, got %v", issues[0].StartedAt)
+	}
+	change := issues[0].History.Nodes[0]
+	if change.FromState.Name != "Todo" || change.ToState.Type != "started" || change.Actor.Name != "Ada" {
+		t.Errorf("Unexpected state change %+v", change)
+	}
+}
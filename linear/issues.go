@@ -1,4 +1,4 @@
-package client
+package linear
 
 import (
 	"context"
@@ -13,18 +13,31 @@ type Issue struct {
 	Description   *string  `json:"description"`
 	Priority      int      `json:"priority"`
 	PriorityLabel string   `json:"priorityLabel"`
+	Estimate      *float64 `json:"estimate"`
+	DueDate       *string  `json:"dueDate"`
 	CreatedAt     string   `json:"createdAt"`
 	UpdatedAt     string   `json:"updatedAt"`
 	CompletedAt   *string  `json:"completedAt"`
-	URL           string   `json:"url"`
-	State         *State   `json:"state"`
-	Assignee      *User    `json:"assignee"`
-	Creator       *User    `json:"creator"`
-	Team          *Team    `json:"team"`
-	Project       *Project `json:"project"`
-	Labels        struct {
+	// StartedAt is when work on the issue started; only
+	// ListAllIssuesWithHistory fetches it
+	StartedAt *string `json:"startedAt,omitempty"`
+	URL       string  `json:"url"`
+	// BranchName is the git branch name Linear suggests; only GetIssue
+	// fetches it
+	BranchName string   `json:"branchName,omitempty"`
+	State      *State   `json:"state"`
+	Assignee   *User    `json:"assignee"`
+	Creator    *User    `json:"creator"`
+	Team       *Team    `json:"team"`
+	Project    *Project `json:"project"`
+	Labels     struct {
 		Nodes []Label `json:"nodes"`
 	} `json:"labels"`
+	// History holds the issue's state changes; only
+	// ListAllIssuesWithHistory fetches it
+	History *struct {
+		Nodes []IssueHistory `json:"nodes"`
+	} `json:"history,omitempty"`
 }
 
 // State represents an issue state
@@ -52,6 +65,9 @@ type Label struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
 	Color string `json:"color"`
+	// Team is set by ListLabels for labels that belong to a team rather
+	// than the workspace
+	Team *Team `json:"team,omitempty"`
 }
 
 // IssuesResponse is the response for listing issues
@@ -75,6 +91,9 @@ type IssueResponse struct {
 
 // ListIssuesOptions contains options for listing issues
 type ListIssuesOptions struct {
+	// Filter selects issues; TeamKey and ProjectID replace its team and
+	// project conditions
+	Filter    IssueFilter
 	TeamKey   string
 	ProjectID string
 	Limit     int
@@ -93,8 +112,11 @@ func (c *Client) ListIssues(ctx context.Context, opts ListIssuesOptions) (*Issue
 					description
 					priority
 					priorityLabel
+					estimate
+					dueDate
 					createdAt
 					updatedAt
+					completedAt
 					url
 					state {
 						name
@@ -131,6 +153,16 @@ func (c *Client) ListIssues(ctx context.Context, opts ListIssuesOptions) (*Issue
 		}
 	`
 
+	var resp IssuesResponse
+	if err := c.Do(ctx, query, issueVariables(opts), &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// issueVariables returns the query variables for listing issues with opts
+func issueVariables(opts ListIssuesOptions) map[string]interface{} {
 	// Default limit to 50 if not specified
 	limit := opts.Limit
 	if limit <= 0 {
@@ -145,38 +177,26 @@ func (c *Client) ListIssues(ctx context.Context, opts ListIssuesOptions) (*Issue
 		vars["after"] = opts.After
 	}
 
-	// Build filter with optional team and project constraints
-	filter := map[string]interface{}{}
+	// Narrow the filter with optional team and project constraints
+	filter := opts.Filter
 
 	if opts.TeamKey != "" {
-		filter["team"] = map[string]interface{}{
-			"key": map[string]interface{}{
-				"eq": opts.TeamKey,
-			},
-		}
+		filter = filter.Team(TeamFilter{}.Key(Eq(opts.TeamKey)))
 	}
 
 	if opts.ProjectID != "" {
-		filter["project"] = map[string]interface{}{
-			"id": map[string]interface{}{
-				"eq": opts.ProjectID,
-			},
-		}
+		filter = filter.Project(ProjectFilter{}.ID(Eq(opts.ProjectID)))
 	}
 
-	if len(filter) > 0 {
+	if !filter.Empty() {
 		vars["filter"] = filter
 	}
 
-	var resp IssuesResponse
-	if err := c.Do(ctx, query, vars, &resp); err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
+	return vars
 }
 
-// ListAllIssues retrieves all issues using cursor-based pagination
+// ListAllIssues retrieves all issues using cursor-based pagination.
+// If a page fails, the issues fetched so far are returned with the error.
 func (c *Client) ListAllIssues(ctx context.Context, opts ListIssuesOptions) ([]Issue, error) {
 	var allIssues []Issue
 	opts.Limit = 100 // Use larger page size for efficiency
@@ -184,14 +204,14 @@ func (c *Client) ListAllIssues(ctx context.Context, opts ListIssuesOptions) ([]I
 	for {
 		resp, err := c.ListIssues(ctx, opts)
 		if err != nil {
-			return nil, err
+			return allIssues, err
 		}
 
 		allIssues = append(allIssues, resp.Issues.Nodes...)
 
 		nextCursor, hasNextPage, err := nextPageCursor(opts.After, resp.Issues.PageInfo)
 		if err != nil {
-			return nil, err
+			return allIssues, err
 		}
 
 		if !hasNextPage {
@@ -204,6 +224,37 @@ func (c *Client) ListAllIssues(ctx context.Context, opts ListIssuesOptions) ([]I
 	return allIssues, nil
 }
 
+// allPages runs a query taking an $after cursor once per page of the
+// connection at field, and returns the nodes of every page
+func allPages[N any](ctx context.Context, c *Client, query, field string) ([]N, error) {
+	var all []N
+	after := ""
+	for {
+		vars := map[string]interface{}{}
+		if after != "" {
+			vars["after"] = after
+		}
+		var resp map[string]struct {
+			Nodes    []N      `json:"nodes"`
+			PageInfo PageInfo `json:"pageInfo"`
+		}
+		if err := c.Do(ctx, query, vars, &resp); err != nil {
+			return nil, err
+		}
+		page := resp[field]
+		all = append(all, page.Nodes...)
+
+		next, hasNextPage, err := nextPageCursor(after, page.PageInfo)
+		if err != nil {
+			return nil, err
+		}
+		if !hasNextPage {
+			return all, nil
+		}
+		after = next
+	}
+}
+
 func nextPageCursor(currentCursor string, pageInfo PageInfo) (string, bool, error) {
 	if !pageInfo.HasNextPage {
 		return "", false, nil
@@ -231,10 +282,13 @@ func (c *Client) GetIssue(ctx context.Context, id string) (*IssueResponse, error
 				description
 				priority
 				priorityLabel
+				estimate
+				dueDate
 				createdAt
 				updatedAt
 				completedAt
 				url
+				branchName
 				state {
 					name
 					color
@@ -342,6 +396,11 @@ type UpdateIssueInput struct {
 	Priority    *int    `json:"priority,omitempty"`
 	ProjectID   *string `json:"projectId,omitempty"`
 	AssigneeID  *string `json:"assigneeId,omitempty"`
+	StateID     *string `json:"stateId,omitempty"`
+	// AddedLabelIDs and RemovedLabelIDs change labels without replacing
+	// the others
+	AddedLabelIDs   []string `json:"addedLabelIds,omitempty"`
+	RemovedLabelIDs []string `json:"removedLabelIds,omitempty"`
 }
 
 // UpdateIssueResponse is the response for updating an issue
@@ -1,8 +1,10 @@
-package client
+package linear
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -545,6 +547,48 @@ func TestClient_ListIssues_WithPagination(t *testing.T) {
 	}
 }
 
+func TestClient_ListAllIssues_PartialResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		if callCount > 1 {
+			// Simulate Ctrl-C while the second page is in flight
+			cancel()
+			io.Copy(io.Discard, r.Body)
+			<-r.Context().Done()
+			return
+		}
+
+		json.NewEncoder(w).Encode(graphQLResponse{
+			Data: json.RawMessage(`{
+				"issues": {
+					"nodes": [{"id": "issue-1", "identifier": "TEST-1", "title": "Test Issue 1"}],
+					"pageInfo": {"hasNextPage": true, "endCursor": "cursor-1"}
+				}
+			}`),
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{},
+		apiKey:     "test-key",
+		endpoint:   server.URL,
+	}
+
+	issues, err := client.ListAllIssues(ctx, ListIssuesOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Identifier != "TEST-1" {
+		t.Errorf("Expected the first page to be returned, got %+v", issues)
+	}
+}
+
 func TestClient_ListAllIssues(t *testing.T) {
 	callCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,44 @@
+package linear
+
+import "context"
+
+// LabelsResponse is the response for listing issue labels
+type LabelsResponse struct {
+	IssueLabels struct {
+		Nodes []Label `json:"nodes"`
+	} `json:"issueLabels"`
+}
+
+// ListLabels retrieves the issue labels of the workspace and its teams,
+// from every page
+func (c *Client) ListLabels(ctx context.Context) (*LabelsResponse, error) {
+	query := `
+		query($after: String) {
+			issueLabels(first: 250, after: $after) {
+				nodes {
+					id
+					name
+					color
+					team {
+						id
+						key
+						name
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	nodes, err := allPages[Label](ctx, c, query, "issueLabels")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp LabelsResponse
+	resp.IssueLabels.Nodes = nodes
+	return &resp, nil
+}
@@ -0,0 +1,47 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_ListLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := graphQLResponse{
+			Data: json.RawMessage(`{
+				"issueLabels": {
+					"nodes": [
+						{"id": "label-1", "name": "Bug", "color": "#eb5757"},
+						{"id": "label-2", "name": "Feature", "color": "#bb87fc"}
+					]
+				}
+			}`),
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiKey:     "test-key",
+		endpoint:   server.URL,
+	}
+
+	resp, err := client.ListLabels(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	labels := resp.IssueLabels.Nodes
+	if len(labels) != 2 {
+		t.Fatalf("Expected 2 labels, got %d", len(labels))
+	}
+
+	if labels[0].Name != "Bug" {
+		t.Errorf("Expected first label to be 'Bug', got '%s'", labels[0].Name)
+	}
+}
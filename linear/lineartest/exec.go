@@ -0,0 +1,445 @@
+package lineartest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// object is a GraphQL object value. Fields resolve lazily so related
+// entities are only built when a query selects or filters on them.
+type object struct {
+	typename string
+	// fields hold scalars and lists of scalars
+	fields map[string]func() any
+	// refs hold single relations, which resolve to nil when unset
+	refs map[string]func() *object
+	// lists hold collections, exposed as connections
+	lists map[string]list
+	// methods hold fields that take arguments
+	methods map[string]func(args map[string]any) (any, error)
+}
+
+func newObject(typename string) *object {
+	return &object{
+		typename: typename,
+		fields:   map[string]func() any{},
+		refs:     map[string]func() *object{},
+		lists:    map[string]list{},
+		methods:  map[string]func(args map[string]any) (any, error){},
+	}
+}
+
+// list is a collection of objects of one type
+type list struct {
+	typename string
+	items    func() []*object
+}
+
+// id returns the object's id field, used as its pagination cursor
+func (o *object) id() string {
+	if field, ok := o.fields["id"]; ok {
+		if id, ok := field().(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// value returns a field's value, or nil when the object has no such field
+func (o *object) value(name string) any {
+	if field, ok := o.fields[name]; ok {
+		return field()
+	}
+	return nil
+}
+
+// gqlError is an error reported in the GraphQL errors array
+type gqlError struct {
+	message string
+	code    string
+	path    []any
+}
+
+func (e *gqlError) Error() string {
+	return e.message
+}
+
+func errorf(code, format string, args ...any) *gqlError {
+	return &gqlError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// notFound reports a missing entity the way the Linear API does
+func notFound(typename string) *gqlError {
+	return errorf("ENTITY_NOT_FOUND", "Entity not found: %s", typename)
+}
+
+// invalidInput reports an argument the API would reject
+func invalidInput(format string, args ...any) *gqlError {
+	return errorf("INVALID_INPUT", format, args...)
+}
+
+// orderedMap is a JSON object that keeps its keys in selection order
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: map[string]any{}}
+}
+
+func (m *orderedMap) set(key string, value any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// executor runs one operation against a root object
+type executor struct {
+	doc     *document
+	vars    map[string]any
+	matcher matcher
+}
+
+// selectOperation picks the operation to run from a document
+func (d *document) selectOperation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, fmt.Errorf("Must provide operation name if query contains multiple operations.")
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown operation named %q.", name)
+}
+
+// coerceVariables applies defaults and checks required variables
+func coerceVariables(op *operation, provided map[string]any) (map[string]any, error) {
+	vars := map[string]any{}
+	for _, def := range op.vars {
+		value, ok := provided[def.name]
+		switch {
+		case ok:
+			vars[def.name] = value
+		case def.hasDefault:
+			vars[def.name] = resolveValue(def.def, nil)
+		case strings.HasSuffix(def.typ, "!"):
+			return nil, fmt.Errorf("Variable \"$%s\" of required type %q was not provided.", def.name, def.typ)
+		}
+		if value == nil && ok && strings.HasSuffix(def.typ, "!") {
+			return nil, fmt.Errorf("Variable \"$%s\" of non-null type %q must not be null.", def.name, def.typ)
+		}
+	}
+	return vars, nil
+}
+
+// execute resolves a selection set against an object
+func (e *executor) execute(obj *object, selections []selection, path []any) (*orderedMap, error) {
+	result := newOrderedMap()
+	if err := e.collect(obj, selections, path, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// collect adds the fields of a selection set to result, expanding fragments
+func (e *executor) collect(obj *object, selections []selection, path []any, result *orderedMap) error {
+	for _, sel := range selections {
+		include, err := e.included(sel.directives)
+		if err != nil {
+			return err
+		}
+		if !include {
+			continue
+		}
+
+		switch {
+		case sel.spread != "":
+			frag, ok := e.doc.fragments[sel.spread]
+			if !ok {
+				return fmt.Errorf("Unknown fragment %q.", sel.spread)
+			}
+			if frag.typeCond == obj.typename {
+				if err := e.collect(obj, frag.selections, path, result); err != nil {
+					return err
+				}
+			}
+		case sel.inline:
+			if sel.typeCond == "" || sel.typeCond == obj.typename {
+				if err := e.collect(obj, sel.selections, path, result); err != nil {
+					return err
+				}
+			}
+		default:
+			fieldPath := append(append([]any{}, path...), sel.key())
+			value, err := e.field(obj, sel, fieldPath)
+			if err != nil {
+				return err
+			}
+			// Repeated fields, typically from fragments, merge their
+			// sub-selections
+			if existing, ok := result.values[sel.key()].(*orderedMap); ok {
+				if merged, ok := value.(*orderedMap); ok {
+					for _, key := range merged.keys {
+						existing.set(key, merged.values[key])
+					}
+					continue
+				}
+			}
+			result.set(sel.key(), value)
+		}
+	}
+	return nil
+}
+
+// included evaluates @skip and @include directives
+func (e *executor) included(directives []directive) (bool, error) {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			continue
+		}
+		args := resolveArgs(d.args, e.vars)
+		cond, ok := args["if"].(bool)
+		if !ok {
+			return false, fmt.Errorf("Directive \"@%s\" argument \"if\" of type \"Boolean!\" is required.", d.name)
+		}
+		if (d.name == "skip") == cond {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// field resolves one field selection and completes its value
+func (e *executor) field(obj *object, sel selection, path []any) (any, error) {
+	value, err := e.resolve(obj, sel)
+	if err != nil {
+		if gerr, ok := err.(*gqlError); ok && gerr.path == nil {
+			gerr.path = path
+		}
+		return nil, err
+	}
+	return e.complete(value, sel, path)
+}
+
+func (e *executor) resolve(obj *object, sel selection) (any, error) {
+	if sel.name == "__typename" {
+		return obj.typename, nil
+	}
+
+	args := resolveArgs(sel.args, e.vars)
+	if method, ok := obj.methods[sel.name]; ok {
+		return method(args)
+	}
+	if ref, ok := obj.refs[sel.name]; ok {
+		return ref(), nil
+	}
+	if l, ok := obj.lists[sel.name]; ok {
+		return connect(l.typename, l.items(), args, e.matcher)
+	}
+	if field, ok := obj.fields[sel.name]; ok {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("Unknown argument on field %q of type %q.", sel.name, obj.typename)
+		}
+		return field(), nil
+	}
+	return nil, fmt.Errorf("Cannot query field %q on type %q.", sel.name, obj.typename)
+}
+
+// complete shapes a resolved value to match the selection
+func (e *executor) complete(value any, sel selection, path []any) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case *object:
+		if v == nil {
+			return nil, nil
+		}
+		if len(sel.selections) == 0 {
+			return nil, fmt.Errorf("Field %q of type %q must have a selection of subfields.", sel.name, v.typename)
+		}
+		return e.execute(v, sel.selections, path)
+	case []*object:
+		list := make([]any, 0, len(v))
+		for i, item := range v {
+			completed, err := e.complete(item, sel, append(append([]any{}, path...), i))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, completed)
+		}
+		return list, nil
+	}
+
+	if len(sel.selections) > 0 {
+		return nil, fmt.Errorf("Field %q must not have a selection since it has no subfields.", sel.name)
+	}
+	return value, nil
+}
+
+// defaultPageSize matches the API's page size when first is not given
+const defaultPageSize = 50
+
+// maxPageSize is the largest page the API returns
+const maxPageSize = 250
+
+// connect filters, orders and paginates a collection into a connection
+// object with nodes, edges and pageInfo
+func connect(typename string, items []*object, args map[string]any, m matcher) (*object, error) {
+	includeArchived, _ := args["includeArchived"].(bool)
+
+	filter, hasFilter := args["filter"].(map[string]any)
+	matched := make([]*object, 0, len(items))
+	for _, item := range items {
+		if !includeArchived && item.value("archivedAt") != nil {
+			continue
+		}
+		if hasFilter {
+			ok, err := m.matchFilter(item, filter)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		matched = append(matched, item)
+	}
+
+	if orderBy, ok := args["orderBy"].(string); ok {
+		if orderBy != "createdAt" && orderBy != "updatedAt" {
+			return nil, invalidInput("Invalid orderBy %q: expected createdAt or updatedAt", orderBy)
+		}
+		// ISO 8601 timestamps in UTC sort lexically
+		sort.SliceStable(matched, func(i, j int) bool {
+			a, _ := matched[i].value(orderBy).(string)
+			b, _ := matched[j].value(orderBy).(string)
+			return a > b
+		})
+	}
+
+	start, end := 0, len(matched)
+	if after, ok := args["after"].(string); ok {
+		i := indexOfCursor(matched, after)
+		if i < 0 {
+			return nil, invalidInput("Invalid cursor %q", after)
+		}
+		start = i + 1
+	}
+	if before, ok := args["before"].(string); ok {
+		i := indexOfCursor(matched, before)
+		if i < 0 {
+			return nil, invalidInput("Invalid cursor %q", before)
+		}
+		end = i
+	}
+	if start > end {
+		start = end
+	}
+
+	first, hasFirst := intArg(args, "first")
+	last, hasLast := intArg(args, "last")
+	if hasFirst && hasLast {
+		return nil, invalidInput("Only one of first and last may be given")
+	}
+	if (hasFirst && (first < 0 || first > maxPageSize)) || (hasLast && (last < 0 || last > maxPageSize)) {
+		return nil, invalidInput("Page size must be between 0 and %d", maxPageSize)
+	}
+	if !hasFirst && !hasLast {
+		first, hasFirst = defaultPageSize, true
+	}
+
+	if hasFirst && end-start > first {
+		end = start + first
+	}
+	if hasLast && end-start > last {
+		start = end - last
+	}
+
+	page := matched[start:end]
+	hasNextPage := end < len(matched)
+	hasPreviousPage := start > 0
+
+	conn := newObject(typename + "Connection")
+	conn.fields["nodes"] = func() any { return page }
+	conn.fields["edges"] = func() any {
+		edges := make([]*object, len(page))
+		for i, node := range page {
+			edge := newObject(typename + "Edge")
+			edge.fields["node"] = func() any { return node }
+			edge.fields["cursor"] = func() any { return node.id() }
+			edges[i] = edge
+		}
+		return edges
+	}
+	conn.fields["pageInfo"] = func() any {
+		info := newObject("PageInfo")
+		info.fields["hasNextPage"] = func() any { return hasNextPage }
+		info.fields["hasPreviousPage"] = func() any { return hasPreviousPage }
+		info.fields["startCursor"] = func() any { return cursorAt(page, 0) }
+		info.fields["endCursor"] = func() any { return cursorAt(page, len(page)-1) }
+		return info
+	}
+	return conn, nil
+}
+
+func indexOfCursor(items []*object, cursor string) int {
+	for i, item := range items {
+		if item.id() == cursor {
+			return i
+		}
+	}
+	return -1
+}
+
+// cursorAt returns the cursor for a page position, or nil for empty pages
+func cursorAt(page []*object, i int) any {
+	if i < 0 || i >= len(page) {
+		return nil
+	}
+	return page[i].id()
+}
+
+// intArg reads an integer argument
+func intArg(args map[string]any, name string) (int, bool) {
+	switch v := args[name].(type) {
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,395 @@
+package lineartest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// matcher evaluates filters; now is the reference time for relative dates
+type matcher struct {
+	now time.Time
+}
+
+// matchFilter reports whether an object satisfies a Linear filter such as
+// IssueFilter. Keys name fields of the object; scalar fields take
+// comparators (eq, in, containsIgnoreCase, ...), relations take a nested
+// filter and collections take some, every or none.
+func (m matcher) matchFilter(obj *object, filter map[string]any) (bool, error) {
+	for key, cond := range filter {
+		ok, err := m.matchKey(obj, key, cond)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (m matcher) matchKey(obj *object, key string, cond any) (bool, error) {
+	if cond == nil {
+		return true, nil
+	}
+
+	switch key {
+	case "and", "or":
+		filters, ok := cond.([]any)
+		if !ok {
+			return false, invalidInput("Filter %q on %s must be a list", key, obj.typename)
+		}
+		for _, f := range filters {
+			sub, ok := f.(map[string]any)
+			if !ok {
+				return false, invalidInput("Filter %q on %s must be a list of objects", key, obj.typename)
+			}
+			matched, err := m.matchFilter(obj, sub)
+			if err != nil {
+				return false, err
+			}
+			if key == "or" && matched {
+				return true, nil
+			}
+			if key == "and" && !matched {
+				return false, nil
+			}
+		}
+		return key == "and" || len(filters) == 0, nil
+	}
+
+	comparators, ok := cond.(map[string]any)
+	if !ok {
+		return false, invalidInput("Filter %q on %s must be an object", key, obj.typename)
+	}
+
+	if l, ok := obj.lists[key]; ok {
+		return m.matchCollection(l, comparators)
+	}
+
+	if ref, ok := obj.refs[key]; ok {
+		return m.matchRelation(ref(), comparators)
+	}
+
+	field, ok := obj.fields[key]
+	if !ok {
+		return false, invalidInput("Field %q is not defined by type %sFilter", key, obj.typename)
+	}
+	return m.matchComparators(field(), comparators)
+}
+
+// matchRelation applies a nested filter to a related object; null matches
+// with {null: true} and fails every other filter
+func (m matcher) matchRelation(related *object, filter map[string]any) (bool, error) {
+	if isNull, ok := filter["null"].(bool); ok {
+		if isNull != (related == nil) {
+			return false, nil
+		}
+		rest := make(map[string]any, len(filter))
+		for k, v := range filter {
+			if k != "null" {
+				rest[k] = v
+			}
+		}
+		filter = rest
+	}
+
+	if related == nil {
+		return len(filter) == 0, nil
+	}
+	return m.matchFilter(related, filter)
+}
+
+// matchCollection applies some, every and none filters to a collection.
+// Other keys filter the collection's items directly and match when some
+// item satisfies all of them, as the API's collection filters do.
+func (m matcher) matchCollection(l list, filter map[string]any) (bool, error) {
+	items := l.items()
+	direct := map[string]any{}
+	for key, cond := range filter {
+		if key == "length" {
+			comparators, ok := cond.(map[string]any)
+			if !ok {
+				return false, invalidInput("Filter %q must be an object", key)
+			}
+			matched, err := m.matchComparators(int64(len(items)), comparators)
+			if err != nil || !matched {
+				return false, err
+			}
+			continue
+		}
+		if key != "some" && key != "every" && key != "none" {
+			direct[key] = cond
+			continue
+		}
+
+		sub, ok := cond.(map[string]any)
+		if !ok {
+			return false, invalidInput("Filter %q on %s collection must be an object", key, l.typename)
+		}
+		count, err := m.count(items, sub)
+		if err != nil {
+			return false, err
+		}
+		if (key == "some" && count == 0) || (key == "every" && count != len(items)) || (key == "none" && count != 0) {
+			return false, nil
+		}
+	}
+
+	if len(direct) > 0 {
+		count, err := m.count(items, direct)
+		if err != nil || count == 0 {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// count returns how many items match a filter
+func (m matcher) count(items []*object, filter map[string]any) (int, error) {
+	n := 0
+	for _, item := range items {
+		matched, err := m.matchFilter(item, filter)
+		if err != nil {
+			return 0, err
+		}
+		if matched {
+			n++
+		}
+	}
+	return n, nil
+}
+
+var comparatorNames = map[string]struct{}{
+	"eq": {}, "neq": {}, "in": {}, "nin": {}, "null": {},
+	"lt": {}, "lte": {}, "gt": {}, "gte": {},
+	"eqIgnoreCase": {}, "neqIgnoreCase": {},
+	"contains": {}, "containsIgnoreCase": {}, "notContains": {}, "notContainsIgnoreCase": {},
+	"startsWith": {}, "startsWithIgnoreCase": {}, "notStartsWith": {},
+	"endsWith": {}, "notEndsWith": {},
+}
+
+// matchComparators applies comparators to a scalar field value
+func (m matcher) matchComparators(value any, comparators map[string]any) (bool, error) {
+	for name, operand := range comparators {
+		ok, err := m.compare(name, value, operand)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (m matcher) compare(name string, value, operand any) (bool, error) {
+	if _, ok := comparatorNames[name]; !ok {
+		return false, invalidInput("Unknown comparator %q", name)
+	}
+	if name == "null" {
+		isNull, ok := operand.(bool)
+		if !ok {
+			return false, invalidInput("Comparator \"null\" expects a boolean")
+		}
+		return isNull == (value == nil), nil
+	}
+	if operand == nil {
+		return true, nil
+	}
+
+	switch name {
+	case "eq":
+		return m.equal(value, operand), nil
+	case "neq":
+		return !m.equal(value, operand), nil
+	case "in", "nin":
+		list, ok := operand.([]any)
+		if !ok {
+			return false, invalidInput("Comparator %q expects a list", name)
+		}
+		found := false
+		for _, item := range list {
+			if m.equal(value, item) {
+				found = true
+				break
+			}
+		}
+		return found == (name == "in"), nil
+	case "lt", "lte", "gt", "gte":
+		if value == nil {
+			return false, nil
+		}
+		c, err := m.order(value, operand)
+		if err != nil {
+			return false, err
+		}
+		switch name {
+		case "lt":
+			return c < 0, nil
+		case "lte":
+			return c <= 0, nil
+		case "gt":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	}
+
+	s, ok := value.(string)
+	if !ok && value != nil {
+		return false, invalidInput("Comparator %q only applies to strings", name)
+	}
+	arg, ok := operand.(string)
+	if !ok {
+		return false, invalidInput("Comparator %q expects a string", name)
+	}
+	if value == nil {
+		// Null never contains or starts with anything, so only the
+		// negated comparators match
+		return strings.HasPrefix(name, "not") || name == "neqIgnoreCase", nil
+	}
+
+	lower, argLower := strings.ToLower(s), strings.ToLower(arg)
+	switch name {
+	case "eqIgnoreCase":
+		return lower == argLower, nil
+	case "neqIgnoreCase":
+		return lower != argLower, nil
+	case "contains":
+		return strings.Contains(s, arg), nil
+	case "containsIgnoreCase":
+		return strings.Contains(lower, argLower), nil
+	case "notContains":
+		return !strings.Contains(s, arg), nil
+	case "notContainsIgnoreCase":
+		return !strings.Contains(lower, argLower), nil
+	case "startsWith":
+		return strings.HasPrefix(s, arg), nil
+	case "startsWithIgnoreCase":
+		return strings.HasPrefix(lower, argLower), nil
+	case "notStartsWith":
+		return !strings.HasPrefix(s, arg), nil
+	case "endsWith":
+		return strings.HasSuffix(s, arg), nil
+	case "notEndsWith":
+		return !strings.HasSuffix(s, arg), nil
+	}
+	return false, nil
+}
+
+// equal compares scalars, treating all numbers alike
+func (m matcher) equal(a, b any) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	if x, ok := a.(string); ok {
+		if y, ok := b.(string); ok && x != y {
+			// Timestamps compare by instant, not by spelling
+			if tx, ok := parseTime(x); ok {
+				if ty, ok := m.parseDateOperand(y); ok {
+					return tx.Equal(ty)
+				}
+			}
+		}
+	}
+	return a == b
+}
+
+// order compares numbers, timestamps and strings
+func (m matcher) order(a, b any) (int, error) {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		if !ok {
+			return 0, invalidInput("Cannot compare a number with %v", b)
+		}
+		return cmp(x, y), nil
+	}
+
+	x, ok := a.(string)
+	y, ok2 := b.(string)
+	if !ok || !ok2 {
+		return 0, invalidInput("Cannot compare %v with %v", a, b)
+	}
+	if tx, ok := parseTime(x); ok {
+		ty, ok := m.parseDateOperand(y)
+		if !ok {
+			return 0, invalidInput("Invalid date %q", y)
+		}
+		return tx.Compare(ty), nil
+	}
+	return strings.Compare(x, y), nil
+}
+
+func cmp(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// parseTime parses the timestamps and dates the server stores
+func parseTime(s string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// parseDateOperand parses a filter date, which may be an absolute date or
+// an ISO 8601 duration relative to now such as "-P2W"
+func (m matcher) parseDateOperand(s string) (time.Time, bool) {
+	if t, ok := parseTime(s); ok {
+		return t, true
+	}
+	if d, ok := parseDuration(s); ok {
+		return d(m.now), true
+	}
+	return time.Time{}, false
+}
+
+var durationPattern = regexp.MustCompile(`^(-)?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses an ISO 8601 duration into a function offsetting a
+// time by it
+func parseDuration(s string) (func(time.Time) time.Time, bool) {
+	m := durationPattern.FindStringSubmatch(s)
+	if m == nil || s == "P" || s == "-P" || strings.HasSuffix(s, "T") {
+		return nil, false
+	}
+
+	n := make([]int, 8)
+	for i := 2; i < len(m); i++ {
+		if m[i] != "" {
+			v, err := strconv.Atoi(m[i])
+			if err != nil {
+				return nil, false
+			}
+			n[i-2] = v
+		}
+	}
+
+	sign := 1
+	if m[1] == "-" {
+		sign = -1
+	}
+	return func(t time.Time) time.Time {
+		t = t.AddDate(sign*n[0], sign*n[1], sign*(n[2]*7+n[3]))
+		offset := time.Duration(n[4])*time.Hour + time.Duration(n[5])*time.Minute + time.Duration(n[6])*time.Second
+		return t.Add(time.Duration(sign) * offset)
+	}, true
+}
@@ -0,0 +1,73 @@
+package lineartest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateOperand(t *testing.T) {
+	m := matcher{now: time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		operand string
+		want    time.Time
+		wantErr bool
+	}{
+		{operand: "2025-01-02", want: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{operand: "2025-01-02T03:04:05.000Z", want: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{operand: "-P2W", want: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{operand: "P1M", want: time.Date(2025, 4, 15, 12, 0, 0, 0, time.UTC)},
+		{operand: "-P1DT6H", want: time.Date(2025, 3, 14, 6, 0, 0, 0, time.UTC)},
+		{operand: "P", wantErr: true},
+		{operand: "PT", wantErr: true},
+		{operand: "last week", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operand, func(t *testing.T) {
+			got, ok := m.parseDateOperand(tt.operand)
+			if ok == tt.wantErr {
+				t.Fatalf("parseDateOperand() ok = %v", ok)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("parseDateOperand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var m matcher
+
+	tests := []struct {
+		name    string
+		op      string
+		value   any
+		operand any
+		want    bool
+		wantErr bool
+	}{
+		{name: "numbers of different types", op: "eq", value: 2, operand: int64(2), want: true},
+		{name: "float range", op: "lt", value: 1.5, operand: int64(2), want: true},
+		{name: "timestamps by instant", op: "eq", value: "2025-01-02T00:00:00.000Z", operand: "2025-01-02", want: true},
+		{name: "null", op: "null", value: nil, operand: true, want: true},
+		{name: "null is not greater", op: "gt", value: nil, operand: int64(0), want: false},
+		{name: "null does not contain", op: "contains", value: nil, operand: "x", want: false},
+		{name: "null not contains", op: "notContains", value: nil, operand: "x", want: true},
+		{name: "nin", op: "nin", value: "a", operand: []any{"b", "c"}, want: true},
+		{name: "unknown comparator", op: "like", value: "a", operand: "a", wantErr: true},
+		{name: "string comparator on number", op: "contains", value: 1, operand: "1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.compare(tt.op, tt.value, tt.operand)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compare() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("compare() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,472 @@
+package lineartest
+
+import (
+	"slices"
+	"strconv"
+	"time"
+)
+
+// mutationRoot is the Mutation type. Mutations validate their input before
+// changing the store, so a rejected mutation leaves it untouched.
+func (st *store) mutationRoot(now time.Time) *object {
+	root := newObject("Mutation")
+
+	root.methods["issueCreate"] = func(args map[string]any) (any, error) {
+		input, err := inputArg(args, "IssueCreateInput")
+		if err != nil {
+			return nil, err
+		}
+		issue := &Issue{CreatorID: st.viewerID, CreatedAt: now}
+		if err := st.applyIssueInput(issue, input, "IssueCreateInput", now); err != nil {
+			return nil, err
+		}
+		if issue.ID == "" {
+			issue.ID = st.newID()
+		}
+		st.issues = append(st.issues, issue)
+		return st.payload("IssuePayload", "issue", st.issueObject(issue)), nil
+	}
+
+	root.methods["issueUpdate"] = func(args map[string]any) (any, error) {
+		issue, err := find(args, "Issue", st.issue)
+		if err != nil {
+			return nil, err
+		}
+		input, err := inputArg(args, "IssueUpdateInput")
+		if err != nil {
+			return nil, err
+		}
+		updated := *issue
+		updated.LabelIDs = slices.Clone(issue.LabelIDs)
+		if err := st.applyIssueInput(&updated, input, "IssueUpdateInput", now); err != nil {
+			return nil, err
+		}
+		// Linear records every update; only moves have from and to states
+		change := &History{ID: st.newID(), IssueID: issue.ID, ActorID: st.viewerID, CreatedAt: now}
+		if updated.StateID != issue.StateID {
+			change.FromStateID, change.ToStateID = issue.StateID, updated.StateID
+		}
+		st.history = append(st.history, change)
+		*issue = updated
+		return st.payload("IssuePayload", "issue", st.issueObject(issue)), nil
+	}
+
+	root.methods["issueArchive"] = func(args map[string]any) (any, error) {
+		issue, err := find(args, "Issue", st.issue)
+		if err != nil {
+			return nil, err
+		}
+		issue.ArchivedAt = now
+		return st.payload("IssueArchivePayload", "entity", st.issueObject(issue)), nil
+	}
+
+	root.methods["issueUnarchive"] = func(args map[string]any) (any, error) {
+		issue, err := find(args, "Issue", st.issue)
+		if err != nil {
+			return nil, err
+		}
+		issue.ArchivedAt = time.Time{}
+		return st.payload("IssueArchivePayload", "entity", st.issueObject(issue)), nil
+	}
+
+	root.methods["issueDelete"] = func(args map[string]any) (any, error) {
+		issue, err := find(args, "Issue", st.issue)
+		if err != nil {
+			return nil, err
+		}
+		st.issues = slices.DeleteFunc(st.issues, func(i *Issue) bool { return i == issue })
+		st.comments = slices.DeleteFunc(st.comments, func(c *Comment) bool { return c.IssueID == issue.ID })
+		return st.payload("IssueArchivePayload", "entity", st.issueObject(issue)), nil
+	}
+
+	root.methods["commentCreate"] = func(args map[string]any) (any, error) {
+		input, err := inputArg(args, "CommentCreateInput")
+		if err != nil {
+			return nil, err
+		}
+		if err := checkFields(input, "CommentCreateInput", "id", "issueId", "body"); err != nil {
+			return nil, err
+		}
+		issueID, _ := input["issueId"].(string)
+		issue := st.issue(issueID)
+		if issue == nil {
+			return nil, notFound("Issue")
+		}
+		body, _ := input["body"].(string)
+		if body == "" {
+			return nil, invalidInput("Comment body must not be empty")
+		}
+		comment := &Comment{IssueID: issue.ID, UserID: st.viewerID, Body: body, CreatedAt: now, UpdatedAt: now}
+		if id, ok := input["id"].(string); ok && id != "" {
+			comment.ID = id
+		} else {
+			comment.ID = st.newID()
+		}
+		st.comments = append(st.comments, comment)
+		return st.payload("CommentPayload", "comment", st.commentObject(comment)), nil
+	}
+
+	root.methods["commentUpdate"] = func(args map[string]any) (any, error) {
+		comment, err := find(args, "Comment", st.comment)
+		if err != nil {
+			return nil, err
+		}
+		input, err := inputArg(args, "CommentUpdateInput")
+		if err != nil {
+			return nil, err
+		}
+		if err := checkFields(input, "CommentUpdateInput", "body"); err != nil {
+			return nil, err
+		}
+		if body, ok := input["body"].(string); ok {
+			if body == "" {
+				return nil, invalidInput("Comment body must not be empty")
+			}
+			comment.Body = body
+			comment.UpdatedAt = now
+		}
+		return st.payload("CommentPayload", "comment", st.commentObject(comment)), nil
+	}
+
+	root.methods["commentDelete"] = func(args map[string]any) (any, error) {
+		comment, err := find(args, "Comment", st.comment)
+		if err != nil {
+			return nil, err
+		}
+		st.comments = slices.DeleteFunc(st.comments, func(c *Comment) bool { return c == comment })
+		payload := newObject("DeletePayload")
+		payload.fields["success"] = func() any { return true }
+		payload.fields["entityId"] = func() any { return comment.ID }
+		return payload, nil
+	}
+
+	root.methods["issueLabelCreate"] = func(args map[string]any) (any, error) {
+		input, err := inputArg(args, "IssueLabelCreateInput")
+		if err != nil {
+			return nil, err
+		}
+		if err := checkFields(input, "IssueLabelCreateInput", "id", "name", "color", "description", "teamId"); err != nil {
+			return nil, err
+		}
+		label := &Label{CreatedAt: now}
+		label.Name, _ = input["name"].(string)
+		label.Color, _ = input["color"].(string)
+		label.Description, _ = input["description"].(string)
+		if label.Name == "" {
+			return nil, invalidInput("Label name must not be empty")
+		}
+		if teamID, ok := input["teamId"].(string); ok && teamID != "" {
+			team := st.team(teamID)
+			if team == nil {
+				return nil, notFound("Team")
+			}
+			label.TeamID = team.ID
+		}
+		for _, l := range st.labels {
+			if l.TeamID == label.TeamID && l.Name == label.Name {
+				return nil, invalidInput("Duplicate label name %q", label.Name)
+			}
+		}
+		if id, ok := input["id"].(string); ok && id != "" {
+			label.ID = id
+		} else {
+			label.ID = st.newID()
+		}
+		st.labels = append(st.labels, label)
+		return st.payload("IssueLabelPayload", "issueLabel", st.labelObject(label)), nil
+	}
+
+	return root
+}
+
+// payload builds a mutation result with success and the changed entity
+func (st *store) payload(typename, field string, entity *object) *object {
+	payload := newObject(typename)
+	payload.fields["success"] = func() any { return true }
+	payload.fields["lastSyncId"] = func() any { return float64(st.lastID) }
+	payload.refs[field] = func() *object { return entity }
+	return payload
+}
+
+// issueInputFields lists the IssueCreateInput and IssueUpdateInput fields
+// the server understands
+var issueInputFields = []string{
+	"id", "title", "description", "teamId", "stateId", "priority", "estimate", "dueDate",
+	"assigneeId", "projectId", "parentId", "labelIds", "addedLabelIds", "removedLabelIds", "subscriberIds",
+}
+
+// applyIssueInput validates an issue input and applies it to issue
+func (st *store) applyIssueInput(issue *Issue, input map[string]any, typename string, now time.Time) error {
+	if err := checkFields(input, typename, issueInputFields...); err != nil {
+		return err
+	}
+	creating := typename == "IssueCreateInput"
+
+	if id, set, err := stringField(input, "id"); err != nil {
+		return err
+	} else if set && creating {
+		if st.issue(id) != nil {
+			return invalidInput("An issue with id %q already exists", id)
+		}
+		issue.ID = id
+	}
+
+	if title, set, err := stringField(input, "title"); err != nil {
+		return err
+	} else if set || creating {
+		if title == "" {
+			return invalidInput("Issue title must not be empty")
+		}
+		issue.Title = title
+	}
+
+	if description, set, err := stringField(input, "description"); err != nil {
+		return err
+	} else if set {
+		issue.Description = description
+	}
+
+	teamID, teamSet, err := stringField(input, "teamId")
+	if err != nil {
+		return err
+	}
+	if creating && teamID == "" {
+		return invalidInput("Argument \"teamId\" of type \"String!\" is required")
+	}
+	if teamSet {
+		team := st.team(teamID)
+		if team == nil {
+			return notFound("Team")
+		}
+		if team.ID != issue.TeamID {
+			// Moving teams assigns a new identifier and the new team's
+			// default state
+			issue.TeamID = team.ID
+			issue.Number = st.nextNumber(team.ID)
+			issue.Identifier = team.Key + "-" + strconv.Itoa(issue.Number)
+			issue.StateID = ""
+		}
+	}
+
+	if stateID, set, err := stringField(input, "stateId"); err != nil {
+		return err
+	} else if set {
+		state := st.state(stateID)
+		if state == nil {
+			return notFound("WorkflowState")
+		}
+		if state.TeamID != issue.TeamID {
+			return invalidInput("Workflow state %q does not belong to the issue's team", state.Name)
+		}
+		setState(issue, state, now)
+	} else if issue.StateID == "" {
+		if state := st.defaultState(issue.TeamID); state != nil {
+			setState(issue, state, now)
+		}
+	}
+
+	if priority, set := input["priority"]; set {
+		p, ok := priority.(int64)
+		if priority != nil && (!ok || p < 0 || p > 4) {
+			return invalidInput("Priority must be an integer between 0 and 4")
+		}
+		issue.Priority = int(p)
+	}
+
+	if estimate, set := input["estimate"]; set {
+		if estimate == nil {
+			issue.Estimate = nil
+		} else {
+			e, ok := toFloat(estimate)
+			if !ok || e < 0 {
+				return invalidInput("Estimate must be a non-negative number")
+			}
+			issue.Estimate = &e
+		}
+	}
+
+	if dueDate, set, err := stringField(input, "dueDate"); err != nil {
+		return err
+	} else if set {
+		if dueDate != "" {
+			if _, err := time.Parse(time.DateOnly, dueDate); err != nil {
+				return invalidInput("Due date %q must be formatted as YYYY-MM-DD", dueDate)
+			}
+		}
+		issue.DueDate = dueDate
+	}
+
+	if assigneeID, set, err := stringField(input, "assigneeId"); err != nil {
+		return err
+	} else if set {
+		if assigneeID != "" && st.user(assigneeID) == nil {
+			return notFound("User")
+		}
+		issue.AssigneeID = assigneeID
+	}
+
+	if projectID, set, err := stringField(input, "projectId"); err != nil {
+		return err
+	} else if set {
+		if projectID != "" && st.project(projectID) == nil {
+			return notFound("Project")
+		}
+		issue.ProjectID = projectID
+	}
+
+	if parentID, set, err := stringField(input, "parentId"); err != nil {
+		return err
+	} else if set {
+		if parentID != "" {
+			parent := st.issue(parentID)
+			if parent == nil {
+				return notFound("Issue")
+			}
+			if parent.ID == issue.ID {
+				return invalidInput("An issue cannot be its own parent")
+			}
+			parentID = parent.ID
+		}
+		issue.ParentID = parentID
+	}
+
+	labels, err := st.labelIDs(issue, input, "labelIds")
+	if err != nil {
+		return err
+	}
+	if labels != nil {
+		issue.LabelIDs = labels
+	}
+	added, err := st.labelIDs(issue, input, "addedLabelIds")
+	if err != nil {
+		return err
+	}
+	for _, id := range added {
+		if !slices.Contains(issue.LabelIDs, id) {
+			issue.LabelIDs = append(issue.LabelIDs, id)
+		}
+	}
+	removed, err := st.labelIDs(issue, input, "removedLabelIds")
+	if err != nil {
+		return err
+	}
+	issue.LabelIDs = slices.DeleteFunc(issue.LabelIDs, func(id string) bool { return slices.Contains(removed, id) })
+
+	if subscribers, err := stringList(input, "subscriberIds"); err != nil {
+		return err
+	} else {
+		for _, id := range subscribers {
+			if st.user(id) == nil {
+				return notFound("User")
+			}
+		}
+	}
+
+	issue.UpdatedAt = now
+	return nil
+}
+
+// labelIDs validates a list of label IDs usable on the issue's team
+func (st *store) labelIDs(issue *Issue, input map[string]any, key string) ([]string, error) {
+	ids, err := stringList(input, key)
+	if err != nil || ids == nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		label := st.label(id)
+		if label == nil {
+			return nil, notFound("IssueLabel")
+		}
+		if label.TeamID != "" && label.TeamID != issue.TeamID {
+			return nil, invalidInput("Label %q does not belong to the issue's team", label.Name)
+		}
+	}
+	return ids, nil
+}
+
+// setState moves an issue to a state, updating its started, completed and
+// canceled times
+func setState(issue *Issue, state *State, now time.Time) {
+	issue.StateID = state.ID
+	issue.CompletedAt = time.Time{}
+	issue.CanceledAt = time.Time{}
+	switch state.Type {
+	case "started":
+		if issue.StartedAt.IsZero() {
+			issue.StartedAt = now
+		}
+	case "completed":
+		issue.CompletedAt = now
+	case "canceled":
+		issue.CanceledAt = now
+	default:
+		issue.StartedAt = time.Time{}
+	}
+}
+
+// inputArg reads the input argument of a mutation
+func inputArg(args map[string]any, typename string) (map[string]any, error) {
+	input, ok := args["input"].(map[string]any)
+	if !ok {
+		return nil, invalidInput("Argument \"input\" of type \"%s!\" is required", typename)
+	}
+	return input, nil
+}
+
+// find looks up the entity named by a mutation's id argument
+func find[T any](args map[string]any, typename string, lookup func(string) *T) (*T, error) {
+	id, ok := args["id"].(string)
+	if !ok {
+		return nil, invalidInput("Argument \"id\" of type \"String!\" is required")
+	}
+	v := lookup(id)
+	if v == nil {
+		return nil, notFound(typename)
+	}
+	return v, nil
+}
+
+// checkFields rejects input fields the type does not define
+func checkFields(input map[string]any, typename string, fields ...string) error {
+	for key := range input {
+		if !slices.Contains(fields, key) {
+			return invalidInput("Field %q is not defined by type %s", key, typename)
+		}
+	}
+	return nil
+}
+
+// stringField reads an optional string input field; null reads as set to
+// the empty string
+func stringField(input map[string]any, key string) (string, bool, error) {
+	v, set := input[key]
+	if !set || v == nil {
+		return "", set, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", false, invalidInput("Field %q must be a string", key)
+	}
+	return s, true, nil
+}
+
+// stringList reads an optional list of strings; nil means unset
+func stringList(input map[string]any, key string) ([]string, error) {
+	v, set := input[key]
+	if !set || v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return nil, invalidInput("Field %q must be a list of strings", key)
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, invalidInput("Field %q must be a list of strings", key)
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
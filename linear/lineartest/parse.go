@@ -0,0 +1,784 @@
+package lineartest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// document is a parsed GraphQL request document
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+// operation is a query or mutation definition
+type operation struct {
+	kind       string
+	name       string
+	vars       []varDef
+	selections []selection
+}
+
+// varDef declares an operation variable
+type varDef struct {
+	name       string
+	typ        string
+	def        any
+	hasDefault bool
+}
+
+// fragment is a named fragment definition
+type fragment struct {
+	name       string
+	typeCond   string
+	selections []selection
+}
+
+// selection is a field, fragment spread or inline fragment
+type selection struct {
+	alias      string
+	name       string
+	args       []argument
+	directives []directive
+	selections []selection
+
+	// spread names a fragment for "...Name" selections
+	spread string
+	// inline marks "... on Type { }" selections; typeCond may be empty
+	inline   bool
+	typeCond string
+}
+
+// key is the response key for a field selection
+func (s selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+type argument struct {
+	name  string
+	value any
+}
+
+type directive struct {
+	name string
+	args []argument
+}
+
+// variable is a "$name" reference in a value position
+type variable string
+
+// enumValue is an unquoted enum literal such as createdAt
+type enumValue string
+
+// objectField is one field of an input object literal
+type objectField struct {
+	name  string
+	value any
+}
+
+// objectValue is an input object literal, kept in source order
+type objectValue []objectField
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// lexer splits a GraphQL document into tokens, skipping whitespace,
+// commas and comments
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+		return token{kind: tokenPunct, value: "...", pos: start}, nil
+	case strings.IndexByte("!$&()[]{}:=@|", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunct, value: string(c), pos: start}, nil
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], pos: start}, nil
+	case c == '-' || isDigit(c):
+		return l.number()
+	case strings.HasPrefix(l.src[l.pos:], `"""`):
+		return l.blockString()
+	case c == '"':
+		return l.string()
+	}
+	return token{}, fmt.Errorf("Syntax Error: Unexpected character %q at position %d", c, start)
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' && l.src[l.pos] != '\r' {
+				l.pos++
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\ufeff"):
+			l.pos += len("\ufeff")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return token{}, fmt.Errorf("Syntax Error: Invalid number at position %d", start)
+	}
+
+	kind := tokenInt
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		l.pos++
+		kind = tokenFloat
+		if digits() == 0 {
+			return token{}, fmt.Errorf("Syntax Error: Invalid number at position %d", start)
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		l.pos++
+		kind = tokenFloat
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if digits() == 0 {
+			return token{}, fmt.Errorf("Syntax Error: Invalid number at position %d", start)
+		}
+	}
+	return token{kind: kind, value: l.src[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) string() (token, error) {
+	start := l.pos
+	l.pos++
+
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return token{kind: tokenString, value: b.String(), pos: start}, nil
+		case c == '\n' || c == '\r':
+			return token{}, fmt.Errorf("Syntax Error: Unterminated string at position %d", start)
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, fmt.Errorf("Syntax Error: Unterminated string at position %d", start)
+			}
+			esc := l.src[l.pos+1]
+			l.pos += 2
+			switch esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.src) {
+					return token{}, fmt.Errorf("Syntax Error: Invalid Unicode escape at position %d", l.pos)
+				}
+				r, err := strconv.ParseUint(l.src[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, fmt.Errorf("Syntax Error: Invalid Unicode escape at position %d", l.pos)
+				}
+				b.WriteRune(rune(r))
+				l.pos += 4
+			default:
+				return token{}, fmt.Errorf("Syntax Error: Invalid escape sequence \\%c at position %d", esc, l.pos-2)
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			b.WriteRune(r)
+			l.pos += size
+		}
+	}
+	return token{}, fmt.Errorf("Syntax Error: Unterminated string at position %d", start)
+}
+
+func (l *lexer) blockString() (token, error) {
+	start := l.pos
+	l.pos += 3
+
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		switch {
+		case strings.HasPrefix(l.src[l.pos:], `\"""`):
+			b.WriteString(`"""`)
+			l.pos += 4
+		case strings.HasPrefix(l.src[l.pos:], `"""`):
+			l.pos += 3
+			return token{kind: tokenString, value: dedentBlockString(b.String()), pos: start}, nil
+		default:
+			b.WriteByte(l.src[l.pos])
+			l.pos++
+		}
+	}
+	return token{}, fmt.Errorf("Syntax Error: Unterminated string at position %d", start)
+}
+
+// dedentBlockString removes the common indentation and surrounding blank
+// lines from a block string, as the GraphQL spec describes
+func dedentBlockString(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(strings.ReplaceAll(raw, "\r\n", "\n"), "\r", "\n"), "\n")
+
+	common := -1
+	for i, line := range lines {
+		if i == 0 {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < len(line) && (common < 0 || indent < common) {
+			common = indent
+		}
+	}
+	if common > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= common {
+				lines[i] = lines[i][common:]
+			} else {
+				lines[i] = ""
+			}
+		}
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parser is a recursive descent parser for executable GraphQL documents
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+// parseDocument parses the operations and fragments in a request
+func parseDocument(src string) (*document, error) {
+	p := &parser{lex: &lexer{src: src}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: map[string]*fragment{}}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunct, "{"):
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: selections})
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.peek(tokenName, "fragment"):
+			frag, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("Must provide an operation.")
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+// skip consumes the token if it matches
+func (p *parser) skip(kind tokenKind, value string) (bool, error) {
+	if !p.peek(kind, value) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	if !p.peek(kind, value) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return fmt.Errorf("Syntax Error: Unexpected <EOF>")
+	}
+	return fmt.Errorf("Syntax Error: Unexpected %q at position %d", p.tok.value, p.tok.pos)
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: p.tok.value}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenName {
+		op.name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := p.skip(tokenPunct, "("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.peek(tokenPunct, ")") {
+			def, err := p.varDef()
+			if err != nil {
+				return nil, err
+			}
+			op.vars = append(op.vars, def)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	return op, nil
+}
+
+func (p *parser) varDef() (varDef, error) {
+	if err := p.expect(tokenPunct, "$"); err != nil {
+		return varDef{}, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return varDef{}, err
+	}
+	if err := p.expect(tokenPunct, ":"); err != nil {
+		return varDef{}, err
+	}
+	typ, err := p.typeRef()
+	if err != nil {
+		return varDef{}, err
+	}
+
+	def := varDef{name: name, typ: typ}
+	if ok, err := p.skip(tokenPunct, "="); err != nil {
+		return varDef{}, err
+	} else if ok {
+		value, err := p.value(true)
+		if err != nil {
+			return varDef{}, err
+		}
+		def.def = value
+		def.hasDefault = true
+	}
+	if _, err := p.directives(); err != nil {
+		return varDef{}, err
+	}
+	return def, nil
+}
+
+func (p *parser) typeRef() (string, error) {
+	var typ string
+	if ok, err := p.skip(tokenPunct, "["); err != nil {
+		return "", err
+	} else if ok {
+		inner, err := p.typeRef()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect(tokenPunct, "]"); err != nil {
+			return "", err
+		}
+		typ = "[" + inner + "]"
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return "", err
+		}
+		typ = name
+	}
+
+	if ok, err := p.skip(tokenPunct, "!"); err != nil {
+		return "", err
+	} else if ok {
+		typ += "!"
+	}
+	return typ, nil
+}
+
+func (p *parser) fragment() (*fragment, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tokenName, "on"); err != nil {
+		return nil, err
+	}
+	typeCond, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &fragment{name: name, typeCond: typeCond, selections: selections}, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect(tokenPunct, "{"); err != nil {
+		return nil, err
+	}
+
+	var selections []selection
+	for !p.peek(tokenPunct, "}") {
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	if len(selections) == 0 {
+		return nil, p.unexpected()
+	}
+	return selections, p.advance()
+}
+
+func (p *parser) selection() (selection, error) {
+	if ok, err := p.skip(tokenPunct, "..."); err != nil {
+		return selection{}, err
+	} else if ok {
+		return p.fragmentSelection()
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return selection{}, err
+	}
+
+	sel := selection{name: name}
+	if ok, err := p.skip(tokenPunct, ":"); err != nil {
+		return selection{}, err
+	} else if ok {
+		sel.alias = name
+		if sel.name, err = p.name(); err != nil {
+			return selection{}, err
+		}
+	}
+
+	if sel.args, err = p.arguments(); err != nil {
+		return selection{}, err
+	}
+	if sel.directives, err = p.directives(); err != nil {
+		return selection{}, err
+	}
+	if p.peek(tokenPunct, "{") {
+		if sel.selections, err = p.selectionSet(); err != nil {
+			return selection{}, err
+		}
+	}
+	return sel, nil
+}
+
+func (p *parser) fragmentSelection() (selection, error) {
+	var sel selection
+	var err error
+
+	switch {
+	case p.peek(tokenName, "on"):
+		if err := p.advance(); err != nil {
+			return selection{}, err
+		}
+		sel.inline = true
+		if sel.typeCond, err = p.name(); err != nil {
+			return selection{}, err
+		}
+	case p.tok.kind == tokenName:
+		sel.spread = p.tok.value
+		if err := p.advance(); err != nil {
+			return selection{}, err
+		}
+	default:
+		sel.inline = true
+	}
+
+	if sel.directives, err = p.directives(); err != nil {
+		return selection{}, err
+	}
+	if sel.inline {
+		if sel.selections, err = p.selectionSet(); err != nil {
+			return selection{}, err
+		}
+	}
+	return sel, nil
+}
+
+func (p *parser) arguments() ([]argument, error) {
+	if ok, err := p.skip(tokenPunct, "("); err != nil || !ok {
+		return nil, err
+	}
+
+	var args []argument
+	for !p.peek(tokenPunct, ")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+		value, err := p.value(false)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, argument{name: name, value: value})
+	}
+	return args, p.advance()
+}
+
+func (p *parser) directives() ([]directive, error) {
+	var directives []directive
+	for p.peek(tokenPunct, "@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.arguments()
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, directive{name: name, args: args})
+	}
+	return directives, nil
+}
+
+// value parses an input value; constant values may not reference variables
+func (p *parser) value(constant bool) (any, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenPunct:
+		switch tok.value {
+		case "$":
+			if constant {
+				return nil, p.unexpected()
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			return variable(name), nil
+		case "[":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			list := []any{}
+			for !p.peek(tokenPunct, "]") {
+				item, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, item)
+			}
+			return list, p.advance()
+		case "{":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			obj := objectValue{}
+			for !p.peek(tokenPunct, "}") {
+				name, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(tokenPunct, ":"); err != nil {
+					return nil, err
+				}
+				value, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				obj = append(obj, objectField{name: name, value: value})
+			}
+			return obj, p.advance()
+		}
+	case tokenInt:
+		n, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Syntax Error: Invalid number %s", tok.value)
+		}
+		return n, p.advance()
+	case tokenFloat:
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Syntax Error: Invalid number %s", tok.value)
+		}
+		return f, p.advance()
+	case tokenString:
+		return tok.value, p.advance()
+	case tokenName:
+		var value any
+		switch tok.value {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			value = enumValue(tok.value)
+		}
+		return value, p.advance()
+	}
+	return nil, p.unexpected()
+}
+
+// resolveValue replaces variables in a parsed value and converts object
+// literals and enums to plain maps and strings
+func resolveValue(v any, vars map[string]any) any {
+	switch v := v.(type) {
+	case variable:
+		return vars[string(v)]
+	case enumValue:
+		return string(v)
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = resolveValue(item, vars)
+		}
+		return list
+	case objectValue:
+		obj := make(map[string]any, len(v))
+		for _, field := range v {
+			// Fields set to unprovided variables are omitted, not null
+			if name, ok := field.value.(variable); ok {
+				if _, provided := vars[string(name)]; !provided {
+					continue
+				}
+			}
+			obj[field.name] = resolveValue(field.value, vars)
+		}
+		return obj
+	}
+	return v
+}
+
+// resolveArgs builds the argument map for a field; arguments referencing
+// unprovided variables are left out so they read as unset
+func resolveArgs(args []argument, vars map[string]any) map[string]any {
+	resolved := make(map[string]any, len(args))
+	for _, arg := range args {
+		if name, ok := arg.value.(variable); ok {
+			if _, provided := vars[string(name)]; !provided {
+				continue
+			}
+		}
+		resolved[arg.name] = resolveValue(arg.value, vars)
+	}
+	return resolved
+}
@@ -0,0 +1,109 @@
+package lineartest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	doc, err := parseDocument(`
+		# Fetch an issue
+		query Issue($id: String!, $first: Int = 10) {
+			issue(id: $id) { id ...Fields }
+			issues(first: $first, filter: { title: { in: ["a", "b"] }, priority: { gt: 1.5 } }, orderBy: updatedAt) {
+				nodes { id }
+			}
+		}
+		fragment Fields on Issue { title }
+	`)
+	if err != nil {
+		t.Fatalf("parseDocument() error = %v", err)
+	}
+
+	op := doc.operations[0]
+	if op.kind != "query" || op.name != "Issue" || len(op.vars) != 2 {
+		t.Fatalf("unexpected operation: %+v", op)
+	}
+	if op.vars[1].typ != "Int" || op.vars[1].def != int64(10) {
+		t.Errorf("unexpected variable: %+v", op.vars[1])
+	}
+	if _, ok := doc.fragments["Fields"]; !ok {
+		t.Error("expected fragment Fields")
+	}
+
+	args := resolveArgs(op.selections[1].args, map[string]any{"first": int64(5)})
+	want := map[string]any{
+		"first": int64(5),
+		"filter": map[string]any{
+			"title":    map[string]any{"in": []any{"a", "b"}},
+			"priority": map[string]any{"gt": 1.5},
+		},
+		"orderBy": "updatedAt",
+	}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("resolveArgs() = %#v, want %#v", args, want)
+	}
+}
+
+func TestResolveArgs_OmitsUnprovidedVariables(t *testing.T) {
+	doc, err := parseDocument(`query($after: String, $key: String) { teams(after: $after, filter: { key: { eq: $key } }) { nodes { id } } }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := resolveArgs(doc.operations[0].selections[0].args, map[string]any{})
+	want := map[string]any{"filter": map[string]any{"key": map[string]any{}}}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("resolveArgs() = %#v, want %#v", args, want)
+	}
+}
+
+func TestLexer_Strings(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "escapes", src: `"tab\there \"quoted\" é"`, want: "tab\there \"quoted\" é"},
+		{name: "unicode", src: `"héllo"`, want: "héllo"},
+		{name: "block", src: "\"\"\"\n    Hello,\n      World!\n\n    \"\"\"", want: "Hello,\n  World!"},
+		{name: "block escaped quotes", src: `"""say \""" please"""`, want: `say """ please`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tok, err := (&lexer{src: tt.src}).next()
+			if err != nil {
+				t.Fatalf("next() error = %v", err)
+			}
+			if tok.kind != tokenString || tok.value != tt.want {
+				t.Errorf("next() = %q, want %q", tok.value, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDocument_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "empty", src: "", want: "Must provide an operation"},
+		{name: "unclosed selection", src: "{ viewer { id }", want: "Unexpected <EOF>"},
+		{name: "empty selection", src: "{ }", want: `Unexpected "}"`},
+		{name: "unterminated string", src: `{ issue(id: "ENG-1) { id } }`, want: "Unterminated string"},
+		{name: "bad character", src: "{ viewer { id % } }", want: "Unexpected character"},
+		{name: "variable in default", src: "query($a: Int = $b) { viewer { id } }", want: `Unexpected "$"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDocument(tt.src)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseDocument() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
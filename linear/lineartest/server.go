@@ -0,0 +1,681 @@
+// Package lineartest provides an in-memory fake of the Linear GraphQL API
+// for tests.
+//
+// A Server keeps teams, users, workflow states, labels, projects, issues and
+// comments in memory and executes the GraphQL documents clients send,
+// including nested selections, fragments, filters, cursor pagination and
+// the common issue, comment and label mutations:
+//
+//	srv := lineartest.NewServer()
+//	defer srv.Close()
+//
+//	team := srv.AddTeam(lineartest.Team{Key: "ENG", Name: "Engineering"})
+//	srv.AddIssue(lineartest.Issue{TeamID: team.ID, Title: "Fix login"})
+//
+//	client := srv.Client()
+//	resp, err := client.ListIssues(ctx, linear.ListIssuesOptions{TeamKey: "ENG"})
+//
+// Fail and RateLimit make the server return errors so retry and error
+// handling can be exercised, and Requests records what clients sent.
+package lineartest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dukky/linear/linear"
+)
+
+// DefaultAPIKey is the API key Client authenticates with
+const DefaultAPIKey = "lin_api_test"
+
+// Server is a fake Linear API. Its methods are safe for concurrent use.
+type Server struct {
+	// URL is the GraphQL endpoint, set by NewServer
+	URL string
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	data     store
+	clock    func() time.Time
+	apiKey   string
+	faults   []*Fault
+	requests []Request
+}
+
+// Fault is an error the server returns in place of executing a request
+type Fault struct {
+	// Operation restricts the fault to requests whose operation name or
+	// any root field matches, such as "issueCreate"; empty matches all
+	Operation string
+	// Status is the HTTP status; zero responds 200 with GraphQL errors
+	Status int
+	// Message and Code fill the GraphQL error; Message defaults to
+	// "Internal server error"
+	Message string
+	Code    string
+	// RetryAfter sets the Retry-After header, rounded up to seconds
+	RetryAfter time.Duration
+	// Delay waits before responding, or until the client gives up. A
+	// fault with only Delay set slows requests without failing them.
+	Delay time.Duration
+	// After lets that many matching requests through before the fault
+	// applies, e.g. to fail the second page of a paginated fetch
+	After int
+	// Times is how many matching requests the fault applies to; zero
+	// applies it until ClearFaults
+	Times int
+}
+
+// Request is a request the server received
+type Request struct {
+	// OperationName is the operation's name, if it has one
+	OperationName string
+	// Fields are the root fields selected, such as "issues"
+	Fields    []string
+	Mutation  bool
+	Query     string
+	Variables map[string]any
+	Header    http.Header
+}
+
+// NewServer starts a fake Linear API. Callers should call Close when done.
+func NewServer() *Server {
+	s := &Server{clock: time.Now}
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a linear.Client for the server that retries quickly.
+// Options are applied after the defaults and may override them.
+func (s *Server) Client(opts ...linear.Option) *linear.Client {
+	s.mu.Lock()
+	apiKey := s.apiKey
+	s.mu.Unlock()
+	if apiKey == "" {
+		apiKey = DefaultAPIKey
+	}
+
+	defaults := []linear.Option{
+		linear.WithAPIKey(apiKey),
+		linear.WithEndpoint(s.URL),
+		linear.WithRetryPolicy(linear.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}),
+	}
+	client, err := linear.NewClient(append(defaults, opts...)...)
+	if err != nil {
+		panic("lineartest: " + err.Error())
+	}
+	return client
+}
+
+// SetClock replaces the clock used for timestamps and relative date filters
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = now
+}
+
+// RequireAPIKey rejects requests not authenticated with key, either as a
+// personal API key or a Bearer token. By default any credentials work.
+func (s *Server) RequireAPIKey(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKey = key
+}
+
+// SetViewer sets the authenticated user; it defaults to the first user added
+func (s *Server) SetViewer(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data.user(userID) == nil {
+		panic("lineartest: SetViewer: unknown user " + userID)
+	}
+	s.data.viewerID = userID
+}
+
+// AddTeam adds a team with the default workflow states (Backlog, Todo,
+// In Progress, Done and Canceled) and returns it with its ID set
+func (s *Server) AddTeam(t Team) Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.Key == "" {
+		panic("lineartest: AddTeam: Key is required")
+	}
+	if s.data.team(t.Key) != nil {
+		panic("lineartest: AddTeam: duplicate team key " + t.Key)
+	}
+	if t.ID == "" {
+		t.ID = s.data.newID()
+	}
+	if t.Name == "" {
+		t.Name = t.Key
+	}
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.clock()
+	}
+	s.data.teams = append(s.data.teams, &t)
+
+	for i, state := range defaultStates {
+		state.ID = s.data.newID()
+		state.TeamID = t.ID
+		state.Position = float64(i)
+		s.data.states = append(s.data.states, &state)
+	}
+	return t
+}
+
+// AddUser adds a user and returns it with its ID set
+func (s *Server) AddUser(u User) User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u.ID == "" {
+		u.ID = s.data.newID()
+	}
+	if u.CreatedAt.IsZero() {
+		u.CreatedAt = s.clock()
+	}
+	s.data.users = append(s.data.users, &u)
+	if s.data.viewerID == "" {
+		s.data.viewerID = u.ID
+	}
+	return u
+}
+
+// AddState adds a workflow state to a team and returns it with its ID set
+func (s *Server) AddState(st State) State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mustTeam("AddState", st.TeamID)
+	if st.ID == "" {
+		st.ID = s.data.newID()
+	}
+	if st.Type == "" {
+		st.Type = "unstarted"
+	}
+	s.data.states = append(s.data.states, &st)
+	return st
+}
+
+// States returns a team's workflow states ordered by position
+func (s *Server) States(teamID string) []State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return values(s.data.teamStates(teamID))
+}
+
+// AddLabel adds a label and returns it with its ID set
+func (s *Server) AddLabel(l Label) Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l.TeamID != "" {
+		s.mustTeam("AddLabel", l.TeamID)
+	}
+	if l.ID == "" {
+		l.ID = s.data.newID()
+	}
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = s.clock()
+	}
+	s.data.labels = append(s.data.labels, &l)
+	return l
+}
+
+// AddProject adds a project and returns it with its ID set
+func (s *Server) AddProject(p Project) Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, teamID := range p.TeamIDs {
+		s.mustTeam("AddProject", teamID)
+	}
+	if p.ID == "" {
+		p.ID = s.data.newID()
+	}
+	if p.State == "" {
+		p.State = "planned"
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = s.clock()
+	}
+	if p.UpdatedAt.IsZero() {
+		p.UpdatedAt = p.CreatedAt
+	}
+	p.TeamIDs = slices.Clone(p.TeamIDs)
+	s.data.projects = append(s.data.projects, &p)
+	return p
+}
+
+// AddIssue adds an issue and returns it with its ID, number and identifier
+// set. Issues without a StateID start in the team's default state and
+// issues without a CreatorID are created by the viewer.
+func (s *Server) AddIssue(i Issue) Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team := s.mustTeam("AddIssue", i.TeamID)
+	if i.Priority < 0 || i.Priority >= len(priorityLabels) {
+		panic(fmt.Sprintf("lineartest: AddIssue: invalid priority %d", i.Priority))
+	}
+	if i.ID == "" {
+		i.ID = s.data.newID()
+	}
+	if i.Number == 0 {
+		i.Number = s.data.nextNumber(team.ID)
+	}
+	if i.Identifier == "" {
+		i.Identifier = team.Key + "-" + strconv.Itoa(i.Number)
+	}
+	if i.StateID == "" {
+		if state := s.data.defaultState(team.ID); state != nil {
+			i.StateID = state.ID
+		}
+	}
+	if i.CreatorID == "" {
+		i.CreatorID = s.data.viewerID
+	}
+	if i.CreatedAt.IsZero() {
+		i.CreatedAt = s.clock()
+	}
+	if i.UpdatedAt.IsZero() {
+		i.UpdatedAt = i.CreatedAt
+	}
+	i.LabelIDs = slices.Clone(i.LabelIDs)
+	s.data.issues = append(s.data.issues, &i)
+	return i
+}
+
+// Issue returns an issue by ID or identifier
+func (s *Server) Issue(id string) (Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issue := s.data.issue(id)
+	if issue == nil {
+		return Issue{}, false
+	}
+	i := *issue
+	i.LabelIDs = slices.Clone(issue.LabelIDs)
+	return i, true
+}
+
+// Issues returns every issue in creation order
+func (s *Server) Issues() []Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return values(s.data.issues)
+}
+
+// AddComment adds a comment and returns it with its ID set. Comments
+// without a UserID are written by the viewer.
+func (s *Server) AddComment(c Comment) Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issue := s.data.issue(c.IssueID)
+	if issue == nil {
+		panic("lineartest: AddComment: unknown issue " + c.IssueID)
+	}
+	c.IssueID = issue.ID
+	if c.ID == "" {
+		c.ID = s.data.newID()
+	}
+	if c.UserID == "" {
+		c.UserID = s.data.viewerID
+	}
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = s.clock()
+	}
+	if c.UpdatedAt.IsZero() {
+		c.UpdatedAt = c.CreatedAt
+	}
+	s.data.comments = append(s.data.comments, &c)
+	return c
+}
+
+// Comments returns an issue's comments in creation order
+func (s *Server) Comments(issueID string) []Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var comments []Comment
+	if issue := s.data.issue(issueID); issue != nil {
+		for _, c := range s.data.comments {
+			if c.IssueID == issue.ID {
+				comments = append(comments, *c)
+			}
+		}
+	}
+	return comments
+}
+
+// AddHistory records a change of an issue, a move between states when
+// ToStateID is set, and returns it with its ID set. It does not change the
+// issue; the actor defaults to the viewer.
+func (s *Server) AddHistory(h History) History {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issue := s.data.issue(h.IssueID)
+	if issue == nil {
+		panic("lineartest: AddHistory: unknown issue " + h.IssueID)
+	}
+	h.IssueID = issue.ID
+	if h.ID == "" {
+		h.ID = s.data.newID()
+	}
+	if h.ActorID == "" {
+		h.ActorID = s.data.viewerID
+	}
+	if h.CreatedAt.IsZero() {
+		h.CreatedAt = s.clock()
+	}
+	s.data.history = append(s.data.history, &h)
+	return h
+}
+
+// History returns an issue's changes in the order they were recorded
+func (s *Server) History(issueID string) []History {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var history []History
+	if issue := s.data.issue(issueID); issue != nil {
+		for _, h := range s.data.history {
+			if h.IssueID == issue.ID {
+				history = append(history, *h)
+			}
+		}
+	}
+	return history
+}
+
+// mustTeam returns a team or panics naming the seeding method
+func (s *Server) mustTeam(method, id string) *Team {
+	team := s.data.team(id)
+	if team == nil {
+		panic(fmt.Sprintf("lineartest: %s: unknown team %q", method, id))
+	}
+	return team
+}
+
+func values[T any](items []*T) []T {
+	out := make([]T, len(items))
+	for i, item := range items {
+		out[i] = *item
+	}
+	return out
+}
+
+// Fail makes the server return an error for matching requests
+func (s *Server) Fail(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// RateLimit rejects the next n requests the way the API does when a rate
+// limit is exceeded
+func (s *Server) RateLimit(n int, retryAfter time.Duration) {
+	s.Fail(Fault{
+		Status:     http.StatusBadRequest,
+		Code:       "RATELIMITED",
+		Message:    "Rate limit exceeded",
+		RetryAfter: retryAfter,
+		Times:      n,
+	})
+}
+
+// ClearFaults removes all faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the requests received so far, oldest first
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// graphQLError is an entry in a response's errors array
+type graphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// ServeHTTP executes a GraphQL request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeErrors(w, http.StatusMethodNotAllowed, nil, graphQLError{Message: "GraphQL requests must use POST"})
+		return
+	}
+
+	var body struct {
+		Query         string         `json:"query"`
+		OperationName string         `json:"operationName"`
+		Variables     map[string]any `json:"variables"`
+	}
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		writeErrors(w, http.StatusBadRequest, nil, graphQLError{Message: "Invalid JSON body: " + err.Error(), Extensions: map[string]any{"code": "BAD_REQUEST"}})
+		return
+	}
+	variables, _ := normalizeNumbers(body.Variables).(map[string]any)
+
+	doc, err := parseDocument(body.Query)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, nil, graphQLError{Message: err.Error(), Extensions: map[string]any{"code": "GRAPHQL_PARSE_FAILED"}})
+		return
+	}
+	op, err := doc.selectOperation(body.OperationName)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, nil, graphQLError{Message: err.Error(), Extensions: map[string]any{"code": "GRAPHQL_VALIDATION_FAILED"}})
+		return
+	}
+
+	req := Request{
+		OperationName: op.name,
+		Mutation:      op.kind == "mutation",
+		Query:         body.Query,
+		Variables:     variables,
+		Header:        r.Header.Clone(),
+	}
+	for _, sel := range op.selections {
+		if sel.name != "" {
+			req.Fields = append(req.Fields, sel.name)
+		}
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	authorized := s.authorized(r.Header.Get("Authorization"))
+	fault := s.takeFault(req)
+	s.mu.Unlock()
+
+	if !authorized {
+		writeErrors(w, http.StatusUnauthorized, nil, graphQLError{
+			Message:    "Authentication required, not authenticated",
+			Extensions: map[string]any{"code": "AUTHENTICATION_ERROR"},
+		})
+		return
+	}
+
+	if fault != nil {
+		if fault.Delay > 0 {
+			timer := time.NewTimer(fault.Delay)
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			}
+		}
+		if fault.Status != 0 || fault.Message != "" || fault.Code != "" {
+			writeFault(w, fault)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	data, err := s.execute(doc, op, variables)
+	s.mu.Unlock()
+
+	if err != nil {
+		gerr := graphQLError{Message: err.Error()}
+		status := http.StatusBadRequest
+		code := "GRAPHQL_VALIDATION_FAILED"
+		if e, ok := err.(*gqlError); ok {
+			status = http.StatusOK
+			code = e.code
+			gerr.Path = e.path
+		}
+		gerr.Extensions = map[string]any{"code": code}
+		writeErrors(w, status, nil, gerr)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+// authorized checks the Authorization header against the required key
+func (s *Server) authorized(header string) bool {
+	if s.apiKey == "" {
+		return header != ""
+	}
+	return header == s.apiKey || header == "Bearer "+s.apiKey
+}
+
+// takeFault returns the first fault matching a request, using up one of
+// its Times
+func (s *Server) takeFault(req Request) *Fault {
+	for i, f := range s.faults {
+		if f.Operation != "" && f.Operation != req.OperationName && !slices.Contains(req.Fields, f.Operation) {
+			continue
+		}
+		if f.After > 0 {
+			f.After--
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = slices.Delete(s.faults, i, i+1)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// execute runs an operation against the store
+func (s *Server) execute(doc *document, op *operation, variables map[string]any) (*orderedMap, error) {
+	vars, err := coerceVariables(op, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock()
+	ex := &executor{doc: doc, vars: vars, matcher: matcher{now: now.UTC()}}
+
+	var root *object
+	switch op.kind {
+	case "query":
+		root = s.data.queryRoot()
+	case "mutation":
+		root = s.data.mutationRoot(now)
+	default:
+		return nil, fmt.Errorf("%s operations are not supported", op.kind)
+	}
+	return ex.execute(root, op.selections, nil)
+}
+
+func writeFault(w http.ResponseWriter, f *Fault) {
+	message := f.Message
+	if message == "" {
+		message = "Internal server error"
+	}
+	gerr := graphQLError{Message: message}
+	if f.Code != "" {
+		gerr.Extensions = map[string]any{"code": f.Code}
+	}
+
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(f.RetryAfter.Seconds()))))
+	}
+	if f.Code == "RATELIMITED" {
+		w.Header().Set("X-RateLimit-Requests-Remaining", "0")
+	}
+
+	status := f.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	writeErrors(w, status, nil, gerr)
+}
+
+func writeErrors(w http.ResponseWriter, status int, data any, errs ...graphQLError) {
+	writeJSON(w, status, map[string]any{"data": data, "errors": errs})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = io.Copy(w, &buf)
+}
+
+// normalizeNumbers converts decoded JSON numbers to int64 when integral and
+// float64 otherwise, matching how literals in the document are parsed
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if !strings.ContainsAny(v.String(), ".eE") {
+			if n, err := v.Int64(); err == nil {
+				return n
+			}
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, item := range v {
+			v[k] = normalizeNumbers(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+		return v
+	}
+	return v
+}
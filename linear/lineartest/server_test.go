@@ -0,0 +1,583 @@
+package lineartest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukky/linear/linear"
+	"github.com/dukky/linear/linear/lineartest"
+)
+
+// seed is a small workspace: two teams, two users, a project per team and
+// a workspace label
+type seed struct {
+	srv      *lineartest.Server
+	eng, ops lineartest.Team
+	ada, bob lineartest.User
+	mobile   lineartest.Project
+	infra    lineartest.Project
+	bug      lineartest.Label
+}
+
+func newSeed(t *testing.T) *seed {
+	t.Helper()
+
+	srv := lineartest.NewServer()
+	t.Cleanup(srv.Close)
+
+	s := &seed{srv: srv}
+	s.ada = srv.AddUser(lineartest.User{Name: "Ada Lovelace", Email: "ada@example.com"})
+	s.bob = srv.AddUser(lineartest.User{Name: "Bob Smith", Email: "bob@example.com"})
+	s.eng = srv.AddTeam(lineartest.Team{Key: "ENG", Name: "Engineering"})
+	s.ops = srv.AddTeam(lineartest.Team{Key: "OPS", Name: "Operations"})
+	s.mobile = srv.AddProject(lineartest.Project{Name: "Mobile App", TeamIDs: []string{s.eng.ID}})
+	s.infra = srv.AddProject(lineartest.Project{Name: "Infrastructure", TeamIDs: []string{s.ops.ID}})
+	s.bug = srv.AddLabel(lineartest.Label{Name: "bug", Color: "#eb5757"})
+	return s
+}
+
+func TestServer_ListIssuesFiltersByTeamAndProject(t *testing.T) {
+	s := newSeed(t)
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Fix login", ProjectID: s.mobile.ID})
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Write docs"})
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.ops.ID, Title: "Rotate keys", ProjectID: s.infra.ID})
+
+	client := s.srv.Client()
+	ctx := context.Background()
+
+	resp, err := client.ListIssues(ctx, linear.ListIssuesOptions{TeamKey: "ENG"})
+	if err != nil {
+		t.Fatalf("ListIssues() error = %v", err)
+	}
+	if got := identifiers(resp.Issues.Nodes); got != "ENG-1,ENG-2" {
+		t.Errorf("team filter returned %s", got)
+	}
+
+	resp, err = client.ListIssues(ctx, linear.ListIssuesOptions{ProjectID: s.infra.ID})
+	if err != nil {
+		t.Fatalf("ListIssues() error = %v", err)
+	}
+	if got := identifiers(resp.Issues.Nodes); got != "OPS-1" {
+		t.Errorf("project filter returned %s", got)
+	}
+	if issue := resp.Issues.Nodes[0]; issue.Team == nil || issue.Team.Key != "OPS" || issue.State == nil || issue.State.Name != "Backlog" {
+		t.Errorf("unexpected relations: %+v", issue)
+	}
+}
+
+func TestServer_ListAllIssuesPaginates(t *testing.T) {
+	s := newSeed(t)
+	for i := 0; i < 230; i++ {
+		s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: fmt.Sprintf("Issue %d", i)})
+	}
+
+	issues, err := s.srv.Client().ListAllIssues(context.Background(), linear.ListIssuesOptions{TeamKey: "ENG"})
+	if err != nil {
+		t.Fatalf("ListAllIssues() error = %v", err)
+	}
+	if len(issues) != 230 {
+		t.Fatalf("got %d issues, want 230", len(issues))
+	}
+	if issues[229].Identifier != "ENG-230" {
+		t.Errorf("last issue = %s, want ENG-230", issues[229].Identifier)
+	}
+	if n := len(s.srv.Requests()); n != 3 {
+		t.Errorf("made %d requests, want 3 pages", n)
+	}
+}
+
+func TestServer_ListStatesLabelsAndUsersPaginate(t *testing.T) {
+	s := newSeed(t)
+	// 2 seeded teams and 50 more have 260 states with 5 each
+	for i := 0; i < 50; i++ {
+		s.srv.AddTeam(lineartest.Team{Key: fmt.Sprintf("T%d", i)})
+	}
+	for i := 0; i < 250; i++ {
+		s.srv.AddLabel(lineartest.Label{Name: fmt.Sprintf("label-%d", i)})
+		s.srv.AddUser(lineartest.User{Name: fmt.Sprintf("User %d", i), Email: fmt.Sprintf("user%d@example.com", i)})
+	}
+	client := s.srv.Client()
+	ctx := context.Background()
+
+	states, err := client.ListWorkflowStates(ctx)
+	if err != nil {
+		t.Fatalf("ListWorkflowStates() error = %v", err)
+	}
+	if n := len(states.WorkflowStates.Nodes); n != 260 {
+		t.Errorf("got %d states, want 260", n)
+	}
+	if last := states.WorkflowStates.Nodes[len(states.WorkflowStates.Nodes)-1]; last.Team == nil || last.Team.Key != "T49" {
+		t.Errorf("last state = %+v, want one of T49", last)
+	}
+
+	labels, err := client.ListLabels(ctx)
+	if err != nil {
+		t.Fatalf("ListLabels() error = %v", err)
+	}
+	if n := len(labels.IssueLabels.Nodes); n != 251 {
+		t.Errorf("got %d labels, want 251", n)
+	}
+
+	users, err := client.ListUsers(ctx)
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	if n := len(users.Users.Nodes); n != 252 {
+		t.Errorf("got %d users, want 252", n)
+	}
+
+	if n := len(s.srv.Requests()); n != 6 {
+		t.Errorf("made %d requests, want 2 pages each", n)
+	}
+}
+
+func TestServer_CreateThenUpdate(t *testing.T) {
+	s := newSeed(t)
+	client := s.srv.Client()
+	ctx := context.Background()
+
+	created, err := client.CreateIssue(ctx, linear.CreateIssueInput{
+		Title:       "Crash on launch",
+		Description: "Steps to reproduce",
+		TeamID:      s.eng.ID,
+		AssigneeID:  s.bob.ID,
+		LabelIds:    []string{s.bug.ID},
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if created.IssueCreate.Issue.Identifier != "ENG-1" {
+		t.Fatalf("created %+v", created.IssueCreate.Issue)
+	}
+
+	project, err := client.GetProjectByIdentifier(ctx, "mobile app", s.eng.ID)
+	if err != nil {
+		t.Fatalf("GetProjectByIdentifier() error = %v", err)
+	}
+
+	priority := 1
+	empty := ""
+	if _, err := client.UpdateIssue(ctx, "ENG-1", linear.UpdateIssueInput{
+		Priority:    &priority,
+		ProjectID:   &project.ID,
+		Description: &empty,
+	}); err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+
+	resp, err := client.GetIssue(ctx, "ENG-1")
+	if err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	issue := resp.Issue
+	if issue.Priority != 1 || issue.PriorityLabel != "Urgent" {
+		t.Errorf("priority = %d %q", issue.Priority, issue.PriorityLabel)
+	}
+	if issue.Project == nil || issue.Project.Name != "Mobile App" {
+		t.Errorf("project = %+v", issue.Project)
+	}
+	if issue.Description != nil {
+		t.Errorf("description = %q, want null", *issue.Description)
+	}
+	if issue.Assignee == nil || issue.Assignee.Email != "bob@example.com" {
+		t.Errorf("assignee = %+v", issue.Assignee)
+	}
+	if issue.Creator == nil || issue.Creator.Name != "Ada Lovelace" {
+		t.Errorf("creator = %+v", issue.Creator)
+	}
+	if len(issue.Labels.Nodes) != 1 || issue.Labels.Nodes[0].Name != "bug" {
+		t.Errorf("labels = %+v", issue.Labels.Nodes)
+	}
+
+	stored, ok := s.srv.Issue("ENG-1")
+	if !ok || stored.ProjectID != s.mobile.ID || stored.Priority != 1 {
+		t.Errorf("stored issue = %+v", stored)
+	}
+}
+
+func TestServer_RejectedMutationLeavesIssueUnchanged(t *testing.T) {
+	s := newSeed(t)
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Original"})
+
+	title := "Renamed"
+	missing := "00000000-0000-4000-8000-999999999999"
+	_, err := s.srv.Client().UpdateIssue(context.Background(), "ENG-1", linear.UpdateIssueInput{Title: &title, ProjectID: &missing})
+
+	var apiErr *linear.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if issue, _ := s.srv.Issue("ENG-1"); issue.Title != "Original" {
+		t.Errorf("title = %q, want unchanged", issue.Title)
+	}
+}
+
+func TestServer_LookupErrors(t *testing.T) {
+	s := newSeed(t)
+	s.srv.AddProject(lineartest.Project{Name: "Mobile Web", TeamIDs: []string{s.eng.ID}})
+	client := s.srv.Client()
+	ctx := context.Background()
+
+	_, err := client.GetIssue(ctx, "ENG-404")
+	var apiErr *linear.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("GetIssue() error = %v, want not found", err)
+	}
+
+	if _, err := client.GetUserByEmail(ctx, "nobody@example.com"); !errors.Is(err, linear.ErrNotFound) {
+		t.Errorf("GetUserByEmail() error = %v, want ErrNotFound", err)
+	}
+
+	if _, err := client.GetProjectByIdentifier(ctx, "mobile", s.eng.ID); !errors.Is(err, linear.ErrAmbiguous) {
+		t.Errorf("GetProjectByIdentifier() error = %v, want ErrAmbiguous", err)
+	}
+
+	resp, err := client.GetTeamByKey(ctx, "NOPE")
+	if err != nil || len(resp.Teams.Nodes) != 0 {
+		t.Errorf("GetTeamByKey() = %+v, %v; want no teams", resp, err)
+	}
+}
+
+func TestServer_RateLimitIsRetried(t *testing.T) {
+	s := newSeed(t)
+	s.srv.RateLimit(2, 0)
+
+	if _, err := s.srv.Client().ListTeams(context.Background()); err != nil {
+		t.Fatalf("ListTeams() error = %v", err)
+	}
+	if n := len(s.srv.Requests()); n != 3 {
+		t.Errorf("made %d requests, want 3", n)
+	}
+
+	s.srv.RateLimit(0, time.Second)
+	_, err := s.srv.Client(linear.WithRetryPolicy(linear.NoRetry)).ListTeams(context.Background())
+	var apiErr *linear.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsRateLimited() || apiErr.RetryAfter != time.Second {
+		t.Fatalf("expected rate limit error with Retry-After, got %#v", err)
+	}
+}
+
+func TestServer_FailMatchesOperation(t *testing.T) {
+	s := newSeed(t)
+	s.srv.Fail(lineartest.Fault{Operation: "issueCreate", Status: http.StatusServiceUnavailable, Times: 1})
+	client := s.srv.Client()
+	ctx := context.Background()
+
+	if _, err := client.ListTeams(ctx); err != nil {
+		t.Fatalf("ListTeams() should not match the fault: %v", err)
+	}
+
+	_, err := client.CreateIssue(ctx, linear.CreateIssueInput{Title: "Retry me", TeamID: s.eng.ID})
+	var apiErr *linear.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %v", err)
+	}
+	if len(s.srv.Issues()) != 0 {
+		t.Error("failed mutation should not create an issue")
+	}
+
+	// Mutations are not retried, and the fault is used up
+	if _, err := client.CreateIssue(ctx, linear.CreateIssueInput{Title: "Retry me", TeamID: s.eng.ID}); err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if n := len(s.srv.Requests()); n != 3 {
+		t.Errorf("made %d requests, want 3", n)
+	}
+}
+
+func TestServer_DelayHonorsRequestTimeout(t *testing.T) {
+	s := newSeed(t)
+	s.srv.Fail(lineartest.Fault{Delay: time.Minute})
+
+	client := s.srv.Client(linear.WithRequestTimeout(20*time.Millisecond), linear.WithRetryPolicy(linear.NoRetry))
+	if _, err := client.ListTeams(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestServer_RequireAPIKey(t *testing.T) {
+	s := newSeed(t)
+	s.srv.RequireAPIKey("lin_api_secret")
+
+	_, err := s.srv.Client(linear.WithAPIKey("lin_api_wrong")).ListTeams(context.Background())
+	var apiErr *linear.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsAuthError() {
+		t.Fatalf("expected auth error, got %v", err)
+	}
+
+	if _, err := s.srv.Client().ListTeams(context.Background()); err != nil {
+		t.Fatalf("Client() should use the required key: %v", err)
+	}
+	if _, err := s.srv.Client(linear.WithTokenSource(linear.StaticTokenSource("lin_api_secret"))).ListTeams(context.Background()); err != nil {
+		t.Fatalf("Bearer token rejected: %v", err)
+	}
+}
+
+// do runs a raw query and returns the data as generic JSON
+func do(t *testing.T, srv *lineartest.Server, query string, vars map[string]any) map[string]any {
+	t.Helper()
+	var data map[string]any
+	if err := srv.Client().Do(context.Background(), query, vars, &data); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	return data
+}
+
+// titles extracts issue titles from data.issues.nodes
+func titles(data map[string]any) string {
+	var out []string
+	for _, node := range data["issues"].(map[string]any)["nodes"].([]any) {
+		out = append(out, node.(map[string]any)["title"].(string))
+	}
+	return strings.Join(out, ",")
+}
+
+func TestServer_IssueFilters(t *testing.T) {
+	s := newSeed(t)
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	states := s.srv.States(s.eng.ID)
+
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Old bug", Priority: 1, LabelIDs: []string{s.bug.ID}, AssigneeID: s.ada.ID, CreatedAt: start.AddDate(0, 0, -30)})
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "New feature", Priority: 3, StateID: states[2].ID, CreatedAt: start.AddDate(0, 0, -2)})
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.ops.ID, Title: "Disk full", Priority: 2, LabelIDs: []string{s.bug.ID}, AssigneeID: s.bob.ID, CreatedAt: start.AddDate(0, 0, -1), DueDate: "2025-01-10"})
+	s.srv.SetClock(func() time.Time { return start })
+
+	query := `query($filter: IssueFilter) { issues(filter: $filter) { nodes { title } } }`
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{name: "label some", filter: `{"labels": {"some": {"name": {"eq": "bug"}}}}`, want: "Old bug,Disk full"},
+		{name: "label none", filter: `{"labels": {"none": {"name": {"eq": "bug"}}}}`, want: "New feature"},
+		{name: "state type", filter: `{"state": {"type": {"eq": "started"}}}`, want: "New feature"},
+		{name: "priority range", filter: `{"priority": {"gte": 2, "lte": 3}}`, want: "New feature,Disk full"},
+		{name: "unassigned", filter: `{"assignee": {"null": true}}`, want: "New feature"},
+		{name: "assignee is me", filter: `{"assignee": {"isMe": {"eq": true}}}`, want: "Old bug"},
+		{name: "relative date", filter: `{"createdAt": {"gt": "-P1W"}}`, want: "New feature,Disk full"},
+		{name: "due date", filter: `{"dueDate": {"lt": "2025-02-01"}}`, want: "Disk full"},
+		{name: "title", filter: `{"title": {"containsIgnoreCase": "BUG"}}`, want: "Old bug"},
+		{name: "or", filter: `{"or": [{"team": {"key": {"eq": "OPS"}}}, {"priority": {"eq": 1}}]}`, want: "Old bug,Disk full"},
+		{name: "in", filter: `{"team": {"key": {"in": ["ops", "OPS"]}}}`, want: "Disk full"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter map[string]any
+			if err := json.Unmarshal([]byte(tt.filter), &filter); err != nil {
+				t.Fatal(err)
+			}
+			if got := titles(do(t, s.srv, query, map[string]any{"filter": filter})); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_UnknownFilterField(t *testing.T) {
+	s := newSeed(t)
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Fix login"})
+
+	err := s.srv.Client().Do(context.Background(), `{ issues(filter: { colour: { eq: "red" } }) { nodes { id } } }`, nil, nil)
+
+	var apiErr *linear.APIError
+	if !errors.As(err, &apiErr) || !strings.Contains(apiErr.Message, `"colour"`) {
+		t.Fatalf("expected filter error, got %v", err)
+	}
+}
+
+func TestServer_QueryFeatures(t *testing.T) {
+	s := newSeed(t)
+	issue := s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Fix login"})
+	s.srv.AddComment(lineartest.Comment{IssueID: issue.ID, UserID: s.bob.ID, Body: "Looking into it"})
+
+	data := do(t, s.srv, `
+		query Issue($id: String!, $withComments: Boolean = true, $skipTeam: Boolean!) {
+			it: issue(id: $id) {
+				__typename
+				...Basics
+				team @skip(if: $skipTeam) { key }
+				... on Issue { comments @include(if: $withComments) { nodes { body user { name } } } }
+			}
+			me: viewer { name }
+		}
+		fragment Basics on Issue { identifier title }
+	`, map[string]any{"id": "eng-1", "skipTeam": true})
+
+	got, _ := json.Marshal(data)
+	want := `{"it":{"__typename":"Issue","comments":{"nodes":[{"body":"Looking into it","user":{"name":"Bob Smith"}}]},"identifier":"ENG-1","title":"Fix login"},"me":{"name":"Ada Lovelace"}}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	requests := s.srv.Requests()
+	last := requests[len(requests)-1]
+	if last.OperationName != "Issue" || strings.Join(last.Fields, ",") != "issue,viewer" || last.Mutation {
+		t.Errorf("recorded request = %+v", last)
+	}
+}
+
+func TestServer_ValidationErrors(t *testing.T) {
+	s := newSeed(t)
+	client := s.srv.Client(linear.WithRetryPolicy(linear.NoRetry))
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "unknown field", query: `{ teams { nodes { colour } } }`, want: `Cannot query field "colour" on type "Team".`},
+		{name: "missing selection", query: `{ viewer }`, want: "must have a selection of subfields"},
+		{name: "scalar selection", query: `{ viewer { name { first } } }`, want: "must not have a selection"},
+		{name: "syntax", query: `{ teams { nodes { id }`, want: "Syntax Error"},
+		{name: "required variable", query: `query($id: String!) { issue(id: $id) { id } }`, want: `Variable "$id" of required type "String!" was not provided.`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.Do(context.Background(), tt.query, nil, nil)
+			var apiErr *linear.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || !strings.Contains(apiErr.Message, tt.want) {
+				t.Fatalf("expected 400 containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestServer_CommentsAndStates(t *testing.T) {
+	s := newSeed(t)
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Ship it"})
+	done := s.srv.States(s.eng.ID)[3]
+
+	data := do(t, s.srv, `
+		mutation($id: String!, $stateId: String!) {
+			issueUpdate(id: $id, input: { stateId: $stateId }) { success issue { state { name } completedAt } }
+			commentCreate(input: { issueId: $id, body: "Released in 1.2" }) { success comment { body user { email } } }
+		}
+	`, map[string]any{"id": "ENG-1", "stateId": done.ID})
+
+	update := data["issueUpdate"].(map[string]any)["issue"].(map[string]any)
+	if update["state"].(map[string]any)["name"] != "Done" || update["completedAt"] == nil {
+		t.Errorf("issueUpdate = %v", update)
+	}
+	comments := s.srv.Comments("ENG-1")
+	if len(comments) != 1 || comments[0].Body != "Released in 1.2" || comments[0].UserID != s.ada.ID {
+		t.Errorf("comments = %+v", comments)
+	}
+}
+
+func TestServer_History(t *testing.T) {
+	s := newSeed(t)
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Ship it"})
+	states := s.srv.States(s.eng.ID)
+	s.srv.AddHistory(lineartest.History{IssueID: "ENG-1", ActorID: s.bob.ID, FromStateID: states[0].ID, ToStateID: states[1].ID})
+
+	data := do(t, s.srv, `
+		mutation($id: String!, $stateId: String!) {
+			issueUpdate(id: $id, input: { stateId: $stateId }) { success }
+			again: issueUpdate(id: $id, input: { title: "Ship it now" }) { success }
+		}
+	`, map[string]any{"id": "ENG-1", "stateId": states[2].ID})
+	if data["issueUpdate"].(map[string]any)["success"] != true {
+		t.Fatalf("issueUpdate = %v", data)
+	}
+
+	// Every update is recorded, by the viewer, with states only for moves
+	history := s.srv.History("ENG-1")
+	if len(history) != 3 || history[1].ActorID != s.ada.ID || history[1].ToStateID != states[2].ID {
+		t.Fatalf("history = %+v", history)
+	}
+	if history[2].ActorID != s.ada.ID || history[2].FromStateID != "" || history[2].ToStateID != "" {
+		t.Errorf("title change = %+v", history[2])
+	}
+
+	data = do(t, s.srv, `{ issue(id: "ENG-1") { history { nodes { actor { name } fromState { name } toState { name } } } } }`, nil)
+	nodes := data["issue"].(map[string]any)["history"].(map[string]any)["nodes"].([]any)
+	var changes []string
+	for _, node := range nodes {
+		h := node.(map[string]any)
+		change := fmt.Sprintf("%s: edit", h["actor"].(map[string]any)["name"])
+		if h["toState"] != nil {
+			change = fmt.Sprintf("%s: %s -> %s", h["actor"].(map[string]any)["name"], h["fromState"].(map[string]any)["name"], h["toState"].(map[string]any)["name"])
+		}
+		changes = append(changes, change)
+	}
+	if got := strings.Join(changes, "; "); got != "Bob Smith: Backlog -> Todo; Ada Lovelace: Backlog -> In Progress; Ada Lovelace: edit" {
+		t.Errorf("history = %q", got)
+	}
+}
+
+func TestServer_ArchivedIssuesAreHidden(t *testing.T) {
+	s := newSeed(t)
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Keep"})
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Archive me"})
+
+	do(t, s.srv, `mutation { issueArchive(id: "ENG-2") { success } }`, nil)
+
+	if got := titles(do(t, s.srv, `{ issues { nodes { title } } }`, nil)); got != "Keep" {
+		t.Errorf("issues = %q", got)
+	}
+	if got := titles(do(t, s.srv, `{ issues(includeArchived: true) { nodes { title } } }`, nil)); got != "Keep,Archive me" {
+		t.Errorf("issues with archived = %q", got)
+	}
+}
+
+func identifiers(issues []linear.Issue) string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.Identifier
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestServer_TypedIssueFilters(t *testing.T) {
+	s := newSeed(t)
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	states := s.srv.States(s.eng.ID)
+
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "Old bug", Priority: 1, LabelIDs: []string{s.bug.ID}, AssigneeID: s.ada.ID, ProjectID: s.mobile.ID, CreatedAt: start.AddDate(0, 0, -30)})
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.eng.ID, Title: "New feature", Priority: 3, StateID: states[2].ID, CreatedAt: start.AddDate(0, 0, -2)})
+	s.srv.AddIssue(lineartest.Issue{TeamID: s.ops.ID, Title: "Disk full", Priority: 2, LabelIDs: []string{s.bug.ID}, AssigneeID: s.bob.ID, CreatedAt: start.AddDate(0, 0, -1)})
+	s.srv.SetClock(func() time.Time { return start })
+
+	tests := []struct {
+		name   string
+		filter linear.IssueFilter
+		want   string
+	}{
+		{"label some", linear.IssueFilter{}.Labels(linear.Some(linear.LabelFilter{}.Name(linear.EqIgnoreCase("BUG")))), "ENG-1,OPS-1"},
+		{"state in", linear.IssueFilter{}.State(linear.StateFilter{}.Type(linear.In("started", "completed"))), "ENG-2"},
+		{"priority range", linear.IssueFilter{}.Priority(linear.Gte(2), linear.Lte(3)), "ENG-2,OPS-1"},
+		{"unassigned", linear.IssueFilter{}.Assignee(linear.UserFilter{}.Null(true)), "ENG-2"},
+		{"no project", linear.IssueFilter{}.Project(linear.ProjectFilter{}.Null(true)), "ENG-2,OPS-1"},
+		{"created after relative", linear.IssueFilter{}.CreatedAt(linear.Gt("-P1W")), "ENG-2,OPS-1"},
+		{"created before time", linear.IssueFilter{}.CreatedAt(linear.Lt(start.AddDate(0, 0, -7))), "ENG-1"},
+		{"or", linear.IssueFilter{}.Or(
+			linear.IssueFilter{}.Team(linear.TeamFilter{}.Key(linear.Eq("OPS"))),
+			linear.IssueFilter{}.Assignee(linear.UserFilter{}.Email(linear.Eq("ada@example.com"))),
+		), "ENG-1,OPS-1"},
+		{"and", linear.IssueFilter{}.And(
+			linear.IssueFilter{}.Title(linear.NotContains("bug")),
+			linear.IssueFilter{}.Team(linear.TeamFilter{}.Key(linear.Neq("OPS"))),
+		), "ENG-2"},
+	}
+
+	client := s.srv.Client()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.ListIssues(context.Background(), linear.ListIssuesOptions{Filter: tt.filter})
+			if err != nil {
+				t.Fatalf("ListIssues() error = %v", err)
+			}
+			if got := identifiers(resp.Issues.Nodes); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
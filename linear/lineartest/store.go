@@ -0,0 +1,517 @@
+package lineartest
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Team is a team in the fake workspace
+type Team struct {
+	ID          string
+	Key         string
+	Name        string
+	Description string
+	CreatedAt   time.Time
+}
+
+// User is a workspace member
+type User struct {
+	ID          string
+	Name        string
+	DisplayName string
+	Email       string
+	Admin       bool
+	CreatedAt   time.Time
+}
+
+// State is a workflow state. Type is one of triage, backlog, unstarted,
+// started, completed or canceled.
+type State struct {
+	ID       string
+	TeamID   string
+	Name     string
+	Type     string
+	Color    string
+	Position float64
+}
+
+// Label is an issue label; labels without a TeamID belong to the workspace
+type Label struct {
+	ID          string
+	TeamID      string
+	Name        string
+	Color       string
+	Description string
+	CreatedAt   time.Time
+}
+
+// Project is a project shared by one or more teams
+type Project struct {
+	ID          string
+	Name        string
+	Description string
+	State       string
+	TeamIDs     []string
+	LeadID      string
+	StartDate   string
+	TargetDate  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Issue is an issue. Identifier and Number are assigned from the team when
+// the issue is added; zero times are reported as null.
+type Issue struct {
+	ID          string
+	Identifier  string
+	Number      int
+	TeamID      string
+	Title       string
+	Description string
+	Priority    int
+	Estimate    *float64
+	DueDate     string
+	StateID     string
+	AssigneeID  string
+	CreatorID   string
+	ProjectID   string
+	ParentID    string
+	LabelIDs    []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	StartedAt   time.Time
+	CompletedAt time.Time
+	CanceledAt  time.Time
+	ArchivedAt  time.Time
+}
+
+// Comment is a comment on an issue
+type Comment struct {
+	ID        string
+	IssueID   string
+	UserID    string
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// History is a change of an issue. Every update records one; only moves
+// between workflow states have FromStateID and ToStateID set, and
+// FromStateID is empty for the state an issue was created in.
+type History struct {
+	ID          string
+	IssueID     string
+	ActorID     string
+	FromStateID string
+	ToStateID   string
+	CreatedAt   time.Time
+}
+
+// defaultStates are created for every team added to the server
+var defaultStates = []State{
+	{Name: "Backlog", Type: "backlog", Color: "#bec2c8"},
+	{Name: "Todo", Type: "unstarted", Color: "#e2e2e2"},
+	{Name: "In Progress", Type: "started", Color: "#f2c94c"},
+	{Name: "Done", Type: "completed", Color: "#5e6ad2"},
+	{Name: "Canceled", Type: "canceled", Color: "#95a2b3"},
+}
+
+// priorityLabels maps Linear priorities to their names
+var priorityLabels = []string{"No priority", "Urgent", "High", "Medium", "Low"}
+
+// urlBase is the workspace URL used for issue, project and comment links
+const urlBase = "https://linear.app/test"
+
+// store holds the workspace in insertion order
+type store struct {
+	teams    []*Team
+	users    []*User
+	states   []*State
+	labels   []*Label
+	projects []*Project
+	issues   []*Issue
+	comments []*Comment
+	history  []*History
+
+	lastID   int
+	viewerID string
+}
+
+// newID returns a UUID-shaped identifier unique within the store
+func (st *store) newID() string {
+	st.lastID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", st.lastID)
+}
+
+func (st *store) team(idOrKey string) *Team {
+	for _, t := range st.teams {
+		if t.ID == idOrKey || strings.EqualFold(t.Key, idOrKey) {
+			return t
+		}
+	}
+	return nil
+}
+
+func (st *store) user(id string) *User {
+	for _, u := range st.users {
+		if u.ID == id {
+			return u
+		}
+	}
+	return nil
+}
+
+func (st *store) state(id string) *State {
+	for _, s := range st.states {
+		if s.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+func (st *store) label(id string) *Label {
+	for _, l := range st.labels {
+		if l.ID == id {
+			return l
+		}
+	}
+	return nil
+}
+
+func (st *store) project(id string) *Project {
+	for _, p := range st.projects {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (st *store) issue(idOrIdentifier string) *Issue {
+	for _, i := range st.issues {
+		if i.ID == idOrIdentifier || strings.EqualFold(i.Identifier, idOrIdentifier) {
+			return i
+		}
+	}
+	return nil
+}
+
+func (st *store) comment(id string) *Comment {
+	for _, c := range st.comments {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// teamStates returns a team's workflow states ordered by position
+func (st *store) teamStates(teamID string) []*State {
+	var states []*State
+	for _, s := range st.states {
+		if s.TeamID == teamID {
+			states = append(states, s)
+		}
+	}
+	slices.SortStableFunc(states, func(a, b *State) int { return cmp(a.Position, b.Position) })
+	return states
+}
+
+// defaultState is the state new issues start in: the team's first backlog
+// state, otherwise its first unstarted state
+func (st *store) defaultState(teamID string) *State {
+	states := st.teamStates(teamID)
+	for _, typ := range []string{"backlog", "unstarted"} {
+		for _, s := range states {
+			if s.Type == typ {
+				return s
+			}
+		}
+	}
+	if len(states) > 0 {
+		return states[0]
+	}
+	return nil
+}
+
+// nextNumber returns the next issue number for a team
+func (st *store) nextNumber(teamID string) int {
+	n := 0
+	for _, i := range st.issues {
+		if i.TeamID == teamID && i.Number > n {
+			n = i.Number
+		}
+	}
+	return n + 1
+}
+
+// timestamp formats a time the way the API does, with null for zero times
+func timestamp(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// nullable returns nil for empty strings
+func nullable(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slug lowercases a title into a URL and branch name fragment
+func slug(s string) string {
+	s = strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(s) > 40 {
+		s = strings.TrimRight(s[:40], "-")
+	}
+	return s
+}
+
+// objects converts entities to GraphQL objects
+func objects[T any](items []*T, build func(*T) *object, keep func(*T) bool) []*object {
+	var out []*object
+	for _, item := range items {
+		if keep == nil || keep(item) {
+			out = append(out, build(item))
+		}
+	}
+	return out
+}
+
+// ref wraps a lookup as a relation that resolves to nil when unset
+func ref[T any](lookup func() *T, build func(*T) *object) func() *object {
+	return func() *object {
+		if v := lookup(); v != nil {
+			return build(v)
+		}
+		return nil
+	}
+}
+
+func (st *store) teamObject(t *Team) *object {
+	obj := newObject("Team")
+	obj.fields["id"] = func() any { return t.ID }
+	obj.fields["key"] = func() any { return t.Key }
+	obj.fields["name"] = func() any { return t.Name }
+	obj.fields["description"] = func() any { return nullable(t.Description) }
+	obj.fields["createdAt"] = func() any { return timestamp(t.CreatedAt) }
+	obj.fields["updatedAt"] = func() any { return timestamp(t.CreatedAt) }
+	obj.lists["states"] = list{"WorkflowState", func() []*object {
+		return objects(st.teamStates(t.ID), st.stateObject, nil)
+	}}
+	obj.lists["labels"] = list{"IssueLabel", func() []*object {
+		return objects(st.labels, st.labelObject, func(l *Label) bool { return l.TeamID == t.ID })
+	}}
+	obj.lists["members"] = list{"User", func() []*object {
+		return objects(st.users, st.userObject, nil)
+	}}
+	obj.lists["issues"] = list{"Issue", func() []*object {
+		return objects(st.issues, st.issueObject, func(i *Issue) bool { return i.TeamID == t.ID })
+	}}
+	obj.lists["projects"] = list{"Project", func() []*object {
+		return objects(st.projects, st.projectObject, func(p *Project) bool { return slices.Contains(p.TeamIDs, t.ID) })
+	}}
+	return obj
+}
+
+func (st *store) userObject(u *User) *object {
+	obj := newObject("User")
+	obj.fields["id"] = func() any { return u.ID }
+	obj.fields["name"] = func() any { return u.Name }
+	obj.fields["displayName"] = func() any {
+		if u.DisplayName != "" {
+			return u.DisplayName
+		}
+		return strings.ToLower(strings.Fields(u.Name + " user")[0])
+	}
+	obj.fields["email"] = func() any { return u.Email }
+	obj.fields["active"] = func() any { return true }
+	obj.fields["admin"] = func() any { return u.Admin }
+	obj.fields["isMe"] = func() any { return u.ID == st.viewerID }
+	obj.fields["createdAt"] = func() any { return timestamp(u.CreatedAt) }
+	obj.lists["assignedIssues"] = list{"Issue", func() []*object {
+		return objects(st.issues, st.issueObject, func(i *Issue) bool { return i.AssigneeID == u.ID })
+	}}
+	obj.lists["createdIssues"] = list{"Issue", func() []*object {
+		return objects(st.issues, st.issueObject, func(i *Issue) bool { return i.CreatorID == u.ID })
+	}}
+	return obj
+}
+
+func (st *store) stateObject(s *State) *object {
+	obj := newObject("WorkflowState")
+	obj.fields["id"] = func() any { return s.ID }
+	obj.fields["name"] = func() any { return s.Name }
+	obj.fields["type"] = func() any { return s.Type }
+	obj.fields["color"] = func() any { return s.Color }
+	obj.fields["position"] = func() any { return s.Position }
+	obj.refs["team"] = ref(func() *Team { return st.team(s.TeamID) }, st.teamObject)
+	obj.lists["issues"] = list{"Issue", func() []*object {
+		return objects(st.issues, st.issueObject, func(i *Issue) bool { return i.StateID == s.ID })
+	}}
+	return obj
+}
+
+func (st *store) labelObject(l *Label) *object {
+	obj := newObject("IssueLabel")
+	obj.fields["id"] = func() any { return l.ID }
+	obj.fields["name"] = func() any { return l.Name }
+	obj.fields["color"] = func() any { return l.Color }
+	obj.fields["description"] = func() any { return nullable(l.Description) }
+	obj.fields["createdAt"] = func() any { return timestamp(l.CreatedAt) }
+	obj.refs["team"] = ref(func() *Team { return st.team(l.TeamID) }, st.teamObject)
+	obj.lists["issues"] = list{"Issue", func() []*object {
+		return objects(st.issues, st.issueObject, func(i *Issue) bool { return slices.Contains(i.LabelIDs, l.ID) })
+	}}
+	return obj
+}
+
+func (st *store) projectObject(p *Project) *object {
+	obj := newObject("Project")
+	obj.fields["id"] = func() any { return p.ID }
+	obj.fields["name"] = func() any { return p.Name }
+	obj.fields["description"] = func() any { return p.Description }
+	obj.fields["state"] = func() any { return p.State }
+	obj.fields["url"] = func() any { return urlBase + "/project/" + slug(p.Name) }
+	obj.fields["startDate"] = func() any { return nullable(p.StartDate) }
+	obj.fields["targetDate"] = func() any { return nullable(p.TargetDate) }
+	obj.fields["createdAt"] = func() any { return timestamp(p.CreatedAt) }
+	obj.fields["updatedAt"] = func() any { return timestamp(p.UpdatedAt) }
+	obj.refs["lead"] = ref(func() *User { return st.user(p.LeadID) }, st.userObject)
+	teams := list{"Team", func() []*object {
+		return objects(st.teams, st.teamObject, func(t *Team) bool { return slices.Contains(p.TeamIDs, t.ID) })
+	}}
+	obj.lists["teams"] = teams
+	obj.lists["accessibleTeams"] = teams
+	obj.lists["issues"] = list{"Issue", func() []*object {
+		return objects(st.issues, st.issueObject, func(i *Issue) bool { return i.ProjectID == p.ID })
+	}}
+	return obj
+}
+
+func (st *store) issueObject(i *Issue) *object {
+	obj := newObject("Issue")
+	obj.fields["id"] = func() any { return i.ID }
+	obj.fields["identifier"] = func() any { return i.Identifier }
+	obj.fields["number"] = func() any { return i.Number }
+	obj.fields["title"] = func() any { return i.Title }
+	obj.fields["description"] = func() any { return nullable(i.Description) }
+	obj.fields["priority"] = func() any { return i.Priority }
+	obj.fields["priorityLabel"] = func() any { return priorityLabels[i.Priority] }
+	obj.fields["estimate"] = func() any {
+		if i.Estimate == nil {
+			return nil
+		}
+		return *i.Estimate
+	}
+	obj.fields["dueDate"] = func() any { return nullable(i.DueDate) }
+	obj.fields["createdAt"] = func() any { return timestamp(i.CreatedAt) }
+	obj.fields["updatedAt"] = func() any { return timestamp(i.UpdatedAt) }
+	obj.fields["startedAt"] = func() any { return timestamp(i.StartedAt) }
+	obj.fields["completedAt"] = func() any { return timestamp(i.CompletedAt) }
+	obj.fields["canceledAt"] = func() any { return timestamp(i.CanceledAt) }
+	obj.fields["archivedAt"] = func() any { return timestamp(i.ArchivedAt) }
+	obj.fields["url"] = func() any { return urlBase + "/issue/" + i.Identifier + "/" + slug(i.Title) }
+	obj.fields["branchName"] = func() any { return strings.ToLower(i.Identifier) + "-" + slug(i.Title) }
+	obj.fields["labelIds"] = func() any { return append([]string{}, i.LabelIDs...) }
+	obj.refs["team"] = ref(func() *Team { return st.team(i.TeamID) }, st.teamObject)
+	obj.refs["state"] = ref(func() *State { return st.state(i.StateID) }, st.stateObject)
+	obj.refs["assignee"] = ref(func() *User { return st.user(i.AssigneeID) }, st.userObject)
+	obj.refs["creator"] = ref(func() *User { return st.user(i.CreatorID) }, st.userObject)
+	obj.refs["project"] = ref(func() *Project { return st.project(i.ProjectID) }, st.projectObject)
+	obj.refs["parent"] = ref(func() *Issue {
+		if i.ParentID == "" {
+			return nil
+		}
+		return st.issue(i.ParentID)
+	}, st.issueObject)
+	obj.lists["labels"] = list{"IssueLabel", func() []*object {
+		return objects(st.labels, st.labelObject, func(l *Label) bool { return slices.Contains(i.LabelIDs, l.ID) })
+	}}
+	obj.lists["comments"] = list{"Comment", func() []*object {
+		return objects(st.comments, st.commentObject, func(c *Comment) bool { return c.IssueID == i.ID })
+	}}
+	obj.lists["children"] = list{"Issue", func() []*object {
+		return objects(st.issues, st.issueObject, func(c *Issue) bool { return c.ParentID == i.ID })
+	}}
+	obj.lists["history"] = list{"IssueHistory", func() []*object {
+		return objects(st.history, st.historyObject, func(h *History) bool { return h.IssueID == i.ID })
+	}}
+	return obj
+}
+
+func (st *store) historyObject(h *History) *object {
+	obj := newObject("IssueHistory")
+	obj.fields["id"] = func() any { return h.ID }
+	obj.fields["createdAt"] = func() any { return timestamp(h.CreatedAt) }
+	obj.fields["updatedAt"] = func() any { return timestamp(h.CreatedAt) }
+	obj.refs["actor"] = ref(func() *User { return st.user(h.ActorID) }, st.userObject)
+	obj.refs["fromState"] = ref(func() *State { return st.state(h.FromStateID) }, st.stateObject)
+	obj.refs["toState"] = ref(func() *State { return st.state(h.ToStateID) }, st.stateObject)
+	obj.refs["issue"] = ref(func() *Issue { return st.issue(h.IssueID) }, st.issueObject)
+	return obj
+}
+
+func (st *store) commentObject(c *Comment) *object {
+	obj := newObject("Comment")
+	obj.fields["id"] = func() any { return c.ID }
+	obj.fields["body"] = func() any { return c.Body }
+	obj.fields["createdAt"] = func() any { return timestamp(c.CreatedAt) }
+	obj.fields["updatedAt"] = func() any { return timestamp(c.UpdatedAt) }
+	obj.fields["url"] = func() any {
+		if issue := st.issue(c.IssueID); issue != nil {
+			return urlBase + "/issue/" + issue.Identifier + "#comment-" + c.ID
+		}
+		return nil
+	}
+	obj.refs["user"] = ref(func() *User { return st.user(c.UserID) }, st.userObject)
+	obj.refs["issue"] = ref(func() *Issue { return st.issue(c.IssueID) }, st.issueObject)
+	return obj
+}
+
+// queryRoot is the Query type
+func (st *store) queryRoot() *object {
+	root := newObject("Query")
+	root.refs["viewer"] = ref(func() *User { return st.user(st.viewerID) }, st.userObject)
+
+	root.lists["teams"] = list{"Team", func() []*object { return objects(st.teams, st.teamObject, nil) }}
+	root.lists["users"] = list{"User", func() []*object { return objects(st.users, st.userObject, nil) }}
+	root.lists["workflowStates"] = list{"WorkflowState", func() []*object { return objects(st.states, st.stateObject, nil) }}
+	root.lists["issueLabels"] = list{"IssueLabel", func() []*object { return objects(st.labels, st.labelObject, nil) }}
+	root.lists["projects"] = list{"Project", func() []*object { return objects(st.projects, st.projectObject, nil) }}
+	root.lists["issues"] = list{"Issue", func() []*object { return objects(st.issues, st.issueObject, nil) }}
+	root.lists["comments"] = list{"Comment", func() []*object { return objects(st.comments, st.commentObject, nil) }}
+
+	root.methods["team"] = lookup("Team", st.team, st.teamObject)
+	root.methods["user"] = lookup("User", st.user, st.userObject)
+	root.methods["workflowState"] = lookup("WorkflowState", st.state, st.stateObject)
+	root.methods["issueLabel"] = lookup("IssueLabel", st.label, st.labelObject)
+	root.methods["project"] = lookup("Project", st.project, st.projectObject)
+	root.methods["issue"] = lookup("Issue", st.issue, st.issueObject)
+	root.methods["comment"] = lookup("Comment", st.comment, st.commentObject)
+	return root
+}
+
+// lookup builds a root field that fetches one entity by its id argument
+func lookup[T any](typename string, find func(string) *T, build func(*T) *object) func(map[string]any) (any, error) {
+	return func(args map[string]any) (any, error) {
+		id, ok := args["id"].(string)
+		if !ok {
+			return nil, invalidInput("Argument \"id\" of type \"String!\" is required")
+		}
+		v := find(id)
+		if v == nil {
+			return nil, notFound(typename)
+		}
+		return build(v), nil
+	}
+}
@@ -1,4 +1,4 @@
-package client
+package linear
 
 import (
 	"context"
@@ -48,15 +48,7 @@ func (c *Client) GetProjectsByTeam(ctx context.Context, teamID string) (*Project
 	`
 
 	vars := map[string]interface{}{
-		"filter": map[string]interface{}{
-			"accessibleTeams": map[string]interface{}{
-				"some": map[string]interface{}{
-					"id": map[string]interface{}{
-						"eq": teamID,
-					},
-				},
-			},
-		},
+		"filter": ProjectFilter{}.AccessibleTeams(Some(TeamFilter{}.ID(Eq(teamID)))),
 	}
 
 	var resp ProjectsResponse
@@ -98,7 +90,7 @@ func (c *Client) GetProjectByIdentifier(ctx context.Context, identifier string,
 		}
 
 		if resp.Project == nil {
-			return nil, fmt.Errorf("project not found: %s", identifier)
+			return nil, notFoundf("project not found: %s", identifier)
 		}
 
 		return resp.Project, nil
@@ -117,21 +109,11 @@ func (c *Client) GetProjectByIdentifier(ctx context.Context, identifier string,
 	`
 
 	// Build filter with name matching
-	filter := map[string]interface{}{
-		"name": map[string]interface{}{
-			"containsIgnoreCase": identifier,
-		},
-	}
+	filter := ProjectFilter{}.Name(ContainsIgnoreCase(identifier))
 
 	// If team ID is provided, filter by team as well
 	if teamID != "" {
-		filter["accessibleTeams"] = map[string]interface{}{
-			"some": map[string]interface{}{
-				"id": map[string]interface{}{
-					"eq": teamID,
-				},
-			},
-		}
+		filter = filter.AccessibleTeams(Some(TeamFilter{}.ID(Eq(teamID))))
 	}
 
 	vars := map[string]interface{}{
@@ -144,7 +126,7 @@ func (c *Client) GetProjectByIdentifier(ctx context.Context, identifier string,
 	}
 
 	if len(resp.Projects.Nodes) == 0 {
-		return nil, fmt.Errorf("project not found: %s", identifier)
+		return nil, notFoundf("project not found: %s", identifier)
 	}
 
 	return selectProjectByIdentifier(identifier, resp.Projects.Nodes)
@@ -152,7 +134,7 @@ func (c *Client) GetProjectByIdentifier(ctx context.Context, identifier string,
 
 func selectProjectByIdentifier(identifier string, projects []Project) (*Project, error) {
 	if len(projects) == 0 {
-		return nil, fmt.Errorf("project not found: %s", identifier)
+		return nil, notFoundf("project not found: %s", identifier)
 	}
 
 	var exactMatches []*Project
@@ -167,36 +149,47 @@ func selectProjectByIdentifier(identifier string, projects []Project) (*Project,
 	}
 
 	if len(exactMatches) > 1 {
-		return nil, fmt.Errorf(
-			"ambiguous project identifier %q: multiple exact matches found: %s; use project UUID instead",
-			identifier,
-			projectCandidates(exactMatches),
-		)
+		candidates := make([]Project, 0, len(exactMatches))
+		for _, project := range exactMatches {
+			candidates = append(candidates, *project)
+		}
+		return nil, &AmbiguousProjectError{Identifier: identifier, Candidates: candidates, exact: true}
 	}
 
 	if len(projects) == 1 {
 		return &projects[0], nil
 	}
 
-	projectPtrs := make([]*Project, 0, len(projects))
-	for i := range projects {
-		projectPtrs = append(projectPtrs, &projects[i])
-	}
+	return nil, &AmbiguousProjectError{Identifier: identifier, Candidates: projects}
+}
 
-	return nil, fmt.Errorf(
-		"ambiguous project identifier %q: matched %d projects: %s; use exact project name or project UUID",
-		identifier,
-		len(projects),
-		projectCandidates(projectPtrs),
-	)
+// AmbiguousProjectError is returned when a project name matches more than
+// one project. It matches ErrAmbiguous with errors.Is.
+type AmbiguousProjectError struct {
+	// Identifier is the name that was looked up
+	Identifier string
+	// Candidates are the projects it matched
+	Candidates []Project
+	// exact is set when the candidates all have exactly that name
+	exact bool
 }
 
-func projectCandidates(projects []*Project) string {
-	candidates := make([]string, 0, len(projects))
-	for _, project := range projects {
+func (e *AmbiguousProjectError) Error() string {
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, project := range e.Candidates {
 		candidates = append(candidates, fmt.Sprintf("%s (%s)", project.Name, project.ID))
 	}
-	return strings.Join(candidates, ", ")
+
+	if e.exact {
+		return fmt.Sprintf("ambiguous project identifier %q: multiple exact matches found: %s; use project UUID instead",
+			e.Identifier, strings.Join(candidates, ", "))
+	}
+	return fmt.Sprintf("ambiguous project identifier %q: matched %d projects: %s; use exact project name or project UUID",
+		e.Identifier, len(e.Candidates), strings.Join(candidates, ", "))
+}
+
+func (e *AmbiguousProjectError) Is(target error) bool {
+	return target == ErrAmbiguous
 }
 
 // isUUID checks if a string looks like a UUID
@@ -1,8 +1,9 @@
-package client
+package linear
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -203,6 +204,9 @@ func TestGetProjectByIdentifier_NotFound(t *testing.T) {
 	if err.Error() != expectedMsg {
 		t.Errorf("Expected error message '%s', got '%s'", expectedMsg, err.Error())
 	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error to match ErrNotFound, got %v", err)
+	}
 }
 
 func TestIsUUID(t *testing.T) {
@@ -253,4 +257,16 @@ func TestSelectProjectByIdentifier_AmbiguousPartialMatch(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected ambiguity error, got nil")
 	}
+	if !errors.Is(err, ErrAmbiguous) {
+		t.Errorf("Expected error to match ErrAmbiguous, got %v", err)
+	}
+
+	var ambiguous *AmbiguousProjectError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Candidates) != 2 {
+		t.Fatalf("Expected an AmbiguousProjectError listing both projects, got %v", err)
+	}
+	want := `ambiguous project identifier "Mobile": matched 2 projects: Mobile App (proj-1), Mobile Platform (proj-2); use exact project name or project UUID`
+	if err.Error() != want {
+		t.Errorf("Expected error %q, got %q", want, err.Error())
+	}
 }
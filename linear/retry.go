@@ -0,0 +1,107 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+//
+// Rate-limited requests are always safe to retry and are retried for both
+// queries and mutations. Network errors and 502, 503 and 504 responses are
+// retried only for queries, since a mutation may already have been applied.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first;
+	// values below 1 mean a single attempt
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry; it doubles with each
+	// further retry
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, including delays
+	// requested by a Retry-After header
+	MaxBackoff time.Duration
+}
+
+var (
+	// DefaultRetryPolicy makes up to three attempts with exponential backoff
+	DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, MinBackoff: 500 * time.Millisecond, MaxBackoff: 10 * time.Second}
+
+	// NoRetry makes a single attempt
+	NoRetry = RetryPolicy{MaxAttempts: 1}
+)
+
+// attempts returns the number of attempts to make
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the delay before retry number n (starting at 1)
+func (p RetryPolicy) backoff(n int, err error) time.Duration {
+	delay := p.MinBackoff
+	for i := 1; i < n && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+		delay = apiErr.RetryAfter
+	}
+
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+// retryable reports whether a failed request may be sent again
+func retryable(err error, mutation bool) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.IsRateLimited() {
+			return true
+		}
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return !mutation
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && !mutation
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds
+func parseRetryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,140 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fastRetry retries quickly so tests don't wait on backoff
+var fastRetry = RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func newRetryServer(t *testing.T, failures int, status int, body string) (*httptest.Server, *int) {
+	t.Helper()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= failures {
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+			return
+		}
+		w.Write([]byte(`{"data":{"viewer":{"id":"user-1"}}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClient_Do_RetriesRateLimited(t *testing.T) {
+	server, calls := newRetryServer(t, 2, http.StatusBadRequest, `{"errors":[{"message":"Rate limit exceeded","extensions":{"code":"RATELIMITED"}}]}`)
+
+	client, _ := NewClient(WithAPIKey("key"), WithEndpoint(server.URL), WithRetryPolicy(fastRetry))
+
+	var result struct {
+		Viewer struct{ ID string } `json:"viewer"`
+	}
+	if err := client.Do(context.Background(), "mutation { issueArchive(id: \"x\") { success } }", nil, &result); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *calls)
+	}
+	if result.Viewer.ID != "user-1" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestClient_Do_RetriesServerErrorsForQueriesOnly(t *testing.T) {
+	server, calls := newRetryServer(t, 1, http.StatusServiceUnavailable, "unavailable")
+	client, _ := NewClient(WithAPIKey("key"), WithEndpoint(server.URL), WithRetryPolicy(fastRetry))
+
+	if err := client.Do(context.Background(), "query { viewer { id } }", nil, nil); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("Expected query to be retried once, got %d attempts", *calls)
+	}
+
+	server, calls = newRetryServer(t, 1, http.StatusServiceUnavailable, "unavailable")
+	client, _ = NewClient(WithAPIKey("key"), WithEndpoint(server.URL), WithRetryPolicy(fastRetry))
+
+	err := client.Do(context.Background(), "mutation { issueCreate(input: {}) { success } }", nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Expected a 503 APIError, got %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("Expected mutation not to be retried, got %d attempts", *calls)
+	}
+}
+
+func TestClient_Do_DoesNotRetryHandWrittenMutations(t *testing.T) {
+	for name, document := range map[string]string{
+		"commented":      "# Archive the duplicate\nmutation { issueArchive(id: \"x\") { success } }",
+		"fragment first": "fragment Result on IssueArchivePayload { success }\nmutation { issueArchive(id: \"x\") { ...Result } }",
+	} {
+		t.Run(name, func(t *testing.T) {
+			server, calls := newRetryServer(t, 1, http.StatusBadGateway, "bad gateway")
+			client, _ := NewClient(WithAPIKey("key"), WithEndpoint(server.URL), WithRetryPolicy(fastRetry))
+
+			if err := client.Do(context.Background(), document, nil, nil); err == nil {
+				t.Fatal("Expected the 502 to be returned")
+			}
+			if *calls != 1 {
+				t.Errorf("Expected mutation not to be retried, got %d attempts", *calls)
+			}
+		})
+	}
+}
+
+func TestClient_Do_NoRetry(t *testing.T) {
+	server, calls := newRetryServer(t, 1, http.StatusTooManyRequests, "slow down")
+	client, _ := NewClient(WithAPIKey("key"), WithEndpoint(server.URL), WithRetryPolicy(NoRetry))
+
+	if err := client.Do(context.Background(), "query { viewer { id } }", nil, nil); err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if *calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", *calls)
+	}
+}
+
+func TestClient_Do_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := newRetryServer(t, 1, http.StatusBadRequest, `{"errors":[{"message":"Argument Validation Error"}]}`)
+	client, _ := NewClient(WithAPIKey("key"), WithEndpoint(server.URL), WithRetryPolicy(fastRetry))
+
+	if err := client.Do(context.Background(), "query { viewer { id } }", nil, nil); err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if *calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", *calls)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		name  string
+		retry int
+		err   error
+		want  time.Duration
+	}{
+		{name: "first retry", retry: 1, want: 100 * time.Millisecond},
+		{name: "doubles", retry: 3, want: 400 * time.Millisecond},
+		{name: "capped", retry: 10, want: time.Second},
+		{name: "retry-after", retry: 1, err: &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 500 * time.Millisecond}, want: 500 * time.Millisecond},
+		{name: "retry-after capped", retry: 1, err: &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute}, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.backoff(tt.retry, tt.err); got != tt.want {
+				t.Errorf("backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package linear
+
+import "context"
+
+// WorkflowState is a state in a team's workflow
+type WorkflowState struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Color string `json:"color"`
+	// Position orders the states of a team's workflow
+	Position float64 `json:"position"`
+	Team     *Team   `json:"team"`
+}
+
+// WorkflowStatesResponse is the response for listing workflow states
+type WorkflowStatesResponse struct {
+	WorkflowStates struct {
+		Nodes []WorkflowState `json:"nodes"`
+	} `json:"workflowStates"`
+}
+
+// ListWorkflowStates retrieves the workflow states of every team, from
+// every page
+func (c *Client) ListWorkflowStates(ctx context.Context) (*WorkflowStatesResponse, error) {
+	query := `
+		query($after: String) {
+			workflowStates(first: 250, after: $after) {
+				nodes {
+					id
+					name
+					type
+					color
+					position
+					team {
+						id
+						key
+						name
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	nodes, err := allPages[WorkflowState](ctx, c, query, "workflowStates")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp WorkflowStatesResponse
+	resp.WorkflowStates.Nodes = nodes
+	return &resp, nil
+}
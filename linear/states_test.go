@@ -0,0 +1,51 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_ListWorkflowStates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := graphQLResponse{
+			Data: json.RawMessage(`{
+				"workflowStates": {
+					"nodes": [
+						{"id": "state-1", "name": "Todo", "type": "unstarted", "color": "#e2e2e2", "team": {"id": "team-1", "key": "ENG", "name": "Engineering"}},
+						{"id": "state-2", "name": "Done", "type": "completed", "color": "#5e6ad2", "team": {"id": "team-1", "key": "ENG", "name": "Engineering"}}
+					]
+				}
+			}`),
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiKey:     "test-key",
+		endpoint:   server.URL,
+	}
+
+	resp, err := client.ListWorkflowStates(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	states := resp.WorkflowStates.Nodes
+	if len(states) != 2 {
+		t.Fatalf("Expected 2 states, got %d", len(states))
+	}
+
+	if states[1].Name != "Done" || states[1].Type != "completed" {
+		t.Errorf("Expected second state to be Done (completed), got %s (%s)", states[1].Name, states[1].Type)
+	}
+
+	if states[0].Team == nil || states[0].Team.Key != "ENG" {
+		t.Errorf("Expected first state to belong to ENG, got %+v", states[0].Team)
+	}
+}
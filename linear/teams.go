@@ -1,4 +1,4 @@
-package client
+package linear
 
 import "context"
 
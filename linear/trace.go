@@ -0,0 +1,291 @@
+package linear
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces secrets in debug logs and traces
+const redacted = "REDACTED"
+
+// apiKeyPattern matches Linear personal API keys and OAuth tokens
+var apiKeyPattern = regexp.MustCompile(`lin_(api|oauth)_[A-Za-z0-9]+`)
+
+// sensitiveHeaders are replaced with redacted in logs and traces
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// TraceOptions configures a TracingTransport
+type TraceOptions struct {
+	// Log receives a one-line summary of each request (e.g. os.Stderr)
+	Log io.Writer
+	// Trace receives full request/response pairs as JSON lines
+	Trace io.Writer
+}
+
+// TracingTransport is an http.RoundTripper that logs GraphQL requests and
+// their responses, with credentials redacted
+type TracingTransport struct {
+	base    http.RoundTripper
+	options TraceOptions
+	mu      sync.Mutex
+	now     func() time.Time
+}
+
+// NewTracingTransport wraps base, or http.DefaultTransport when base is nil
+func NewTracingTransport(base http.RoundTripper, options TraceOptions) *TracingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &TracingTransport{
+		base:    base,
+		options: options,
+		now:     time.Now,
+	}
+}
+
+// traceEntry is a single request/response pair written to the trace file
+type traceEntry struct {
+	Time       time.Time      `json:"time"`
+	DurationMS int64          `json:"duration_ms"`
+	Operation  string         `json:"operation,omitempty"`
+	Request    traceMessage   `json:"request"`
+	Response   *traceResponse `json:"response,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+type traceMessage struct {
+	Method  string              `json:"method"`
+	URL     string              `json:"url"`
+	Headers map[string][]string `json:"headers"`
+	Body    json.RawMessage     `json:"body,omitempty"`
+}
+
+type traceResponse struct {
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers"`
+	Body    json.RawMessage     `json:"body,omitempty"`
+}
+
+// RoundTrip sends the request through the wrapped transport and records it
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	start := t.now()
+	resp, err := t.base.RoundTrip(req)
+	duration := t.now().Sub(start)
+
+	var respBody []byte
+	if resp != nil && resp.Body != nil {
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	operation, variables := describeGraphQL(reqBody)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.options.Log != nil {
+		t.writeLog(req, resp, err, operation, variables, duration, len(respBody))
+	}
+	if t.options.Trace != nil {
+		t.writeTrace(req, reqBody, resp, respBody, err, operation, start, duration)
+	}
+
+	return resp, err
+}
+
+func (t *TracingTransport) writeLog(req *http.Request, resp *http.Response, err error, operation, variables string, duration time.Duration, size int) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[debug] %s %s", req.Method, req.URL.Redacted())
+	if operation != "" {
+		fmt.Fprintf(&b, " %s", operation)
+	}
+	if variables != "" {
+		fmt.Fprintf(&b, " variables=%s", variables)
+	}
+
+	if err != nil {
+		fmt.Fprintf(&b, " error=%q (%s)\n", redact(err.Error()), duration.Round(time.Millisecond))
+		io.WriteString(t.options.Log, b.String())
+		return
+	}
+
+	fmt.Fprintf(&b, " -> %s (%s, %s)", resp.Status, duration.Round(time.Millisecond), formatSize(size))
+	if limits := rateLimitHeaders(resp.Header); limits != "" {
+		fmt.Fprintf(&b, " %s", limits)
+	}
+	b.WriteString("\n")
+	io.WriteString(t.options.Log, b.String())
+}
+
+func (t *TracingTransport) writeTrace(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, err error, operation string, start time.Time, duration time.Duration) {
+	entry := traceEntry{
+		Time:       start.UTC(),
+		DurationMS: duration.Milliseconds(),
+		Operation:  operation,
+		Request: traceMessage{
+			Method:  req.Method,
+			URL:     req.URL.Redacted(),
+			Headers: redactHeaders(req.Header),
+			Body:    traceBody(reqBody),
+		},
+	}
+	if resp != nil {
+		entry.Response = &traceResponse{
+			Status:  resp.StatusCode,
+			Headers: redactHeaders(resp.Header),
+			Body:    traceBody(respBody),
+		}
+	}
+	if err != nil {
+		entry.Error = redact(err.Error())
+	}
+
+	line, marshalErr := json.Marshal(entry)
+	if marshalErr != nil {
+		return
+	}
+	t.options.Trace.Write(append(line, '\n'))
+}
+
+// readRequestBody reads the request body and restores it for sending
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// describeGraphQL returns the operation name and redacted variables of a
+// GraphQL request body
+func describeGraphQL(body []byte) (string, string) {
+	var payload struct {
+		Query         string          `json:"query"`
+		OperationName string          `json:"operationName"`
+		Variables     json.RawMessage `json:"variables"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", ""
+	}
+
+	operation := payload.OperationName
+	if operation == "" {
+		operation = operationName(payload.Query)
+	}
+
+	variables := ""
+	if len(payload.Variables) > 0 && string(payload.Variables) != "null" {
+		variables = redact(string(payload.Variables))
+	}
+	return operation, variables
+}
+
+var operationPattern = regexp.MustCompile(`^\s*(query|mutation|subscription)?\s*([A-Za-z_][A-Za-z0-9_]*)?`)
+var rootFieldPattern = regexp.MustCompile(`^\s*(?:[A-Za-z_][A-Za-z0-9_]*\s*:\s*)?([A-Za-z_][A-Za-z0-9_]*)`)
+
+// operationName describes a GraphQL document by its operation name, or by
+// its operation type and first root field for anonymous operations
+// (e.g. "query issues")
+func operationName(query string) string {
+	m := operationPattern.FindStringSubmatch(query)
+	if m == nil {
+		return ""
+	}
+	kind, name := m[1], m[2]
+	if kind == "" {
+		kind = "query"
+	}
+	if name != "" {
+		return name
+	}
+
+	open := strings.Index(query, "{")
+	if open < 0 {
+		return kind
+	}
+	field := rootFieldPattern.FindStringSubmatch(query[open+1:])
+	if field == nil {
+		return kind
+	}
+	return kind + " " + field[1]
+}
+
+// rateLimitHeaders formats Linear's rate limit and complexity headers
+func rateLimitHeaders(header http.Header) string {
+	var parts []string
+	for name, values := range header {
+		lower := strings.ToLower(name)
+		if !strings.HasPrefix(lower, "x-ratelimit-") && lower != "x-complexity" {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", lower, strings.Join(values, ",")))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
+
+// redactHeaders copies header with credentials replaced
+func redactHeaders(header http.Header) map[string][]string {
+	out := make(map[string][]string, len(header))
+	for name, values := range header {
+		copied := make([]string, len(values))
+		for i, value := range values {
+			copied[i] = redact(value)
+		}
+		out[name] = copied
+	}
+	for _, name := range sensitiveHeaders {
+		if _, ok := out[name]; ok {
+			out[name] = []string{redacted}
+		}
+	}
+	return out
+}
+
+// redact replaces Linear API keys in s
+func redact(s string) string {
+	return apiKeyPattern.ReplaceAllString(s, "lin_${1}_"+redacted)
+}
+
+// traceBody returns body as raw JSON when it is valid JSON, or as a JSON
+// string otherwise, with API keys redacted
+func traceBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	clean := []byte(redact(string(body)))
+	if json.Valid(clean) {
+		return clean
+	}
+	quoted, _ := json.Marshal(string(clean))
+	return quoted
+}
+
+func formatSize(n int) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
@@ -0,0 +1,120 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTracingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Requests-Remaining", "1499")
+		w.Header().Set("X-Complexity", "12")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"issue":{"id":"issue-1"}}}`))
+	}))
+	defer server.Close()
+
+	var log, trace bytes.Buffer
+	client := &Client{
+		httpClient: &http.Client{},
+		apiKey:     "lin_api_secret123",
+		endpoint:   server.URL,
+	}
+	WithTransport(NewTracingTransport(nil, TraceOptions{Log: &log, Trace: &trace}))(client)
+
+	var result map[string]any
+	vars := map[string]interface{}{"id": "ENG-1", "token": "lin_api_leaked456"}
+	if err := client.Do(context.Background(), "query($id: String!) { issue(id: $id) { id } }", vars, &result); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if result["issue"] == nil {
+		t.Error("response body should still reach the client")
+	}
+
+	logLine := log.String()
+	for _, want := range []string{
+		"[debug] POST " + server.URL,
+		"query issue",
+		`"id":"ENG-1"`,
+		"-> 200 OK",
+		"x-complexity=12",
+		"x-ratelimit-requests-remaining=1499",
+	} {
+		if !strings.Contains(logLine, want) {
+			t.Errorf("debug log missing %q: %s", want, logLine)
+		}
+	}
+
+	var entry traceEntry
+	if err := json.Unmarshal(trace.Bytes(), &entry); err != nil {
+		t.Fatalf("trace is not a JSON line: %v\n%s", err, trace.String())
+	}
+	if entry.Operation != "query issue" {
+		t.Errorf("Operation = %q", entry.Operation)
+	}
+	if got := entry.Request.Headers["Authorization"]; len(got) != 1 || got[0] != redacted {
+		t.Errorf("Authorization header = %q, want redacted", got)
+	}
+	if entry.Response == nil || entry.Response.Status != http.StatusOK {
+		t.Fatalf("Response = %+v", entry.Response)
+	}
+	if !strings.Contains(string(entry.Response.Body), `"issue-1"`) {
+		t.Errorf("response body = %s", entry.Response.Body)
+	}
+
+	for name, output := range map[string]string{"log": logLine, "trace": trace.String()} {
+		if strings.Contains(output, "secret123") || strings.Contains(output, "leaked456") {
+			t.Errorf("%s leaks an API key: %s", name, output)
+		}
+	}
+}
+
+func TestTracingTransport_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	var log bytes.Buffer
+	transport := NewTracingTransport(nil, TraceOptions{Log: &log})
+	client := &http.Client{Transport: transport, Timeout: time.Second}
+
+	if _, err := client.Post(server.URL, "application/json", strings.NewReader(`{"query":"mutation { issueCreate { success } }"}`)); err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+	if !strings.Contains(log.String(), "mutation issueCreate error=") {
+		t.Errorf("debug log = %q", log.String())
+	}
+}
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "query { teams { nodes { id } } }", want: "query teams"},
+		{query: "\n\t\tquery($id: String!) {\n\t\t\tissue(id: $id) { id }", want: "query issue"},
+		{query: "mutation($input: IssueCreateInput!) { issueCreate(input: $input) { success } }", want: "mutation issueCreate"},
+		{query: "query ListIssues { issues { nodes { id } } }", want: "ListIssues"},
+		{query: "{ viewer { id } }", want: "query viewer"},
+		{query: "query { me: viewer { id } }", want: "query viewer"},
+	}
+
+	for _, tt := range tests {
+		if got := operationName(tt.query); got != tt.want {
+			t.Errorf("operationName(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	got := redact(`{"key":"lin_api_AbC123","oauth":"lin_oauth_xyz"}`)
+	want := `{"key":"lin_api_REDACTED","oauth":"lin_oauth_REDACTED"}`
+	if got != want {
+		t.Errorf("redact() = %q, want %q", got, want)
+	}
+}
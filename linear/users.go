@@ -0,0 +1,95 @@
+package linear
+
+import "context"
+
+// UsersResponse is the response for listing users
+type UsersResponse struct {
+	Users struct {
+		Nodes []User `json:"nodes"`
+	} `json:"users"`
+}
+
+// ListUsers retrieves the users in the workspace, from every page
+func (c *Client) ListUsers(ctx context.Context) (*UsersResponse, error) {
+	query := `
+		query($after: String) {
+			users(first: 250, after: $after) {
+				nodes {
+					id
+					name
+					email
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	nodes, err := allPages[User](ctx, c, query, "users")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp UsersResponse
+	resp.Users.Nodes = nodes
+	return &resp, nil
+}
+
+// ViewerResponse is the response for getting the authenticated user
+type ViewerResponse struct {
+	Viewer User `json:"viewer"`
+}
+
+// GetViewer retrieves the user the API key belongs to
+func (c *Client) GetViewer(ctx context.Context) (*User, error) {
+	query := `
+		query {
+			viewer {
+				id
+				name
+				email
+			}
+		}
+	`
+
+	var resp ViewerResponse
+	if err := c.Do(ctx, query, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Viewer, nil
+}
+
+// GetUserByEmail retrieves the user with the given email address
+func (c *Client) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	query := `
+		query($email: String!) {
+			users(filter: { email: { eq: $email} }) {
+				nodes {
+					id
+					email
+					name
+				}
+			}
+		}
+	`
+
+	vars := map[string]any{
+		"email": email,
+	}
+
+	var userRsp UsersResponse
+
+	err := c.Do(ctx, query, vars, &userRsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(userRsp.Users.Nodes) == 0 {
+		return nil, notFoundf("no user found with the provided email")
+	}
+
+	return &userRsp.Users.Nodes[0], nil
+}
@@ -1,4 +1,4 @@
-package client
+package linear
 
 import (
 	"context"
@@ -100,3 +100,24 @@ func TestGetUserByEmail_NotFound(t *testing.T) {
 
 	require.EqualError(t, err, "no user found with the provided email")
 }
+
+func TestGetViewer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := graphQLResponse{
+			Data: json.RawMessage(`{"viewer": {"id": "user-1", "name": "Ada", "email": "ada@example.com"}}`),
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiKey:     "test-key",
+		endpoint:   server.URL,
+	}
+
+	user, err := client.GetViewer(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "user-1", user.ID)
+	require.Equal(t, "ada@example.com", user.Email)
+}